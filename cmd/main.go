@@ -2,17 +2,32 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
 
 	"Avito/internal/api"
 	"Avito/internal/config"
 	"Avito/internal/controller"
+	"Avito/internal/migrations"
+	"Avito/internal/model"
 	"Avito/internal/repository"
+	"Avito/internal/storage"
+	"Avito/internal/worker"
 
 	_ "Avito/docs"
 
 	"github.com/gin-gonic/gin"
-	pgx "github.com/jackc/pgx/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -27,51 +42,338 @@ import (
 // @host           localhost:8080
 // @BasePath       /
 func main() {
+	if err := run(); err != nil {
+		logrus.Errorln(err)
+		os.Exit(1)
+	}
+}
 
-	config, err := config.LoadConfig()
+func run() error {
+	config, args, err := config.LoadConfig(os.Args[1:])
 	if err != nil {
-		logrus.Errorln("Load config: ", err)
-		panic(err)
+		return fmt.Errorf("load config: %w", err)
 	}
 
+	level, _ := logrus.ParseLevel(config.LogLevel)
+	logrus.SetLevel(level)
+
 	dbURL := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s", config.Username, config.Password, config.Host, config.Port, config.Database)
-	db, err := pgx.Connect(context.Background(), dbURL)
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return fmt.Errorf("parse DB config: %w", err)
+	}
+	if config.Pool.MaxConns > 0 {
+		poolConfig.MaxConns = config.Pool.MaxConns
+	}
+	if config.Pool.MinConns > 0 {
+		poolConfig.MinConns = config.Pool.MinConns
+	}
+	if config.Pool.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = config.Pool.MaxConnLifetime
+	}
+	if config.Pool.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = config.Pool.MaxConnIdleTime
+	}
+	if config.Pool.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = config.Pool.HealthCheckPeriod
+	}
+
+	db, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
-		logrus.Errorln("Connect to DB", err)
-		panic(err)
+		return fmt.Errorf("connect to DB: %w", err)
+	}
+	// The pool is closed only after the HTTP server has drained.
+	defer db.Close()
+
+	if err := db.Ping(context.Background()); err != nil {
+		return fmt.Errorf("ping DB: %w", err)
+	}
+
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := migrate(context.Background(), db, args[1:]); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+		return nil
 	}
-	defer db.Close(context.Background())
 
-	repository, err := repository.NewRepository(db)
+	if config.MigrateOnStart {
+		if err := migrations.Up(context.Background(), db); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+	}
+
+	repository, err := repository.NewRepository(db, config.Pool.AcquireTimeout)
 	if err != nil {
-		logrus.Errorln("Init repository", err)
-		panic(err)
+		return fmt.Errorf("init repository: %w", err)
+	}
+	reservationTTL := controller.ReservationTTL{Default: config.Reservations.TTL, Services: map[uuid.UUID]time.Duration{}}
+	for serviceID, ttl := range config.Reservations.Services {
+		reservationTTL.Services[uuid.MustParse(serviceID)] = ttl
 	}
-	controller, err := controller.NewController(repository)
+	withdrawals := controller.Withdrawals{MinBalance: map[string]model.Money{}}
+	for currency, amount := range config.Withdrawals.MinBalance {
+		// Checked by config validation.
+		withdrawals.MinBalance[currency], _ = model.ParseMoney(amount, currency)
+	}
+	fees, err := loadFees(config)
 	if err != nil {
-		logrus.Errorln("Init controller", err)
-		panic(err)
+		return fmt.Errorf("load fees: %w", err)
 	}
-	api, err := api.NewApi(controller)
+	rates, err := loadRates(config.RatesFile)
 	if err != nil {
-		logrus.Errorln("Init api", err)
-		panic(err)
+		return fmt.Errorf("load rates: %w", err)
+	}
+	reportStorage, err := newStorage(config)
+	if err != nil {
+		return fmt.Errorf("init report storage: %w", err)
+	}
+	controller, err := controller.NewController(repository, reportStorage, reservationTTL, withdrawals, fees, rates)
+	if err != nil {
+		return fmt.Errorf("init controller: %w", err)
+	}
+	if len(args) > 0 && args[0] == "reconcile" {
+		if err := reconcile(context.Background(), controller, args[1:]); err != nil {
+			return fmt.Errorf("reconcile: %w", err)
+		}
+		return nil
+	}
+
+	expiry, err := worker.NewExpiry(controller, config.Reservations.CheckInterval, config.Reservations.BatchSize)
+	if err != nil {
+		return fmt.Errorf("init expiry worker: %w", err)
+	}
+	retention, err := worker.NewRetention(controller, config.Reports.CleanupInterval, config.Reports.Retention, config.Idempotency.TTL)
+	if err != nil {
+		return fmt.Errorf("init retention worker: %w", err)
+	}
+	reports, err := worker.NewReports(controller, config.Reports.Workers, config.Reports.PollInterval, config.Reports.JobTimeout)
+	if err != nil {
+		return fmt.Errorf("init report workers: %w", err)
+	}
+	timeout := api.Timeout(config.Timeouts.Endpoints, config.Timeouts.Default)
+	api, err := api.NewApi(controller, config.BaseURL, config.AdminToken)
+	if err != nil {
+		return fmt.Errorf("init api: %w", err)
 	}
 
 	r := gin.Default()
+	r.Use(timeout, api.Errors)
+	r.GET("/health", api.Health)
 	r.GET("/balance", api.Balance)
-	r.POST("/balance", api.Enrollment)
-	r.POST("/transfer", api.Transfer)
-	r.POST("/order", api.Order)
-	r.POST("/order/success", api.OrderSuccess)
-	r.POST("/order/failed", api.OrderFailed)
+	r.POST("/balance", api.Idempotency, api.Enrollment)
+	r.POST("/transfer", api.Idempotency, api.Transfer)
+	r.POST("/withdraw", api.Idempotency, api.Withdraw)
+	r.POST("/order", api.Idempotency, api.Order)
+	r.GET("/order", api.GetOrder)
+	r.POST("/order/success", api.Idempotency, api.OrderSuccess)
+	r.POST("/order/failed", api.Idempotency, api.OrderFailed)
+	r.POST("/order/refund", api.Idempotency, api.Refund)
 	r.POST("/report", api.Report)
 	r.GET("/report/csv", api.CsvReport)
+	r.GET("/report/:id", api.ReportJob)
 	r.GET("/history", api.History)
+	r.POST("/admin/reconcile", api.Admin, api.Reconcile)
+	r.GET("/admin/rates", api.Admin, api.Rates)
+	r.PUT("/admin/rates", api.Admin, api.SetRates)
+	r.GET("/admin/services", api.Admin, api.Services)
+	r.POST("/admin/services", api.Admin, api.CreateService)
+	r.GET("/admin/services/:id", api.Admin, api.Service)
+	r.PUT("/admin/services/:id", api.Admin, api.UpdateService)
+	r.DELETE("/admin/services/:id", api.Admin, api.DeleteService)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	err = r.Run(":8080")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The workers are waited for before the deferred pool close.
+	var workers sync.WaitGroup
+	defer workers.Wait()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		expiry.Run(ctx)
+	}()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		reports.Run(ctx)
+	}()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		retention.Run(ctx)
+	}()
+
+	return serve(ctx, stop, &http.Server{Addr: config.ListenAddress, Handler: r}, config.ShutdownTimeout)
+}
+
+// serve runs the server until ctx is done (SIGINT or SIGTERM), then stops accepting connections
+// and waits up to grace for in-flight requests. Requests still running after that
+// are cut off, which cancels their contexts and rolls back their transactions.
+func serve(ctx context.Context, stop context.CancelFunc, server *http.Server, grace time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		logrus.Infof("Listening on %s\n", server.Addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		stop()
+		return fmt.Errorf("router run: %w", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	logrus.Infof("Shutting down, waiting up to %s for in-flight requests\n", grace)
+
+	shutdownCtx := context.Background()
+	if grace > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, grace)
+		defer cancel()
+	}
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logrus.Errorln("Shutdown: ", err)
+		if err := server.Close(); err != nil {
+			logrus.Errorln("Close: ", err)
+		}
+	}
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("router run: %w", err)
+	}
+
+	logrus.Infoln("Server stopped")
+	return nil
+}
+
+// newStorage picks the report storage named in the configuration.
+func newStorage(config *config.Config) (controller.IStorage, error) {
+	switch config.Reports.Storage {
+	case "memory":
+		return storage.NewMemory(), nil
+	case "s3":
+		s3 := config.Reports.S3
+		return storage.NewS3(storage.S3Config{Endpoint: s3.Endpoint, Region: s3.Region, Bucket: s3.Bucket, Prefix: s3.Prefix, AccessKey: s3.AccessKey, SecretKey: s3.SecretKey})
+	default:
+		return storage.NewLocal(config.ReportsDir), nil
+	}
+}
+
+// loadFees builds the commission rules of the fees section of the config.
+func loadFees(config *config.Config) (controller.Fees, error) {
+	transfers, err := config.Fees.Transfers.Rule()
+	if err != nil {
+		return controller.Fees{}, err
+	}
+	orders, err := config.Fees.Orders.Rule()
+	if err != nil {
+		return controller.Fees{}, err
+	}
+	fees := controller.Fees{Transfers: transfers, Orders: orders, Services: map[uuid.UUID]model.FeeRule{}}
+	for serviceID, f := range config.Fees.Services {
+		rule, err := f.Rule()
+		if err != nil {
+			return controller.Fees{}, err
+		}
+		// Checked by config validation.
+		fees.Services[uuid.MustParse(serviceID)] = rule
+	}
+	return fees, nil
+}
+
+// loadRates reads the exchange rate table from a JSON file; without a file only
+// model.DefaultCurrency is supported.
+func loadRates(name string) (model.Rates, error) {
+	if name == "" {
+		return model.DefaultRates(), nil
+	}
+	data, err := os.ReadFile(name)
 	if err != nil {
-		logrus.Errorln("Router run: ", err)
-		panic(err)
+		return model.Rates{}, err
+	}
+	rates := model.Rates{}
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return model.Rates{}, fmt.Errorf("%s: %w", name, err)
+	}
+	if err := rates.Validate(); err != nil {
+		return model.Rates{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return rates, nil
+}
+
+// reconcile handles the "reconcile" subcommand and prints the discrepancies to stdout:
+// reconcile [--fix] [--format json|csv]
+func reconcile(ctx context.Context, controller controller.IController, args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	fix := fs.Bool("fix", false, "post correcting entries")
+	format := fs.String("format", "json", "output format: json or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format: %s", *format)
+	}
+
+	discrepancies, err := controller.Reconcile(ctx, *fix)
+	if err != nil {
+		return err
+	}
+
+	if *format == "csv" {
+		return model.WriteDiscrepanciesCSV(os.Stdout, discrepancies)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(discrepancies)
+}
+
+// migrate handles the "migrate" subcommand:
+// migrate up | migrate down [steps] | migrate status | migrate baseline <version>
+func migrate(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		return migrations.Up(ctx, db)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("wrong number of steps: %s", args[1])
+			}
+			steps = n
+		}
+		return migrations.Down(ctx, db, steps)
+	case "baseline":
+		if len(args) < 2 {
+			return errors.New("baseline needs a version")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("wrong version: %s", args[1])
+		}
+		return migrations.Baseline(ctx, db, version)
+	case "status":
+		statuses, err := migrations.CurrentStatus(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command: %s", command)
 	}
 }
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// Admin lets a request through only with "Authorization: Bearer <admin token>".
+// Without a configured token the admin endpoints are closed.
+func (a *api) Admin(c *gin.Context) {
+	header := c.GetHeader("Authorization")
+	token := strings.TrimPrefix(header, "Bearer ")
+	if a.adminToken == "" || token == header || subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) != 1 {
+		logrus.Errorf("Admin %s %s: %s\n", c.Request.Method, c.FullPath(), Err.ErrForbidden)
+		_ = c.Error(Err.ErrForbidden)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// @Summary      Reconcile
+// @Description  Сверяет балансы пользователей с журналом операций и при fix=true записывает корректирующие проводки
+// @Tags         admin
+// @Produce      json,text/csv
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Param        fix    query  bool   false "Записать корректирующие проводки"
+// @Param        format query  string false "json или csv"
+// @Success		 200 {array}  model.Discrepancy
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 403 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /admin/reconcile [post]
+func (a *api) Reconcile(c *gin.Context) {
+	logrus.Infoln("Starting api.Reconcile")
+
+	var details []Err.FieldError
+	fix := false
+	if f := c.Query("fix"); f != "" {
+		var err error
+		if fix, err = strconv.ParseBool(f); err != nil {
+			details = append(details, Err.Field("fix", "must be a boolean"))
+		}
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		details = append(details, Err.Field("format", "must be json or csv"))
+	}
+	if len(details) > 0 {
+		logrus.Errorf("%s, fix: %s, format: %s\n", Err.ErrBadRequest, c.Query("fix"), format)
+		_ = c.Error(Err.Validation(details...))
+		logrus.Infoln("Ending api.Reconcile")
+		return
+	}
+
+	discrepancies, err := a.controller.Reconcile(c.Request.Context(), fix)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Reconcile")
+		return
+	}
+
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := model.WriteDiscrepanciesCSV(c.Writer, discrepancies); err != nil {
+			logrus.Errorln("Write: ", err)
+		}
+		logrus.Infoln("Ending api.Reconcile")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, discrepancies)
+	logrus.Infoln("Ending api.Reconcile")
+}
+
+// @Summary      Rates
+// @Description  Предоставляет текущую таблицу курсов валют
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Success		 200 {object} model.Rates
+// @Failure 	 403 {object} errors.Error
+// @Router       /admin/rates [get]
+func (a *api) Rates(c *gin.Context) {
+	logrus.Infoln("Starting api.Rates")
+
+	c.IndentedJSON(http.StatusOK, a.controller.Rates(c.Request.Context()))
+	logrus.Infoln("Ending api.Rates")
+}
+
+// @Summary      SetRates
+// @Description  Заменяет таблицу курсов валют: одна единица base стоит rates[код] единиц валюты
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Param        rates body model.Rates true "Таблица курсов"
+// @Success		 200 {object} model.Rates
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 403 {object} errors.Error
+// @Router       /admin/rates [put]
+func (a *api) SetRates(c *gin.Context) {
+	logrus.Infoln("Starting api.SetRates")
+
+	rates := model.Rates{}
+	if err := decode(c, &rates); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.SetRates")
+		return
+	}
+
+	if err := a.controller.SetRates(c.Request.Context(), rates); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.SetRates")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, rates)
+	logrus.Infoln("Ending api.SetRates")
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		status int
+	}{
+		{"no token configured", "", "Bearer ", http.StatusForbidden},
+		{"no header", "secret", "", http.StatusForbidden},
+		{"wrong scheme", "secret", "secret", http.StatusForbidden},
+		{"wrong token", "secret", "Bearer other", http.StatusForbidden},
+		{"valid", "secret", "Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &api{adminToken: tt.token}
+			r := gin.New()
+			r.Use(a.Errors)
+			r.POST("/", a.Admin, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
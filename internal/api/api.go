@@ -1,66 +1,131 @@
 package api
 
 import (
+	"context"
 	"encoding/csv"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	Err "Avito/internal/errors"
+	"Avito/internal/export"
 	"Avito/internal/model"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"github.com/sirupsen/logrus"
 )
 
 type IApi interface {
+	Errors(c *gin.Context)
+	Idempotency(c *gin.Context)
+	Health(c *gin.Context)
 	Balance(c *gin.Context)
 	Enrollment(c *gin.Context)
 	Transfer(c *gin.Context)
+	Withdraw(c *gin.Context)
 	Order(c *gin.Context)
+	GetOrder(c *gin.Context)
 	OrderSuccess(c *gin.Context)
 	OrderFailed(c *gin.Context)
+	Refund(c *gin.Context)
 	Report(c *gin.Context)
+	ReportJob(c *gin.Context)
 	CsvReport(c *gin.Context)
 	History(c *gin.Context)
+	Admin(c *gin.Context)
+	Reconcile(c *gin.Context)
+	Rates(c *gin.Context)
+	SetRates(c *gin.Context)
+	Services(c *gin.Context)
+	Service(c *gin.Context)
+	CreateService(c *gin.Context)
+	UpdateService(c *gin.Context)
+	DeleteService(c *gin.Context)
 }
 
+// maxTTLSeconds caps the reservation TTL a client may ask for (a year).
+const maxTTLSeconds = 365 * 24 * 60 * 60
+
 type api struct {
 	controller IController
+	baseURL    string
+	adminToken string
 }
 
-func NewApi(controller IController) (IApi, error) {
+// NewApi builds the handlers. baseURL prefixes report links, without it they are relative
+// paths; an empty adminToken closes the admin endpoints.
+func NewApi(controller IController, baseURL, adminToken string) (IApi, error) {
 	if controller == nil {
 		return nil, Err.ErrNoController
 	}
-	return &api{controller: controller}, nil
+	return &api{controller: controller, baseURL: strings.TrimSuffix(baseURL, "/"), adminToken: adminToken}, nil
 }
 
 type IController interface {
-	Balance(userID uuid.UUID) (*model.User, error)
-	Enrollment(userID uuid.UUID, funds float64) error
-	Transfer(senderID, recipientID uuid.UUID, funds float64) error
-	Order(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error
-	OrderSuccess(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error
-	OrderFailed(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error
-	Report(year, month string) (string, error)
-	History(userID uuid.UUID, offset, limit int) ([]model.History, error)
+	Health(ctx context.Context) error
+	Balance(ctx context.Context, userID uuid.UUID, currency string) (*model.User, error)
+	Enrollment(ctx context.Context, userID uuid.UUID, funds model.Money) error
+	Transfer(ctx context.Context, senderID, recipientID uuid.UUID, funds model.Money) error
+	Withdraw(ctx context.Context, userID uuid.UUID, funds model.Money) error
+	Order(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money, ttl time.Duration) error
+	GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error)
+	OrderSuccess(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money) error
+	OrderFailed(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money) error
+	Refund(ctx context.Context, orderID uuid.UUID, amount model.Money) error
+	Report(ctx context.Context, q model.ReportQuery) ([]model.Report, error)
+	EnqueueReport(ctx context.Context, q model.ReportQuery) (*model.ReportJob, error)
+	ReportJob(ctx context.Context, id uuid.UUID) (*model.ReportJob, error)
+	OpenReport(ctx context.Context, id uuid.UUID) (io.ReadCloser, error)
+	History(ctx context.Context, q model.HistoryQuery) (*model.HistoryPage, error)
+	Reconcile(ctx context.Context, fix bool) ([]model.Discrepancy, error)
+	Rates(ctx context.Context) model.Rates
+	SetRates(ctx context.Context, rates model.Rates) error
+	StartIdempotent(ctx context.Context, key, requestHash string) (*model.Idempotency, error)
+	FinishIdempotent(ctx context.Context, key string, status int, body []byte) error
+	ReleaseIdempotent(ctx context.Context, key string) error
+	Services(ctx context.Context) ([]model.Service, error)
+	Service(ctx context.Context, id uuid.UUID) (*model.Service, error)
+	CreateService(ctx context.Context, s model.Service) (*model.Service, error)
+	UpdateService(ctx context.Context, s model.Service) (*model.Service, error)
+	DeleteService(ctx context.Context, id uuid.UUID) error
+}
+
+// @Summary      Health
+// @Description  Проверяет доступность базы данных
+// @Tags         health
+// @Produce      json
+// @Success		 200 {object} message
+// @Failure 	 503 {object} errors.Error
+// @Router       /health [get]
+func (a *api) Health(c *gin.Context) {
+	logrus.Infoln("Starting api.Health")
+
+	if err := a.controller.Health(c.Request.Context()); err != nil {
+		_ = c.Error(Err.ErrUnavailable)
+		logrus.Infoln("Ending api.Health")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, message{Message: "OK"})
+	logrus.Infoln("Ending api.Health")
 }
 
 // @Summary      Balance
 // @Description  Предоставляет информацию о пользователе
 // @Tags         balance
 // @Produce      json
-// @Param        id   query   string  true "UserID"
+// @Param        id       query   string  true  "UserID"
+// @Param        currency query   string  false "Валюта, в которую пересчитать все кошельки"
 // @Success		 200 {object} model.User
-// @Failure 	 400 {object} message
-// @Failure 	 404 {object} message
-// @Failure 	 500 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /balance [get]
 func (a *api) Balance(c *gin.Context) {
 	logrus.Infoln("Starting api.Balance")
@@ -69,22 +134,24 @@ func (a *api) Balance(c *gin.Context) {
 	userID, err := uuid.Parse(arg)
 	if err != nil {
 		logrus.Errorf("Parse %s: %s\n", arg, err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(Err.Validation(Err.Field("id", "must be a UUID")))
 		logrus.Infoln("Ending api.Balance")
 		return
 	}
 
-	user, err := a.controller.Balance(userID)
+	currency := strings.ToUpper(c.Query("currency"))
+	if currency != "" && !model.ValidCurrency(currency) {
+		logrus.Errorf("%s currency: %s\n", Err.ErrBadRequest, currency)
+		_ = c.Error(Err.Validation(Err.Field("currency", "must be a currency code")))
+		logrus.Infoln("Ending api.Balance")
+		return
+	}
+
+	user, err := a.controller.Balance(c.Request.Context(), userID, currency)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			c.IndentedJSON(http.StatusNotFound, message{Message: "Not found"})
-			logrus.Infoln("Ending api.Balance")
-			return
-		} else {
-			c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
-			logrus.Infoln("Ending api.Balance")
-			return
-		}
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Balance")
+		return
 	}
 
 	c.IndentedJSON(http.StatusOK, user)
@@ -96,31 +163,38 @@ func (a *api) Balance(c *gin.Context) {
 // @Tags         balance
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Ключ идемпотентности"
 // @Success		 200 {object} message
-// @Failure 	 400 {object} message
-// @Failure 	 500 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /balance [post]
 func (a *api) Enrollment(c *gin.Context) {
 	logrus.Infoln("Starting api.Enrollment")
 
 	u := user{}
-	if err := json.NewDecoder(c.Request.Body).Decode(&u); err != nil {
-		logrus.Errorln("Decoding: ", err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	if err := decode(c, &u); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Enrollment")
+		return
+	}
+
+	var err error
+	if u.Funds, err = inCurrency(u.Funds, u.Currency); err != nil {
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.Enrollment")
 		return
 	}
 
-	if u.Funds <= 0 {
+	if !u.Funds.IsPositive() {
 		logrus.Errorf("%s: %s", u.Funds, Err.ErrBadRequest)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(Err.Validation(Err.Field("funds", "must be positive")))
 		logrus.Infoln("Ending api.Enrollment")
 		return
 	}
 
-	err := a.controller.Enrollment(u.ID, u.Funds)
+	err = a.controller.Enrollment(c.Request.Context(), u.ID, u.Funds)
 	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.Enrollment")
 		return
 	}
@@ -134,145 +208,219 @@ func (a *api) Enrollment(c *gin.Context) {
 // @Tags         balance
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Ключ идемпотентности"
 // @Success		 200 {object} message
-// @Failure 	 400 {object} message
-// @Failure 	 404 {object} message
-// @Failure 	 500 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /transfer [post]
 func (a *api) Transfer(c *gin.Context) {
 	logrus.Infoln("Starting api.Transfer")
 
 	t := transfer{}
-	if err := json.NewDecoder(c.Request.Body).Decode(&t); err != nil {
-		logrus.Errorln("Decoding: ", err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	if err := decode(c, &t); err != nil {
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.Transfer")
 		return
 	}
 
-	if t.Funds <= 0 {
+	var err error
+	if t.Funds, err = inCurrency(t.Funds, t.Currency); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Transfer")
+		return
+	}
+
+	if !t.Funds.IsPositive() {
 		logrus.Errorf("%s: %s", t.Funds, Err.ErrBadRequest)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(Err.Validation(Err.Field("funds", "must be positive")))
 		logrus.Infoln("Ending api.Transfer")
 		return
 	}
 
-	err := a.controller.Transfer(t.SenderID, t.RecipientID, t.Funds)
+	err = a.controller.Transfer(c.Request.Context(), t.SenderID, t.RecipientID, t.Funds)
 	if err != nil {
-		switch {
-		case errors.Is(err, Err.ErrInsufficientFunds):
-			c.IndentedJSON(http.StatusBadRequest, message{Message: "Insufficient funds"})
-			logrus.Infoln("Ending api.Transfer")
-			return
-		case errors.Is(err, pgx.ErrNoRows):
-			c.IndentedJSON(http.StatusNotFound, message{Message: "Not found"})
-			logrus.Infoln("Ending api.Transfer")
-			return
-		default:
-			c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
-			logrus.Infoln("Ending api.Transfer")
-			return
-		}
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Transfer")
+		return
 	}
 
 	c.IndentedJSON(http.StatusOK, message{Message: "Success"})
 	logrus.Infoln("Ending api.Transfer")
 }
 
+// @Summary      Withdraw
+// @Description  Вывод средств пользователя из сервиса
+// @Tags         balance
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key header string false "Ключ идемпотентности"
+// @Success		 200 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /withdraw [post]
+func (a *api) Withdraw(c *gin.Context) {
+	logrus.Infoln("Starting api.Withdraw")
+
+	w := withdrawal{}
+	if err := decode(c, &w); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Withdraw")
+		return
+	}
+
+	var err error
+	if w.Funds, err = inCurrency(w.Funds, w.Currency); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Withdraw")
+		return
+	}
+
+	if !w.Funds.IsPositive() {
+		logrus.Errorf("%s: %s", w.Funds, Err.ErrBadRequest)
+		_ = c.Error(Err.Validation(Err.Field("funds", "must be positive")))
+		logrus.Infoln("Ending api.Withdraw")
+		return
+	}
+
+	err = a.controller.Withdraw(c.Request.Context(), w.UserID, w.Funds)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Withdraw")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, message{Message: "Success"})
+	logrus.Infoln("Ending api.Withdraw")
+}
+
 // @Summary      Order
-// @Description  Заказ пользователем услуги
+// @Description  Заказ пользователем услуги из каталога; service_name можно не передавать
 // @Tags         order
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Ключ идемпотентности"
 // @Success		 200 {object} message
-// @Failure 	 400 {object} message
-// @Failure 	 404 {object} message
-// @Failure 	 500 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /order [post]
 func (a *api) Order(c *gin.Context) {
 	logrus.Infoln("Starting api.Order")
 
 	o := order{}
-	if err := json.NewDecoder(c.Request.Body).Decode(&o); err != nil {
-		logrus.Errorln("Decoding: ", err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	if err := decode(c, &o); err != nil {
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.Order")
 		return
 	}
 
-	if o.Cost <= 0 {
+	var err error
+	if o.Cost, err = inCurrency(o.Cost, o.Currency); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Order")
+		return
+	}
+
+	if !o.Cost.IsPositive() {
 		logrus.Errorf("%s: %s", o.Cost, Err.ErrBadRequest)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(Err.Validation(Err.Field("cost", "must be positive")))
 		logrus.Infoln("Ending api.Order")
 		return
 	}
 
-	if o.ServiceName == "" {
-		logrus.Errorln(Err.ErrBadRequest)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	if o.TTLSeconds < 0 || o.TTLSeconds > maxTTLSeconds {
+		logrus.Errorf("%s ttl: %d\n", Err.ErrBadRequest, o.TTLSeconds)
+		_ = c.Error(Err.Validation(Err.Field("ttl_seconds", "must be between 0 and 31536000")))
 		logrus.Infoln("Ending api.Order")
 		return
 	}
 
-	err := a.controller.Order(o.UserID, o.ServiceID, o.OrderID, o.ServiceName, o.Cost)
+	err = a.controller.Order(c.Request.Context(), o.UserID, o.ServiceID, o.OrderID, o.ServiceName, o.Cost, time.Duration(o.TTLSeconds)*time.Second)
 	if err != nil {
-		switch {
-		case errors.Is(err, Err.ErrInsufficientFunds):
-			c.IndentedJSON(http.StatusBadRequest, message{Message: "Insufficient funds"})
-			logrus.Infoln("Ending api.Order")
-			return
-		case errors.Is(err, pgx.ErrNoRows):
-			c.IndentedJSON(http.StatusNotFound, message{Message: "Not found"})
-			logrus.Infoln("Ending api.Order")
-			return
-		default:
-			c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
-			logrus.Infoln("Ending api.Order")
-			return
-		}
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Order")
+		return
 	}
 
 	c.IndentedJSON(http.StatusOK, message{Message: "Success"})
 	logrus.Infoln("Ending api.Order")
 }
 
+// @Summary      GetOrder
+// @Description  Предоставляет текущее состояние заказа
+// @Tags         order
+// @Produce      json
+// @Param        id   query   string  true "OrderID"
+// @Success		 200 {object} model.Order
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /order [get]
+func (a *api) GetOrder(c *gin.Context) {
+	logrus.Infoln("Starting api.GetOrder")
+
+	arg := c.Query("id")
+	orderID, err := uuid.Parse(arg)
+	if err != nil {
+		logrus.Errorf("Parse %s: %s\n", arg, err)
+		_ = c.Error(Err.Validation(Err.Field("id", "must be a UUID")))
+		logrus.Infoln("Ending api.GetOrder")
+		return
+	}
+
+	order, err := a.controller.GetOrder(c.Request.Context(), orderID)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.GetOrder")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, order)
+	logrus.Infoln("Ending api.GetOrder")
+}
+
 // @Summary      Success order
 // @Description  Успешное выполнение услуги
 // @Tags         order
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Ключ идемпотентности"
 // @Success		 200 {object} message
-// @Failure 	 400 {object} message
-// @Failure 	 404 {object} message
-// @Failure 	 500 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /order/success [post]
 func (a *api) OrderSuccess(c *gin.Context) {
 	logrus.Infoln("Starting api.OrderSuccess")
 
 	o := order{}
-	if err := json.NewDecoder(c.Request.Body).Decode(&o); err != nil {
-		logrus.Errorln("Decoding: ", err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	if err := decode(c, &o); err != nil {
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.OrderSuccess")
 		return
 	}
 
-	err := a.controller.OrderSuccess(o.UserID, o.ServiceID, o.OrderID, o.ServiceName, o.Cost)
+	var err error
+	if o.Cost, err = inCurrency(o.Cost, o.Currency); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.OrderSuccess")
+		return
+	}
+
+	if !o.Cost.IsPositive() {
+		logrus.Errorf("%s: %s", o.Cost, Err.ErrBadRequest)
+		_ = c.Error(Err.Validation(Err.Field("cost", "must be positive")))
+		logrus.Infoln("Ending api.OrderSuccess")
+		return
+	}
+
+	err = a.controller.OrderSuccess(c.Request.Context(), o.UserID, o.ServiceID, o.OrderID, o.ServiceName, o.Cost)
 	if err != nil {
-		switch {
-		case errors.Is(err, pgx.ErrNoRows):
-			c.IndentedJSON(http.StatusBadRequest, message{Message: "Not found"})
-			logrus.Infoln("Ending api.OrderSuccess")
-			return
-		case errors.Is(err, Err.ErrBadRequest):
-			c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
-			logrus.Infoln("Ending api.OrderSuccess")
-			return
-		default:
-			c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
-			logrus.Infoln("Ending api.OrderSuccess")
-			return
-		}
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.OrderSuccess")
+		return
 	}
 
 	c.IndentedJSON(http.StatusOK, message{Message: "Success"})
@@ -284,142 +432,324 @@ func (a *api) OrderSuccess(c *gin.Context) {
 // @Tags         order
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Ключ идемпотентности"
 // @Success		 200 {object} message
-// @Failure 	 400 {object} message
-// @Failure 	 404 {object} message
-// @Failure 	 500 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /order/failed [post]
 func (a *api) OrderFailed(c *gin.Context) {
 	logrus.Infoln("Starting api.OrderFailed")
 
 	o := order{}
-	if err := json.NewDecoder(c.Request.Body).Decode(&o); err != nil {
-		logrus.Errorln("Decoding: ", err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	if err := decode(c, &o); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.OrderFailed")
+		return
+	}
+
+	var err error
+	if o.Cost, err = inCurrency(o.Cost, o.Currency); err != nil {
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.OrderFailed")
 		return
 	}
 
-	err := a.controller.OrderFailed(o.UserID, o.ServiceID, o.OrderID, o.ServiceName, o.Cost)
+	err = a.controller.OrderFailed(c.Request.Context(), o.UserID, o.ServiceID, o.OrderID, o.ServiceName, o.Cost)
 	if err != nil {
-		switch {
-		case errors.Is(err, pgx.ErrNoRows):
-			c.IndentedJSON(http.StatusBadRequest, message{Message: "Not found"})
-			logrus.Infoln("Ending api.OrderFailed")
-			return
-		case errors.Is(err, Err.ErrBadRequest):
-			c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
-			logrus.Infoln("Ending api.OrderFailed")
-			return
-		default:
-			c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
-			logrus.Infoln("Ending api.OrderFailed")
-			return
-		}
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.OrderFailed")
+		return
 	}
 
 	c.IndentedJSON(http.StatusOK, message{Message: "Success"})
 	logrus.Infoln("Ending api.OrderFailed")
 }
 
+// @Summary      Refund
+// @Description  Частичный или полный возврат средств по подтвержденному заказу
+// @Tags         order
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key header string false "Ключ идемпотентности"
+// @Success		 200 {object} message
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 409 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /order/refund [post]
+func (a *api) Refund(c *gin.Context) {
+	logrus.Infoln("Starting api.Refund")
+
+	r := refund{}
+	if err := decode(c, &r); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Refund")
+		return
+	}
+
+	var err error
+	if r.Amount, err = inCurrency(r.Amount, r.Currency); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Refund")
+		return
+	}
+
+	if !r.Amount.IsPositive() {
+		logrus.Errorf("%s: %s", r.Amount, Err.ErrBadRequest)
+		_ = c.Error(Err.Validation(Err.Field("amount", "must be positive")))
+		logrus.Infoln("Ending api.Refund")
+		return
+	}
+
+	err = a.controller.Refund(c.Request.Context(), r.OrderID, r.Amount)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Refund")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, message{Message: "Success"})
+	logrus.Infoln("Ending api.Refund")
+}
+
 // @Summary      Report
-// @Description  Предоставляет ссылку на месячный отчет по пользователям
+// @Description  Ставит в очередь отчет о выручке за месяц или период и возвращает задание; с format=json сразу возвращает строки отчета, построенные в пределах таймаута запроса
 // @Tags         report
 // @Accept       json
 // @Produce      json
-// @Success		 200 {object} message
-// @Failure 	 400 {object} message
-// @Failure 	 500 {object} message
+// @Param        report body report true "период, группировка и формат отчета"
+// @Success		 202 {object} reportJob
+// @Success		 200 {array} model.Report
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /report [post]
 func (a *api) Report(c *gin.Context) {
 	logrus.Infoln("Starting api.Report")
 
 	r := report{}
-	if err := json.NewDecoder(c.Request.Body).Decode(&r); err != nil {
-		logrus.Errorln("Deconding: ", err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	if err := decode(c, &r); err != nil {
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.Report")
 		return
 	}
-
-	str, err := a.controller.Report(r.Year, r.Month)
+	q, err := reportQuery(r)
 	if err != nil {
-		if errors.Is(err, Err.ErrBadRequest) {
-			c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Report")
+		return
+	}
+
+	switch r.Format {
+	case "json":
+		// The rows are grouped by the database and few, so they are built within the request
+		// timeout; reports that do not fit go through the queue and download as JSON Lines.
+		rows, err := a.controller.Report(c.Request.Context(), q)
+		if err != nil {
+			_ = c.Error(err)
 			logrus.Infoln("Ending api.Report")
 			return
-		} else {
-			c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
+		}
+		if rows == nil {
+			rows = []model.Report{}
+		}
+		c.IndentedJSON(http.StatusOK, rows)
+	case "", "csv":
+		job, err := a.controller.EnqueueReport(c.Request.Context(), q)
+		if err != nil {
+			_ = c.Error(err)
 			logrus.Infoln("Ending api.Report")
 			return
 		}
+		c.Header("Location", a.url("/report/"+job.ID.String()))
+		c.IndentedJSON(http.StatusAccepted, a.reportJob(job))
+	default:
+		logrus.Errorf("%s format: %s\n", Err.ErrBadRequest, r.Format)
+		_ = c.Error(Err.Validation(Err.Field("format", "must be csv or json")))
 	}
 
-	c.IndentedJSON(http.StatusOK, message{Message: "http://localhost:8080/report/csv?id=" + str})
 	logrus.Infoln("Ending api.Report")
 }
 
+// @Summary      ReportJob
+// @Description  Предоставляет состояние отчета из очереди: pending, running, done (со ссылкой на файл) или failed (с причиной)
+// @Tags         report
+// @Produce      json
+// @Param        id   path   string  true "report ID"
+// @Success      200 {object} reportJob
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /report/{id} [get]
+func (a *api) ReportJob(c *gin.Context) {
+	logrus.Infoln("Starting api.ReportJob")
+
+	arg := c.Param("id")
+	id, err := uuid.Parse(arg)
+	if err != nil {
+		logrus.Errorf("Parse %s: %s\n", arg, err)
+		_ = c.Error(Err.ErrReportNotFound)
+		logrus.Infoln("Ending api.ReportJob")
+		return
+	}
+
+	job, err := a.controller.ReportJob(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.ReportJob")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, a.reportJob(job))
+	logrus.Infoln("Ending api.ReportJob")
+}
+
 // @Summary      CsvReport
-// @Description  Предоставляет месячный отчет по пользователям
+// @Description  Отдает файл отчета в формате csv (по умолчанию), xlsx или jsonl
 // @Tags         report
-// @Produce      plain
-// @Param        id   query   string  true "file ID"
-// @Success      200 {array}  string
-// @Failure 	 400 {object} message
-// @Failure 	 500 {object} message
+// @Produce      text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/jsonl
+// @Param        id         query   string  true  "file ID"
+// @Param        format     query   string  false "csv, xlsx или jsonl"
+// @Param        delimiter  query   string  false "разделитель полей csv, по умолчанию запятая; tab - табуляция"
+// @Param        header     query   bool    false "строка с названиями столбцов в csv и xlsx, по умолчанию true"
+// @Success      200 {file}   file
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
 // @Router       /report/csv [get]
 func (a *api) CsvReport(c *gin.Context) {
 	logrus.Infoln("Starting api.CsvReport")
 
-	id := c.Query("id")
-	name := fmt.Sprintf("./reports/%s.csv", id)
-
-	if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
-		logrus.Errorf("Stat %s: %s\n", name, err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	arg := c.Query("id")
+	id, err := uuid.Parse(arg)
+	if err != nil {
+		logrus.Errorf("Parse %s: %s\n", arg, err)
+		_ = c.Error(Err.ErrReportNotFound)
+		logrus.Infoln("Ending api.CsvReport")
+		return
+	}
+	format, options, err := exportOptions(c)
+	if err != nil {
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.CsvReport")
 		return
 	}
 
-	file, err := os.Open(name)
+	file, err := a.controller.OpenReport(c.Request.Context(), id)
 	if err != nil {
-		logrus.Errorf("Open %s: %s\n", name, err)
-		c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal Error"})
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.CsvReport")
 		return
 	}
+	defer file.Close()
 
-	records, err := csv.NewReader(file).ReadAll()
+	// Report files are CSV with a header row; they are converted row by row as they are read.
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	columns, err := reader.Read()
 	if err != nil {
-		logrus.Errorln("ReadALL: ", err)
-		c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal Error"})
+		logrus.Errorf("Read %s: %s\n", id, err)
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.CsvReport")
 		return
 	}
 
-	for _, str := range records {
-		for i, s := range str {
-			if i == 0 {
-			} else {
-				c.Writer.Write([]byte(";"))
+	out := &attachment{c: c, name: fmt.Sprintf("report-%s.%s", id, format), contentType: format.ContentType()}
+	writer, err := export.New(format, out, columns, options)
+	if err != nil {
+		logrus.Errorf("New %s writer: %s\n", format, err)
+		_ = c.Error(exportError(err))
+		logrus.Infoln("Ending api.CsvReport")
+		return
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			err = writer.Write(record)
+		}
+		if err != nil {
+			logrus.Errorf("Write %s: %s\n", id, err)
+			// Once the file has started, the client only sees it cut.
+			if !out.started {
+				_ = c.Error(err)
 			}
-			c.Writer.Write([]byte(s))
+			logrus.Infoln("Ending api.CsvReport")
+			return
+		}
+	}
+	if err := writer.Close(); err != nil {
+		logrus.Errorf("Close %s: %s\n", id, err)
+		if !out.started {
+			_ = c.Error(err)
 		}
-		c.Writer.Write([]byte("\n"))
+		logrus.Infoln("Ending api.CsvReport")
+		return
 	}
+	// An empty JSON Lines file has no bytes at all.
+	out.start()
 
 	logrus.Infoln("Ending api.CsvReport")
 }
 
+// attachment sends a file download. The status and the headers go out with the first byte
+// of the file, so an error before that is still answered by the Errors middleware.
+type attachment struct {
+	c           *gin.Context
+	name        string
+	contentType string
+	started     bool
+}
+
+func (a *attachment) start() {
+	if a.started {
+		return
+	}
+	a.started = true
+	a.c.Header("Content-Type", a.contentType)
+	a.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, a.name))
+	a.c.Status(http.StatusOK)
+}
+
+func (a *attachment) Write(b []byte) (int, error) {
+	a.start()
+	return a.c.Writer.Write(b)
+}
+
+// exportError describes the export options the writer refused.
+func exportError(err error) error {
+	switch {
+	case errors.Is(err, export.ErrBadFormat):
+		return Err.Validation(Err.Field("format", "must be csv, xlsx or jsonl"))
+	case errors.Is(err, export.ErrBadDelimiter):
+		return Err.Validation(Err.Field("delimiter", "must be one character or tab"))
+	default:
+		return err
+	}
+}
+
 // @Summary      History
-// @Description  Предоставляет историю заказов пользователя
+// @Description  Предоставляет историю операций пользователя с сортировкой и фильтрами
 // @Tags         report
 // @Produce      json
-// @Success		 200 {array}  model.History
-// @Failure 	 400 {object} message
-// @Failure 	 404 {object} message
-// @Failure 	 500 {object} message
-// @Router       /history [post]
+// @Param        id          query string   true  "id пользователя"
+// @Param        limit       query int      true  "размер страницы"
+// @Param        offset      query int      false "смещение"
+// @Param        cursor      query string   false "курсор следующей страницы; пустой для первой страницы"
+// @Param        total       query bool     false "посчитать число записей"
+// @Param        sort        query string   false "date или amount"
+// @Param        order       query string   false "asc или desc"
+// @Param        from        query string   false "первый день, YYYY-MM-DD"
+// @Param        to          query string   false "последний день, YYYY-MM-DD"
+// @Param        type        query []string false "типы операций" collectionFormat(csv)
+// @Param        min_amount  query number   false "минимальная сумма по модулю"
+// @Param        max_amount  query number   false "максимальная сумма по модулю"
+// @Success		 200 {object} historyPage "с cursor или total, иначе массив model.History"
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /history [get]
 func (a *api) History(c *gin.Context) {
 	logrus.Infoln("Starting api.History")
 
@@ -427,49 +757,235 @@ func (a *api) History(c *gin.Context) {
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		logrus.Errorf("Parse %s: %s\n", id, err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(Err.Validation(Err.Field("id", "must be a UUID")))
 		logrus.Infoln("Ending api.History")
 		return
 	}
 
-	l := c.Query("limit")
-	limit, err := strconv.Atoi(l)
+	q, err := historyQuery(c)
 	if err != nil {
-		logrus.Errorf("Atoi %s: %s\n", l, err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.History")
 		return
 	}
+	q.UserID = userID
 
-	o := c.Query("offset")
-	offset, err := strconv.Atoi(o)
+	page, err := a.controller.History(c.Request.Context(), q)
 	if err != nil {
-		logrus.Errorf("Atoi %s: %s\n", o, err)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+		_ = c.Error(err)
 		logrus.Infoln("Ending api.History")
 		return
 	}
 
-	if limit <= 0 || offset < 0 {
-		logrus.Errorf("%s, limit: %s, offset: %s\n", Err.ErrBadRequest, limit, offset)
-		c.IndentedJSON(http.StatusBadRequest, message{Message: "Wrong data"})
+	// Clients paging with limit and offset get the bare array, as before cursors.
+	if _, ok := c.GetQuery("cursor"); !ok && !q.Total {
+		c.IndentedJSON(http.StatusOK, page.Entries)
 		logrus.Infoln("Ending api.History")
 		return
 	}
+	res := historyPage{Entries: page.Entries, Total: page.Total}
+	if page.Next != nil {
+		res.NextCursor = page.Next.Encode()
+	}
+
+	c.IndentedJSON(http.StatusOK, res)
+	logrus.Infoln("Ending api.History")
+}
+
+// inCurrency gives m the currency named in a request. An empty code leaves the
+// currency unset, so the controller applies the default one.
+func inCurrency(m model.Money, code string) (model.Money, error) {
+	if code == "" {
+		return m, nil
+	}
+	code = strings.ToUpper(code)
+	if !model.ValidCurrency(code) {
+		logrus.Errorf("%s currency: %s\n", Err.ErrBadRequest, code)
+		return m, Err.Validation(Err.Field("currency", "must be a currency code"))
+	}
+	m.Currency = code
+	return m, nil
+}
+
+// exportOptions reads the format of a report download from the query string.
+func exportOptions(c *gin.Context) (export.Format, export.Options, error) {
+	format := export.Format(c.DefaultQuery("format", string(export.CSV)))
+	options := export.Options{Header: true, Numeric: []string{"revenue"}}
+
+	var details []Err.FieldError
+	switch format {
+	case export.CSV, export.XLSX, export.JSONL:
+	default:
+		details = append(details, Err.Field("format", "must be csv, xlsx or jsonl"))
+	}
+	switch delimiter := c.Query("delimiter"); {
+	case delimiter == "":
+	case delimiter == "tab":
+		options.Delimiter = '\t'
+	case utf8.RuneCountInString(delimiter) == 1 && utf8.ValidString(delimiter) && !strings.ContainsAny(delimiter, "\"\r\n"):
+		options.Delimiter, _ = utf8.DecodeRuneInString(delimiter)
+	default:
+		details = append(details, Err.Field("delimiter", "must be one character or tab"))
+	}
+	if header := c.Query("header"); header != "" {
+		var err error
+		if options.Header, err = strconv.ParseBool(header); err != nil {
+			details = append(details, Err.Field("header", "must be true or false"))
+		}
+	}
+
+	if len(details) > 0 {
+		logrus.Errorf("%s export options: %s\n", Err.ErrBadRequest, c.Request.URL.RawQuery)
+		return format, options, Err.Validation(details...)
+	}
+	return format, options, nil
+}
+
+// reportQuery reads the range of a report request: from and to, both days included,
+// or else the month given by year and month.
+func reportQuery(r report) (model.ReportQuery, error) {
+	q := model.ReportQuery{Period: model.ReportPeriod(r.Period), GroupBy: model.ReportGroup(r.GroupBy)}
+	if q.GroupBy == "" {
+		q.GroupBy = model.GroupServiceName
+	}
+
+	if r.From == "" && r.To == "" {
+		date := r.Year + "-" + r.Month + "-01"
+		month, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			logrus.Errorf("Parse %s: %s\n", date, err)
+			return q, Err.Validation(Err.Field("year", "must be YYYY"), Err.Field("month", "must be MM"))
+		}
+		m := model.MonthQuery(month)
+		q.From, q.To = m.From, m.To
+		return q, nil
+	}
 
-	report, err := a.controller.History(userID, limit, offset)
+	var details []Err.FieldError
+	from, err := time.Parse("2006-01-02", r.From)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			c.IndentedJSON(http.StatusNotFound, message{Message: "Not found"})
-			logrus.Infoln("Ending api.History")
-			return
+		details = append(details, Err.Field("from", "must be YYYY-MM-DD"))
+	}
+	to, err := time.Parse("2006-01-02", r.To)
+	if err != nil {
+		details = append(details, Err.Field("to", "must be YYYY-MM-DD"))
+	}
+	if len(details) > 0 {
+		logrus.Errorf("%s range: %s - %s\n", Err.ErrBadRequest, r.From, r.To)
+		return q, Err.Validation(details...)
+	}
+	q.From, q.To = from, to.AddDate(0, 0, 1)
+	return q, nil
+}
+
+// historyQuery reads the page, the sort and the filters of a history request. The page is
+// given by offset or by cursor, the range in days, both inclusive; types are repeated or
+// separated by commas.
+func historyQuery(c *gin.Context) (model.HistoryQuery, error) {
+	q := model.HistoryQuery{Sort: model.HistorySort(c.DefaultQuery("sort", string(model.SortDate))), Desc: true}
+	var details []Err.FieldError
+
+	l := c.Query("limit")
+	limit, err := strconv.Atoi(l)
+	if err != nil {
+		logrus.Errorf("Atoi %s: %s\n", l, err)
+		details = append(details, Err.Field("limit", "must be an integer"))
+	}
+	q.Limit = limit
+
+	o := c.DefaultQuery("offset", "0")
+	offset, err := strconv.Atoi(o)
+	if err != nil {
+		logrus.Errorf("Atoi %s: %s\n", o, err)
+		details = append(details, Err.Field("offset", "must be an integer"))
+	}
+	q.Offset = offset
+
+	if token := c.Query("cursor"); token != "" {
+		if q.After, err = model.ParseHistoryCursor(token); err != nil {
+			logrus.Errorf("Cursor %s: %s\n", token, err)
+			details = append(details, Err.Field("cursor", "is not valid"))
+		}
+	}
+
+	if total := c.Query("total"); total != "" {
+		if q.Total, err = strconv.ParseBool(total); err != nil {
+			details = append(details, Err.Field("total", "must be true or false"))
+		}
+	}
+
+	switch c.Query("order") {
+	case "", "desc":
+	case "asc":
+		q.Desc = false
+	default:
+		details = append(details, Err.Field("order", "must be asc or desc"))
+	}
+
+	if from := c.Query("from"); from != "" {
+		if q.From, err = time.Parse("2006-01-02", from); err != nil {
+			details = append(details, Err.Field("from", "must be YYYY-MM-DD"))
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			details = append(details, Err.Field("to", "must be YYYY-MM-DD"))
 		} else {
-			c.IndentedJSON(http.StatusInternalServerError, message{Message: "Internal error"})
-			logrus.Infoln("Ending api.History")
-			return
+			q.To = day.AddDate(0, 0, 1)
 		}
 	}
 
-	c.IndentedJSON(http.StatusOK, report)
-	logrus.Infoln("Ending api.History")
+	for _, types := range c.QueryArray("type") {
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				q.Types = append(q.Types, model.HistoryType(t))
+			}
+		}
+	}
+
+	bounds := []struct {
+		field string
+		money **model.Money
+	}{{"min_amount", &q.MinAmount}, {"max_amount", &q.MaxAmount}}
+	for _, b := range bounds {
+		s := c.Query(b.field)
+		if s == "" {
+			continue
+		}
+		m, err := model.ParseMoney(s, "")
+		if err != nil {
+			details = append(details, Err.Field(b.field, "must be an amount"))
+			continue
+		}
+		*b.money = &m
+	}
+
+	if len(details) > 0 {
+		logrus.Errorf("%s history: %s\n", Err.ErrBadRequest, c.Request.URL.RawQuery)
+		return q, Err.Validation(details...)
+	}
+	return q, nil
+}
+
+// reportJob shows a job with the link to its file once it is done.
+func (a *api) reportJob(job *model.ReportJob) reportJob {
+	r := reportJob{
+		ID:         job.ID,
+		Status:     job.Status,
+		Error:      job.Error,
+		DateCreate: job.DateCreate,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Status == model.ReportDone {
+		r.Link = a.url("/report/csv?id=" + job.ID.String())
+	}
+	return r
+}
+
+// url turns a path into a link to this service. Without a base URL the link stays relative:
+// the Host header is up to the caller and must not end up in links handed to clients.
+func (a *api) url(path string) string {
+	return a.baseURL + path
 }
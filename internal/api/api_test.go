@@ -0,0 +1,285 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// reportController serves a single report file.
+type reportController struct {
+	IController
+	id   uuid.UUID
+	file string
+}
+
+func (r *reportController) OpenReport(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	if id != r.id {
+		return nil, Err.ErrReportNotFound
+	}
+	return io.NopCloser(strings.NewReader(r.file)), nil
+}
+
+func TestCsvReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	id := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	a := &api{controller: &reportController{id: id, file: "service_name,revenue,currency\n\"Доставка; \"\"экспресс\"\"\",1500.50,RUB\n"}}
+	r := gin.New()
+	r.Use(a.Errors)
+	r.GET("/report/csv", a.CsvReport)
+
+	tests := []struct {
+		name        string
+		query       string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"csv", "", http.StatusOK, "text/csv; charset=utf-8",
+			"service_name,revenue,currency\n\"Доставка; \"\"экспресс\"\"\",1500.50,RUB\n"},
+		{"semicolon without header", "&delimiter=%3B&header=false", http.StatusOK, "text/csv; charset=utf-8",
+			"\"Доставка; \"\"экспресс\"\"\";1500.50;RUB\n"},
+		{"tab", "&delimiter=tab", http.StatusOK, "text/csv; charset=utf-8",
+			"service_name\trevenue\tcurrency\n\"Доставка; \"\"экспресс\"\"\"\t1500.50\tRUB\n"},
+		{"jsonl", "&format=jsonl", http.StatusOK, "application/jsonl; charset=utf-8",
+			`{"service_name":"Доставка; \"экспресс\"","revenue":1500.50,"currency":"RUB"}` + "\n"},
+		{"bad format", "&format=pdf", http.StatusBadRequest, "application/json; charset=utf-8", ""},
+		{"bad delimiter", "&delimiter=%22", http.StatusBadRequest, "application/json; charset=utf-8", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report/csv?id="+id.String()+tt.query, nil))
+
+			require.Equal(t, tt.status, w.Code)
+			require.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			if tt.status == http.StatusOK {
+				require.Equal(t, tt.body, w.Body.String())
+				require.Contains(t, w.Header().Get("Content-Disposition"), "report-"+id.String())
+			}
+		})
+	}
+
+	t.Run("xlsx", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report/csv?format=xlsx&id="+id.String(), nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `attachment; filename="report-`+id.String()+`.xlsx"`, w.Header().Get("Content-Disposition"))
+		require.True(t, strings.HasPrefix(w.Body.String(), "PK"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report/csv?id="+uuid.New().String(), nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("broken file", func(t *testing.T) {
+		a := &api{controller: &reportController{id: id, file: "service_name,revenue,currency\n\"Доставка,1500.50,RUB\n"}}
+		r := gin.New()
+		r.Use(a.Errors)
+		r.GET("/report/csv", a.CsvReport)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report/csv?id="+id.String(), nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("empty jsonl", func(t *testing.T) {
+		a := &api{controller: &reportController{id: id, file: "service_name,revenue,currency\n"}}
+		r := gin.New()
+		r.Use(a.Errors)
+		r.GET("/report/csv", a.CsvReport)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report/csv?format=jsonl&id="+id.String(), nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/jsonl; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Header().Get("Content-Disposition"), "report-"+id.String()+".jsonl")
+		require.Empty(t, w.Body.String())
+	})
+}
+
+// jobController reports every job as done.
+type jobController struct {
+	IController
+}
+
+func (j *jobController) ReportJob(ctx context.Context, id uuid.UUID) (*model.ReportJob, error) {
+	return &model.ReportJob{ID: id, Status: model.ReportDone}, nil
+}
+
+func TestReportJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		baseURL string
+		link    string
+	}{
+		{"relative without base url", "", "/report/csv?id=" + id.String()},
+		{"base url", "https://billing.example.com", "https://billing.example.com/report/csv?id=" + id.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &api{controller: &jobController{}, baseURL: tt.baseURL}
+			r := gin.New()
+			r.Use(a.Errors)
+			r.GET("/report/:id", a.ReportJob)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/report/"+id.String(), nil)
+			req.Host = "attacker.example"
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var job reportJob
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+			require.Equal(t, tt.link, job.Link)
+		})
+	}
+}
+
+// historyController answers every history request with one page and keeps the query.
+type historyController struct {
+	IController
+	page  model.HistoryPage
+	query model.HistoryQuery
+}
+
+func (h *historyController) History(ctx context.Context, q model.HistoryQuery) (*model.HistoryPage, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	h.query = q
+	return &h.page, nil
+}
+
+func TestHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	next := model.HistoryCursor{Sort: model.SortDate, Desc: true, Date: time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC), ID: 42}
+	total := 3
+	h := &historyController{page: model.HistoryPage{
+		Entries: []model.History{{UserID: userID, Type: model.HistoryTopUp, Amount: model.NewMoney(1000, model.DefaultCurrency)}},
+		Next:    &next,
+		Total:   &total,
+	}}
+	a := &api{controller: h}
+	r := gin.New()
+	r.Use(a.Errors)
+	r.GET("/history", a.History)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/history?id="+userID.String()+"&limit=1"+query, nil))
+		return w
+	}
+
+	t.Run("limit and offset", func(t *testing.T) {
+		w := get("&offset=0")
+		require.Equal(t, http.StatusOK, w.Code)
+		var entries []model.History
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+		require.Len(t, entries, 1)
+	})
+
+	t.Run("first page", func(t *testing.T) {
+		w := get("&cursor=&total=true")
+		require.Equal(t, http.StatusOK, w.Code)
+		var page historyPage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		require.Len(t, page.Entries, 1)
+		require.Equal(t, next.Encode(), page.NextCursor)
+		require.Equal(t, &total, page.Total)
+		require.Nil(t, h.query.After)
+		require.True(t, h.query.Total)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		w := get("&cursor=" + next.Encode())
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, &next, h.query.After)
+	})
+
+	t.Run("bad cursor", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, get("&cursor=not-a-cursor").Code)
+		require.Equal(t, http.StatusBadRequest, get("&order=asc&cursor="+next.Encode()).Code)
+		require.Equal(t, http.StatusBadRequest, get("&offset=5&cursor="+next.Encode()).Code)
+	})
+}
+
+// serviceController keeps the service it was last given.
+type serviceController struct {
+	IController
+	service model.Service
+}
+
+func (s *serviceController) CreateService(ctx context.Context, service model.Service) (*model.Service, error) {
+	s.service = service
+	return &service, nil
+}
+
+func (s *serviceController) UpdateService(ctx context.Context, service model.Service) (*model.Service, error) {
+	s.service = service
+	return &service, nil
+}
+
+func TestServices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s := &serviceController{}
+	a := &api{controller: s}
+	r := gin.New()
+	r.Use(a.Errors)
+	r.POST("/admin/services", a.CreateService)
+	r.PUT("/admin/services/:id", a.UpdateService)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	id := uuid.New()
+	w := send(http.MethodPost, "/admin/services", `{"id":"`+id.String()+`","name":"Доставка","price":12.5,"currency":"usd"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, "/admin/services/"+id.String(), w.Header().Get("Location"))
+	require.Equal(t, "Доставка", s.service.Name)
+	require.True(t, s.service.Active)
+	require.True(t, s.service.Price.Equal(model.NewMoney(1250, "USD")))
+
+	w = send(http.MethodPut, "/admin/services/"+id.String(), `{"name":"Доставка","active":false}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, id, s.service.ID)
+	require.False(t, s.service.Active)
+	require.Nil(t, s.service.Price)
+
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPut, "/admin/services/"+id.String(), `{"id":"`+uuid.New().String()+`","name":"Доставка"}`).Code)
+	require.Equal(t, http.StatusNotFound, send(http.MethodPut, "/admin/services/42", `{"name":"Доставка"}`).Code)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, "/admin/services", `{"name":"Доставка","price":1,"currency":"rubles"}`).Code)
+}
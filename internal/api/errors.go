@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	Err "Avito/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// statusClientClosedRequest is the nginx convention for a client that went away.
+const statusClientClosedRequest = 499
+
+var statuses = map[Err.Code]int{
+	Err.CodeBadRequest:                http.StatusBadRequest,
+	Err.CodeInvalidAmount:             http.StatusBadRequest,
+	Err.CodeAmountOverflow:            http.StatusBadRequest,
+	Err.CodeCurrencyMismatch:          http.StatusBadRequest,
+	Err.CodeUnsupportedCurrency:       http.StatusBadRequest,
+	Err.CodeInsufficientFunds:         http.StatusBadRequest,
+	Err.CodeOrderMismatch:             http.StatusBadRequest,
+	Err.CodeCaptureExceedsReservation: http.StatusBadRequest,
+	Err.CodeRefundExceedsCapture:      http.StatusBadRequest,
+	Err.CodeServiceInactive:           http.StatusBadRequest,
+	Err.CodeOrderExists:               http.StatusConflict,
+	Err.CodeOrderTransition:           http.StatusConflict,
+	Err.CodeServiceExists:             http.StatusConflict,
+	Err.CodeServiceInUse:              http.StatusConflict,
+	Err.CodeUserNotFound:              http.StatusNotFound,
+	Err.CodeOrderNotFound:             http.StatusNotFound,
+	Err.CodeReportNotFound:            http.StatusNotFound,
+	Err.CodeServiceNotFound:           http.StatusNotFound,
+	Err.CodeNotFound:                  http.StatusNotFound,
+	Err.CodeForbidden:                 http.StatusForbidden,
+	Err.CodeIdempotencyConflict:       http.StatusUnprocessableEntity,
+	Err.CodeIdempotencyInProgress:     http.StatusConflict,
+	Err.CodeTimeout:                   http.StatusGatewayTimeout,
+	Err.CodeCancelled:                 statusClientClosedRequest,
+	Err.CodeUnavailable:               http.StatusServiceUnavailable,
+	Err.CodeInternal:                  http.StatusInternalServerError,
+}
+
+// Errors is the only place where errors become responses: handlers attach
+// them with c.Error and return, and the last one is written as Err.Error.
+func (a *api) Errors(c *gin.Context) {
+	c.Next()
+	renderError(c)
+}
+
+// renderError writes the last error of the request unless a response was already written.
+func renderError(c *gin.Context) {
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	e := toError(c.Errors.Last().Err)
+	status, ok := statuses[e.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	c.AbortWithStatusJSON(status, e)
+}
+
+func toError(err error) *Err.Error {
+	var e *Err.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		logrus.Errorln("Request timeout: ", err)
+		return Err.ErrTimeout
+	case errors.Is(err, context.Canceled):
+		logrus.Errorln("Request cancelled: ", err)
+		return Err.ErrCancelled
+	case errors.As(err, &e):
+		return e
+	case errors.Is(err, pgx.ErrNoRows):
+		return Err.ErrNotFound
+	default:
+		logrus.Errorln("Internal error: ", err)
+		return Err.ErrInternal
+	}
+}
+
+// decode reads a JSON body into v and describes what was wrong with it.
+func decode(c *gin.Context, v any) error {
+	err := json.NewDecoder(c.Request.Body).Decode(v)
+	if err == nil {
+		return nil
+	}
+	logrus.Errorln("Decoding: ", err)
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr):
+		return Err.Validation(Err.Field(typeErr.Field, "must be "+typeErr.Type.String()))
+	case errors.Is(err, Err.ErrInvalidAmount), errors.Is(err, Err.ErrAmountOverflow):
+		return err
+	default:
+		return Err.Validation(Err.Field("body", "malformed JSON"))
+	}
+}
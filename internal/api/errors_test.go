@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	Err "Avito/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   Err.Code
+	}{
+		{"validation", Err.Validation(Err.Field("funds", "must be positive")), http.StatusBadRequest, Err.CodeBadRequest},
+		{"insufficient funds", fmt.Errorf("debit: %w", Err.ErrInsufficientFunds), http.StatusBadRequest, Err.CodeInsufficientFunds},
+		{"unknown user", Err.ErrUserNotFound, http.StatusNotFound, Err.CodeUserNotFound},
+		{"unknown order", Err.ErrOrderNotFound, http.StatusNotFound, Err.CodeOrderNotFound},
+		{"order mismatch", Err.ErrOrderMismatch, http.StatusBadRequest, Err.CodeOrderMismatch},
+		{"no rows", pgx.ErrNoRows, http.StatusNotFound, Err.CodeNotFound},
+		{"timeout", context.DeadlineExceeded, http.StatusGatewayTimeout, Err.CodeTimeout},
+		{"internal", errors.New("connection reset"), http.StatusInternalServerError, Err.CodeInternal},
+	}
+
+	a := &api{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(a.Errors)
+			r.GET("/", func(c *gin.Context) { _ = c.Error(tt.err) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			require.Equal(t, tt.status, w.Code)
+
+			var body Err.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			require.Equal(t, tt.code, body.Code)
+			require.NotEmpty(t, body.Message)
+		})
+	}
+
+	t.Run("details", func(t *testing.T) {
+		r := gin.New()
+		r.Use(a.Errors)
+		r.GET("/", func(c *gin.Context) {
+			_ = c.Error(Err.ErrOrderMismatch.WithDetails(Err.Field("cost", "does not match the order")))
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.JSONEq(t, `{"code":"order_mismatch","message":"order fields do not match the reservation","details":[{"field":"cost","message":"does not match the order"}]}`, w.Body.String())
+	})
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	Err "Avito/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	replayedHeader    = "Idempotent-Replayed"
+	maxIdempotencyKey = 255
+	replayContentType = "application/json; charset=utf-8"
+)
+
+// responseRecorder copies everything written to the client so it can be stored.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Idempotency is a middleware for mutating endpoints. The first response for an
+// Idempotency-Key is stored and replayed for every repeat with the same payload.
+func (a *api) Idempotency(c *gin.Context) {
+	key := c.GetHeader(idempotencyHeader)
+	if key == "" {
+		c.Next()
+		return
+	}
+
+	logrus.Infoln("Starting api.Idempotency")
+
+	if len(key) > maxIdempotencyKey {
+		logrus.Errorf("%s: key length %d\n", Err.ErrBadRequest, len(key))
+		_ = c.Error(Err.Validation(Err.Field(idempotencyHeader, "must not be longer than 255 characters")))
+		c.Abort()
+		logrus.Infoln("Ending api.Idempotency")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		logrus.Errorln("ReadAll: ", err)
+		_ = c.Error(Err.Validation(Err.Field("body", "cannot be read")))
+		c.Abort()
+		logrus.Infoln("Ending api.Idempotency")
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.FullPath() + "\n"))
+	hash.Write(body)
+
+	stored, err := a.controller.StartIdempotent(c.Request.Context(), key, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		logrus.Infoln("Ending api.Idempotency")
+		return
+	}
+
+	if stored != nil {
+		c.Header(replayedHeader, "true")
+		c.Data(stored.Status, replayContentType, stored.Body)
+		c.Abort()
+		logrus.Infoln("Ending api.Idempotency")
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	c.Next()
+	// The response has to be written before it is stored.
+	renderError(c)
+
+	if interrupted(c, recorder.Status()) {
+		if err := a.controller.ReleaseIdempotent(context.Background(), key); err != nil {
+			logrus.Errorf("ReleaseIdempotent %s: %s\n", key, err)
+		}
+	} else if err := a.controller.FinishIdempotent(context.Background(), key, recorder.Status(), recorder.body.Bytes()); err != nil {
+		logrus.Errorf("FinishIdempotent %s: %s\n", key, err)
+	}
+
+	logrus.Infoln("Ending api.Idempotency")
+}
+
+// interrupted reports whether the request was cut off by the client going away or by its
+// timeout rather than finished: its transaction was rolled back, so a retry has to run it.
+func interrupted(c *gin.Context, status int) bool {
+	if status == statusClientClosedRequest || status == http.StatusGatewayTimeout {
+		return true
+	}
+	for _, e := range c.Errors {
+		if errors.Is(e.Err, context.Canceled) || errors.Is(e.Err, context.DeadlineExceeded) ||
+			errors.Is(e.Err, Err.ErrCancelled) || errors.Is(e.Err, Err.ErrTimeout) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// idempotencyController keeps the idempotency keys in memory.
+type idempotencyController struct {
+	IController
+	keys map[string]*model.Idempotency
+}
+
+func (i *idempotencyController) StartIdempotent(ctx context.Context, key, requestHash string) (*model.Idempotency, error) {
+	stored, ok := i.keys[key]
+	if !ok {
+		i.keys[key] = &model.Idempotency{Key: key, RequestHash: requestHash}
+		return nil, nil
+	}
+	if stored.Status == 0 {
+		return nil, Err.ErrIdempotencyInProgress
+	}
+	return stored, nil
+}
+
+func (i *idempotencyController) FinishIdempotent(ctx context.Context, key string, status int, body []byte) error {
+	i.keys[key].Status = status
+	i.keys[key].Body = body
+	return nil
+}
+
+func (i *idempotencyController) ReleaseIdempotent(ctx context.Context, key string) error {
+	delete(i.keys, key)
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	send := func(r *gin.Engine) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/balance", strings.NewReader(`{"funds": 10}`))
+		req.Header.Set(idempotencyHeader, "key")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"cancelled", context.Canceled},
+		{"timeout", context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" request runs again", func(t *testing.T) {
+			a := &api{controller: &idempotencyController{keys: map[string]*model.Idempotency{}}}
+			calls := 0
+			r := gin.New()
+			r.Use(a.Errors)
+			r.POST("/balance", a.Idempotency, func(c *gin.Context) {
+				calls++
+				if calls == 1 {
+					_ = c.Error(tt.err)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			require.NotEqual(t, http.StatusOK, send(r).Code)
+
+			w := send(r)
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Empty(t, w.Header().Get(replayedHeader))
+			require.Equal(t, 2, calls)
+		})
+	}
+
+	t.Run("finished request is replayed", func(t *testing.T) {
+		a := &api{controller: &idempotencyController{keys: map[string]*model.Idempotency{}}}
+		calls := 0
+		r := gin.New()
+		r.Use(a.Errors)
+		r.POST("/balance", a.Idempotency, func(c *gin.Context) {
+			calls++
+			_ = c.Error(Err.ErrInsufficientFunds)
+		})
+
+		require.Equal(t, http.StatusBadRequest, send(r).Code)
+
+		w := send(r)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Equal(t, "true", w.Header().Get(replayedHeader))
+		require.Equal(t, 1, calls)
+	})
+}
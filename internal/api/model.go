@@ -1,31 +1,85 @@
 package api
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+)
 
 type message struct {
 	Message string `json:"message"`
 }
 
 type user struct {
-	ID    uuid.UUID `json:"id"`
-	Funds float64   `json:"funds"`
+	ID       uuid.UUID   `json:"id"`
+	Funds    model.Money `json:"funds" swaggertype:"number"`
+	Currency string      `json:"currency,omitempty"`
 }
 
 type transfer struct {
-	SenderID    uuid.UUID `json:"sender_id"`
-	RecipientID uuid.UUID `json:"recipient_id"`
-	Funds       float64   `json:"funds"`
+	SenderID    uuid.UUID   `json:"sender_id"`
+	RecipientID uuid.UUID   `json:"recipient_id"`
+	Funds       model.Money `json:"funds" swaggertype:"number"`
+	Currency    string      `json:"currency,omitempty"`
+}
+
+type withdrawal struct {
+	UserID   uuid.UUID   `json:"user_id"`
+	Funds    model.Money `json:"funds" swaggertype:"number"`
+	Currency string      `json:"currency,omitempty"`
 }
 
 type order struct {
-	UserID      uuid.UUID `json:"user_id"`
-	ServiceID   uuid.UUID `json:"service_id"`
-	ServiceName string    `json:"service_name"`
-	OrderID     uuid.UUID `json:"order_id"`
-	Cost        float64   `json:"cost"`
+	UserID      uuid.UUID   `json:"user_id"`
+	ServiceID   uuid.UUID   `json:"service_id"`
+	ServiceName string      `json:"service_name"`
+	OrderID     uuid.UUID   `json:"order_id"`
+	Cost        model.Money `json:"cost" swaggertype:"number"`
+	Currency    string      `json:"currency,omitempty"`
+	TTLSeconds  int64       `json:"ttl_seconds,omitempty"`
+}
+
+type refund struct {
+	OrderID  uuid.UUID   `json:"order_id"`
+	Amount   model.Money `json:"amount" swaggertype:"number"`
+	Currency string      `json:"currency,omitempty"`
+}
+
+// service is a catalog entry as an admin writes it. A service is active unless Active is false.
+type service struct {
+	ID       *uuid.UUID   `json:"id,omitempty"`
+	Name     string       `json:"name"`
+	Price    *model.Money `json:"price,omitempty" swaggertype:"number"`
+	Currency string       `json:"currency,omitempty"`
+	Active   *bool        `json:"active,omitempty"`
 }
 
 type report struct {
-	Year  string `json:"year"`
-	Month string `json:"month"`
+	Year    string `json:"year,omitempty"`
+	Month   string `json:"month,omitempty"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+	Period  string `json:"period,omitempty"`
+	GroupBy string `json:"group_by,omitempty"`
+	Format  string `json:"format,omitempty"`
+}
+
+// historyPage is the history of a client paging with cursors.
+type historyPage struct {
+	Entries    []model.History `json:"entries"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Total      *int            `json:"total,omitempty"`
+}
+
+// reportJob is a queued report; Link points at the file once the report is done.
+type reportJob struct {
+	ID         uuid.UUID             `json:"id"`
+	Status     model.ReportJobStatus `json:"status"`
+	Link       string                `json:"link,omitempty"`
+	Error      string                `json:"error,omitempty"`
+	DateCreate time.Time             `json:"date_create"`
+	StartedAt  *time.Time            `json:"started_at,omitempty"`
+	FinishedAt *time.Time            `json:"finished_at,omitempty"`
 }
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"net/http"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// serviceID reads the service ID of the path; an ID that is not a UUID names no service.
+func serviceID(c *gin.Context) (uuid.UUID, error) {
+	arg := c.Param("id")
+	id, err := uuid.Parse(arg)
+	if err != nil {
+		logrus.Errorf("Parse %s: %s\n", arg, err)
+		return id, Err.ErrServiceNotFound
+	}
+	return id, nil
+}
+
+// decodeService reads a catalog entry from the body.
+func decodeService(c *gin.Context) (model.Service, error) {
+	body := service{}
+	if err := decode(c, &body); err != nil {
+		return model.Service{}, err
+	}
+
+	s := model.Service{Name: body.Name, Active: body.Active == nil || *body.Active}
+	if body.ID != nil {
+		s.ID = *body.ID
+	}
+	if body.Price != nil {
+		price, err := inCurrency(*body.Price, body.Currency)
+		if err != nil {
+			return s, err
+		}
+		s.Price = &price
+	}
+	return s, nil
+}
+
+// @Summary      Services
+// @Description  Предоставляет каталог услуг
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Success		 200 {array}  model.Service
+// @Failure 	 403 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /admin/services [get]
+func (a *api) Services(c *gin.Context) {
+	logrus.Infoln("Starting api.Services")
+
+	services, err := a.controller.Services(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Services")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, services)
+	logrus.Infoln("Ending api.Services")
+}
+
+// @Summary      Service
+// @Description  Предоставляет услугу из каталога
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Param        id   path   string  true "service ID"
+// @Success		 200 {object} model.Service
+// @Failure 	 403 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /admin/services/{id} [get]
+func (a *api) Service(c *gin.Context) {
+	logrus.Infoln("Starting api.Service")
+
+	id, err := serviceID(c)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Service")
+		return
+	}
+
+	s, err := a.controller.Service(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.Service")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, s)
+	logrus.Infoln("Ending api.Service")
+}
+
+// @Summary      CreateService
+// @Description  Добавляет услугу в каталог; без id он создается. Услуга с ценой продается только по ней
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Param        service body service true "Услуга"
+// @Success		 201 {object} model.Service
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 403 {object} errors.Error
+// @Failure 	 409 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /admin/services [post]
+func (a *api) CreateService(c *gin.Context) {
+	logrus.Infoln("Starting api.CreateService")
+
+	s, err := decodeService(c)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.CreateService")
+		return
+	}
+
+	created, err := a.controller.CreateService(c.Request.Context(), s)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.CreateService")
+		return
+	}
+
+	c.Header("Location", "/admin/services/"+created.ID.String())
+	c.IndentedJSON(http.StatusCreated, created)
+	logrus.Infoln("Ending api.CreateService")
+}
+
+// @Summary      UpdateService
+// @Description  Заменяет название, цену и активность услуги. Прежние заказы сохраняют название, отчеты показывают новое
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Param        id   path   string  true "service ID"
+// @Param        service body service true "Услуга"
+// @Success		 200 {object} model.Service
+// @Failure 	 400 {object} errors.Error
+// @Failure 	 403 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /admin/services/{id} [put]
+func (a *api) UpdateService(c *gin.Context) {
+	logrus.Infoln("Starting api.UpdateService")
+
+	id, err := serviceID(c)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.UpdateService")
+		return
+	}
+
+	s, err := decodeService(c)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.UpdateService")
+		return
+	}
+	if s.ID != uuid.Nil && s.ID != id {
+		_ = c.Error(Err.Validation(Err.Field("id", "must match the path")))
+		logrus.Infoln("Ending api.UpdateService")
+		return
+	}
+	s.ID = id
+
+	updated, err := a.controller.UpdateService(c.Request.Context(), s)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.UpdateService")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, updated)
+	logrus.Infoln("Ending api.UpdateService")
+}
+
+// @Summary      DeleteService
+// @Description  Удаляет услугу, которую ни разу не заказывали; остальные можно только сделать неактивными
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header string true "Bearer <admin token>"
+// @Param        id   path   string  true "service ID"
+// @Success		 200 {object} message
+// @Failure 	 403 {object} errors.Error
+// @Failure 	 404 {object} errors.Error
+// @Failure 	 409 {object} errors.Error
+// @Failure 	 500 {object} errors.Error
+// @Router       /admin/services/{id} [delete]
+func (a *api) DeleteService(c *gin.Context) {
+	logrus.Infoln("Starting api.DeleteService")
+
+	id, err := serviceID(c)
+	if err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.DeleteService")
+		return
+	}
+
+	if err := a.controller.DeleteService(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		logrus.Infoln("Ending api.DeleteService")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, message{Message: "Success"})
+	logrus.Infoln("Ending api.DeleteService")
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout bounds the request context by the timeout configured for the route,
+// keyed as "METHOD /path" (e.g. "POST /report"), or by fallback. A zero duration disables the limit.
+func Timeout(timeouts map[string]time.Duration, fallback time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := timeouts[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = fallback
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
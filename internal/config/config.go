@@ -1,53 +1,364 @@
 package config
 
 import (
-	"io/ioutil"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
 
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	yaml "gopkg.in/yaml.v3"
 )
 
-type config struct {
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	Database string `yaml:"database"`
+const (
+	defaultConfigPath = "config.yaml"
+	configPathEnv     = "AVITO_CONFIG"
+)
+
+type Config struct {
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	Host     string   `yaml:"host"`
+	Port     string   `yaml:"port"`
+	Database string   `yaml:"database"`
+	Pool     pool     `yaml:"pool"`
+	Timeouts timeouts `yaml:"timeouts"`
+
+	Reservations reservations `yaml:"reservations"`
+	Idempotency  idempotency  `yaml:"idempotency"`
+	Reports      reports      `yaml:"reports"`
+	Withdrawals  withdrawals  `yaml:"withdrawals"`
+	Fees         fees         `yaml:"fees"`
+
+	ListenAddress string `yaml:"listen_address"`
+	LogLevel      string `yaml:"log_level"`
+	ReportsDir    string `yaml:"reports_dir"`
+	// BaseURL prefixes the report links, e.g. "https://billing.example.com"; without it
+	// links are relative paths such as "/report/csv?id=...".
+	BaseURL         string        `yaml:"base_url"`
+	MigrateOnStart  bool          `yaml:"migrate_on_start"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// AdminToken guards the /admin endpoints; they are closed while it is empty.
+	AdminToken string `yaml:"admin_token"`
+	// RatesFile is a JSON exchange rate table; without it only RUB is accepted.
+	RatesFile string `yaml:"rates_file"`
+}
+
+// pool holds pgxpool settings; zero values keep the pgxpool defaults.
+type pool struct {
+	MaxConns          int32         `yaml:"max_conns"`
+	MinConns          int32         `yaml:"min_conns"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period"`
+	AcquireTimeout    time.Duration `yaml:"acquire_timeout"`
+}
+
+// timeouts bound request handling; Endpoints is keyed by "METHOD /path" and overrides Default.
+type timeouts struct {
+	Default   time.Duration            `yaml:"default"`
+	Endpoints map[string]time.Duration `yaml:"endpoints"`
+}
+
+// reservations configures the expiry of unresolved orders. Services is keyed by service ID
+// and overrides TTL; a zero TTL disables expiry.
+type reservations struct {
+	TTL           time.Duration            `yaml:"ttl"`
+	Services      map[string]time.Duration `yaml:"services"`
+	CheckInterval time.Duration            `yaml:"check_interval"`
+	BatchSize     int                      `yaml:"batch_size"`
+}
+
+// idempotency configures how long Idempotency-Key responses are replayed: keys older than TTL
+// are deleted every reports.cleanup_interval.
+type idempotency struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// reports configures where report files are kept: "local" in ReportsDir, "memory" or "s3".
+// Reports older than Retention are deleted every CleanupInterval; a zero Retention keeps them.
+// Report jobs are built by Workers goroutines that look for new jobs every PollInterval;
+// a job running longer than JobTimeout is cancelled and picked up again.
+type reports struct {
+	Storage         string        `yaml:"storage"`
+	Retention       time.Duration `yaml:"retention"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval"`
+	Workers         int           `yaml:"workers"`
+	PollInterval    time.Duration `yaml:"poll_interval"`
+	JobTimeout      time.Duration `yaml:"job_timeout"`
+	S3              s3            `yaml:"s3"`
+}
+
+// s3 addresses a bucket of an S3-compatible service such as MinIO. Reports are kept under
+// Prefix, and retention deletes only the objects under it.
+type s3 struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	Prefix    string `yaml:"prefix"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
 }
 
-func LoadConfig() (*config, error) {
+// withdrawals configures POST /withdraw. MinBalance is keyed by currency code and holds the
+// amount a wallet must keep after a withdrawal; currencies not listed can be emptied.
+type withdrawals struct {
+	MinBalance map[string]string `yaml:"min_balance"`
+}
+
+// fees configures the commission: Transfers is paid by the sender of a transfer, Orders is
+// withheld from the service revenue when an order is captured, and Services, keyed by
+// service ID, overrides Orders.
+type fees struct {
+	Transfers fee            `yaml:"transfers"`
+	Orders    fee            `yaml:"orders"`
+	Services  map[string]fee `yaml:"services"`
+}
+
+// fee is a commission rule: Percent of the amount plus Fixed, kept within Min and Max.
+// Fixed, Min and Max are keyed by currency code.
+type fee struct {
+	Percent string            `yaml:"percent"`
+	Fixed   map[string]string `yaml:"fixed"`
+	Min     map[string]string `yaml:"min"`
+	Max     map[string]string `yaml:"max"`
+}
+
+// Rule parses the amounts of f and checks the rule.
+func (f fee) Rule() (model.FeeRule, error) {
+	rule := model.FeeRule{Percent: f.Percent}
+	for _, amounts := range []struct {
+		from map[string]string
+		to   *map[string]model.Money
+	}{{f.Fixed, &rule.Fixed}, {f.Min, &rule.Min}, {f.Max, &rule.Max}} {
+		*amounts.to = make(map[string]model.Money, len(amounts.from))
+		for currency, amount := range amounts.from {
+			m, err := model.ParseMoney(amount, currency)
+			if err != nil {
+				return rule, fmt.Errorf("%w: %s %s", ErrBadFee, currency, amount)
+			}
+			(*amounts.to)[currency] = m
+		}
+	}
+	if err := rule.Validate(); err != nil {
+		var e *Err.Error
+		if errors.As(err, &e) && len(e.Details) > 0 {
+			return rule, fmt.Errorf("%w: %s %s", ErrBadFee, e.Details[0].Field, e.Details[0].Message)
+		}
+		return rule, fmt.Errorf("%w: %s", ErrBadFee, err)
+	}
+	return rule, nil
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		ListenAddress:   ":8080",
+		LogLevel:        "info",
+		ReportsDir:      "./reports",
+		ShutdownTimeout: 30 * time.Second,
+		Reservations: reservations{
+			TTL:           24 * time.Hour,
+			CheckInterval: time.Minute,
+			BatchSize:     100,
+		},
+		Idempotency: idempotency{
+			TTL: 24 * time.Hour,
+		},
+		Reports: reports{
+			Storage:         "local",
+			CleanupInterval: time.Hour,
+			Workers:         2,
+			PollInterval:    time.Second,
+			JobTimeout:      10 * time.Minute,
+			S3:              s3{Prefix: "reports/"},
+		},
+	}
+}
+
+// LoadConfig builds the configuration from, in increasing priority: defaults,
+// the YAML file, AVITO_* environment variables and command line flags.
+// It returns the arguments left after the flags, e.g. a subcommand.
+func LoadConfig(args []string) (*Config, []string, error) {
 
 	logrus.Info("Starting loading config")
 
-	config := &config{}
+	fs := flag.NewFlagSet("avito", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
 
-	yamlFile, err := ioutil.ReadFile("config.yaml")
-	if err != nil {
-		logrus.Fatalln("ReadFile: ", err)
+	path, pathSet := os.LookupEnv(configPathEnv)
+	if !pathSet {
+		path = defaultConfigPath
 	}
+	fs.StringVar(&path, "config", path, "path to the YAML config file (env "+configPathEnv+")")
 
-	err = yaml.Unmarshal(yamlFile, config)
-	if err != nil {
-		logrus.Fatalln("Unmarshal: ", err)
+	flagValues := make(map[string]*string, len(options))
+	for _, o := range options {
+		flagValues[o.name] = fs.String(o.name, "", fmt.Sprintf("%s (env %s)", o.usage, o.env))
 	}
 
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "config" {
+			pathSet = true
+		}
+	})
+
+	config := defaultConfig()
+
+	yamlFile, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(yamlFile, config); err != nil {
+			return nil, nil, fmt.Errorf("unmarshal %s: %w", path, err)
+		}
+	case errors.Is(err, os.ErrNotExist) && !pathSet:
+		// Without an explicit path the file is optional: everything may come from the environment.
+		logrus.Infof("Config file %s not found, using environment and flags\n", path)
+	default:
+		return nil, nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	for _, o := range options {
+		value, ok := os.LookupEnv(o.env)
+		if !ok {
+			continue
+		}
+		if err := o.set(config, value); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", o.env, err)
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		for _, o := range options {
+			if o.name != f.Name || flagErr != nil {
+				continue
+			}
+			if err := o.set(config, *flagValues[o.name]); err != nil {
+				flagErr = fmt.Errorf("-%s: %w", o.name, err)
+			}
+		}
+	})
+	if flagErr != nil {
+		return nil, nil, flagErr
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	logrus.Info("Ending loading config")
+
+	return config, fs.Args(), nil
+}
+
+func (config *Config) validate() error {
 	if config.Username == "" {
-		return nil, ErrNoUsername
+		return ErrNoUsername
 	}
 	if config.Password == "" {
-		return nil, ErrNoPassword
+		return ErrNoPassword
 	}
 	if config.Host == "" {
-		return nil, ErrNoHost
+		return ErrNoHost
 	}
 	if config.Port == "" {
-		return nil, ErrNoPort
+		return ErrNoPort
 	}
 	if config.Database == "" {
-		return nil, ErrNoDatabase
+		return ErrNoDatabase
+	}
+	if config.Pool.MaxConns < 0 || config.Pool.MinConns < 0 || config.Pool.MaxConns != 0 && config.Pool.MinConns > config.Pool.MaxConns {
+		return ErrBadPoolSize
+	}
+	if config.ListenAddress == "" {
+		return ErrNoListenAddress
+	}
+	if _, err := logrus.ParseLevel(config.LogLevel); err != nil {
+		return fmt.Errorf("%w: %s", ErrBadLogLevel, config.LogLevel)
+	}
+	switch config.Reports.Storage {
+	case "local":
+		if config.ReportsDir == "" {
+			return ErrNoReportsDir
+		}
+	case "memory":
+	case "s3":
+		if u, err := url.Parse(config.Reports.S3.Endpoint); err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" || config.Reports.S3.Bucket == "" || config.Reports.S3.Prefix == "" {
+			return ErrBadS3
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrBadReportStorage, config.Reports.Storage)
+	}
+	if config.Reports.Retention < 0 || config.Reports.CleanupInterval <= 0 {
+		return ErrBadRetention
+	}
+	if config.Reports.Workers <= 0 || config.Reports.PollInterval <= 0 || config.Reports.JobTimeout <= 0 {
+		return ErrBadReportJobs
+	}
+	if config.BaseURL != "" {
+		if u, err := url.Parse(config.BaseURL); err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("%w: %s", ErrBadBaseURL, config.BaseURL)
+		}
 	}
 
-	logrus.Info("Ending loading config")
+	if config.ShutdownTimeout < 0 {
+		return ErrBadTimeout
+	}
+	if config.Timeouts.Default < 0 {
+		return ErrBadTimeout
+	}
+	for _, timeout := range config.Timeouts.Endpoints {
+		if timeout < 0 {
+			return ErrBadTimeout
+		}
+	}
+
+	if config.Reservations.TTL < 0 || config.Reservations.CheckInterval <= 0 || config.Reservations.BatchSize <= 0 {
+		return ErrBadReservations
+	}
+	for serviceID, ttl := range config.Reservations.Services {
+		if _, err := uuid.Parse(serviceID); err != nil {
+			return fmt.Errorf("%w: %s", ErrBadServiceID, serviceID)
+		}
+		if ttl < 0 {
+			return ErrBadReservations
+		}
+	}
+
+	if config.Idempotency.TTL <= 0 {
+		return ErrBadIdempotencyTTL
+	}
+
+	for currency, amount := range config.Withdrawals.MinBalance {
+		if m, err := model.ParseMoney(amount, currency); !model.ValidCurrency(currency) || err != nil || m.IsNegative() {
+			return fmt.Errorf("%w: %s %s", ErrBadMinBalance, currency, amount)
+		}
+	}
+
+	if _, err := config.Fees.Transfers.Rule(); err != nil {
+		return fmt.Errorf("transfers: %w", err)
+	}
+	if _, err := config.Fees.Orders.Rule(); err != nil {
+		return fmt.Errorf("orders: %w", err)
+	}
+	for serviceID, f := range config.Fees.Services {
+		if _, err := uuid.Parse(serviceID); err != nil {
+			return fmt.Errorf("%w: %s", ErrBadServiceID, serviceID)
+		}
+		if _, err := f.Rule(); err != nil {
+			return fmt.Errorf("%s: %w", serviceID, err)
+		}
+	}
 
-	return config, nil
+	return nil
 }
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testYAML = `username: "user"
+password: "secret"
+host: "pg"
+port: "5432"
+database: "Avito"
+listen_address: ":9090"
+timeouts:
+  default: "5s"
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("yaml and defaults", func(t *testing.T) {
+		config, args, err := LoadConfig([]string{"--config", writeConfig(t, testYAML)})
+		require.NoError(t, err)
+		require.Empty(t, args)
+		require.Equal(t, "pg", config.Host)
+		require.Equal(t, ":9090", config.ListenAddress)
+		require.Equal(t, "info", config.LogLevel)
+		require.Equal(t, "./reports", config.ReportsDir)
+		require.Equal(t, "local", config.Reports.Storage)
+		require.Equal(t, 5*time.Second, config.Timeouts.Default)
+		require.Equal(t, 24*time.Hour, config.Reservations.TTL)
+		require.Equal(t, 100, config.Reservations.BatchSize)
+		require.Equal(t, 24*time.Hour, config.Idempotency.TTL)
+	})
+
+	t.Run("env overrides yaml", func(t *testing.T) {
+		t.Setenv("AVITO_DB_HOST", "db.internal")
+		t.Setenv("AVITO_REQUEST_TIMEOUT", "10s")
+		t.Setenv("AVITO_DB_MAX_CONNS", "50")
+
+		config, _, err := LoadConfig([]string{"--config", writeConfig(t, testYAML)})
+		require.NoError(t, err)
+		require.Equal(t, "db.internal", config.Host)
+		require.Equal(t, 10*time.Second, config.Timeouts.Default)
+		require.Equal(t, int32(50), config.Pool.MaxConns)
+	})
+
+	t.Run("flag overrides env", func(t *testing.T) {
+		t.Setenv("AVITO_DB_HOST", "db.internal")
+
+		config, args, err := LoadConfig([]string{"--config", writeConfig(t, testYAML), "--db-host", "localhost", "migrate", "status"})
+		require.NoError(t, err)
+		require.Equal(t, "localhost", config.Host)
+		require.Equal(t, []string{"migrate", "status"}, args)
+	})
+
+	t.Run("config path from env", func(t *testing.T) {
+		t.Setenv("AVITO_CONFIG", writeConfig(t, testYAML))
+
+		config, _, err := LoadConfig(nil)
+		require.NoError(t, err)
+		require.Equal(t, "pg", config.Host)
+	})
+
+	t.Run("explicit config missing", func(t *testing.T) {
+		_, _, err := LoadConfig([]string{"--config", filepath.Join(t.TempDir(), "missing.yaml")})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("env only", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(t.TempDir()))
+		t.Cleanup(func() { _ = os.Chdir(wd) })
+
+		t.Setenv("AVITO_DB_USERNAME", "user")
+		t.Setenv("AVITO_DB_PASSWORD", "secret")
+		t.Setenv("AVITO_DB_HOST", "pg")
+		t.Setenv("AVITO_DB_PORT", "5432")
+		t.Setenv("AVITO_DB_NAME", "Avito")
+
+		config, _, err := LoadConfig(nil)
+		require.NoError(t, err)
+		require.Equal(t, ":8080", config.ListenAddress)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		path := writeConfig(t, testYAML)
+
+		_, _, err := LoadConfig([]string{"--config", path, "--log-level", "loud"})
+		require.ErrorIs(t, err, ErrBadLogLevel)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--db-host", ""})
+		require.ErrorIs(t, err, ErrNoHost)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--shutdown-timeout", "soon"})
+		require.Error(t, err)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--unknown"})
+		require.Error(t, err)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--reservation-batch-size", "0"})
+		require.ErrorIs(t, err, ErrBadReservations)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--idempotency-ttl", "0s"})
+		require.ErrorIs(t, err, ErrBadIdempotencyTTL)
+
+		_, _, err = LoadConfig([]string{"--config", writeConfig(t, testYAML+"reservations:\n  services:\n    taxi: 1h\n")})
+		require.ErrorIs(t, err, ErrBadServiceID)
+
+		_, _, err = LoadConfig([]string{"--config", writeConfig(t, testYAML+"withdrawals:\n  min_balance:\n    rub: \"10\"\n")})
+		require.ErrorIs(t, err, ErrBadMinBalance)
+
+		_, _, err = LoadConfig([]string{"--config", writeConfig(t, testYAML+"withdrawals:\n  min_balance:\n    RUB: \"-1\"\n")})
+		require.ErrorIs(t, err, ErrBadMinBalance)
+
+		_, _, err = LoadConfig([]string{"--config", writeConfig(t, testYAML+"fees:\n  transfers:\n    percent: \"150\"\n")})
+		require.ErrorIs(t, err, ErrBadFee)
+
+		_, _, err = LoadConfig([]string{"--config", writeConfig(t, testYAML+"fees:\n  orders:\n    min:\n      RUB: \"10\"\n    max:\n      RUB: \"5\"\n")})
+		require.ErrorIs(t, err, ErrBadFee)
+
+		_, _, err = LoadConfig([]string{"--config", writeConfig(t, testYAML+"fees:\n  services:\n    taxi:\n      percent: \"5\"\n")})
+		require.ErrorIs(t, err, ErrBadServiceID)
+
+		config, _, err := LoadConfig([]string{"--config", writeConfig(t, testYAML+"fees:\n  transfers:\n    percent: \"1.5\"\n    fixed:\n      RUB: \"10\"\n")})
+		require.NoError(t, err)
+		rule, err := config.Fees.Transfers.Rule()
+		require.NoError(t, err)
+		require.Equal(t, "1.5", rule.Percent)
+		require.Equal(t, int64(1000), rule.Fixed["RUB"].Amount)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--report-storage", "ftp"})
+		require.ErrorIs(t, err, ErrBadReportStorage)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--report-storage", "s3", "--s3-endpoint", "minio:9000", "--s3-bucket", "reports"})
+		require.ErrorIs(t, err, ErrBadS3)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--report-storage", "s3", "--s3-endpoint", "http://minio:9000", "--s3-bucket", "reports"})
+		require.NoError(t, err)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--report-retention", "-1h"})
+		require.ErrorIs(t, err, ErrBadRetention)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--report-workers", "0"})
+		require.ErrorIs(t, err, ErrBadReportJobs)
+
+		_, _, err = LoadConfig([]string{"--config", path, "--base-url", "localhost:9000"})
+		require.ErrorIs(t, err, ErrBadBaseURL)
+	})
+}
@@ -3,9 +3,24 @@ package config
 import "errors"
 
 var (
-	ErrNoUsername = errors.New("missing username")
-	ErrNoPassword = errors.New("missing password")
-	ErrNoHost     = errors.New("missing host")
-	ErrNoPort     = errors.New("missing port")
-	ErrNoDatabase = errors.New("missing database")
+	ErrNoUsername        = errors.New("missing username")
+	ErrNoPassword        = errors.New("missing password")
+	ErrNoHost            = errors.New("missing host")
+	ErrNoPort            = errors.New("missing port")
+	ErrNoDatabase        = errors.New("missing database")
+	ErrBadPoolSize       = errors.New("wrong pool size")
+	ErrBadTimeout        = errors.New("wrong timeout")
+	ErrNoListenAddress   = errors.New("missing listen address")
+	ErrBadLogLevel       = errors.New("wrong log level")
+	ErrNoReportsDir      = errors.New("missing reports directory")
+	ErrBadReportStorage  = errors.New("wrong report storage, must be local, memory or s3")
+	ErrBadS3             = errors.New("wrong s3 endpoint or missing bucket or key prefix")
+	ErrBadRetention      = errors.New("wrong report retention or cleanup interval")
+	ErrBadReportJobs     = errors.New("wrong report workers, poll interval or job timeout")
+	ErrBadBaseURL        = errors.New("wrong base URL")
+	ErrBadReservations   = errors.New("wrong reservation TTL, check interval or batch size")
+	ErrBadServiceID      = errors.New("wrong service ID")
+	ErrBadIdempotencyTTL = errors.New("wrong idempotency TTL")
+	ErrBadMinBalance     = errors.New("wrong minimum balance")
+	ErrBadFee            = errors.New("wrong fee")
 )
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// option is a setting that can be overridden by an environment variable and a flag.
+type option struct {
+	name  string
+	env   string
+	usage string
+	set   func(config *Config, value string) error
+}
+
+var options = []option{
+	stringOption("db-username", "AVITO_DB_USERNAME", "database user", func(c *Config) *string { return &c.Username }),
+	stringOption("db-password", "AVITO_DB_PASSWORD", "database password", func(c *Config) *string { return &c.Password }),
+	stringOption("db-host", "AVITO_DB_HOST", "database host", func(c *Config) *string { return &c.Host }),
+	stringOption("db-port", "AVITO_DB_PORT", "database port", func(c *Config) *string { return &c.Port }),
+	stringOption("db-name", "AVITO_DB_NAME", "database name", func(c *Config) *string { return &c.Database }),
+
+	int32Option("db-max-conns", "AVITO_DB_MAX_CONNS", "maximum pool size", func(c *Config) *int32 { return &c.Pool.MaxConns }),
+	int32Option("db-min-conns", "AVITO_DB_MIN_CONNS", "minimum pool size", func(c *Config) *int32 { return &c.Pool.MinConns }),
+	durationOption("db-max-conn-lifetime", "AVITO_DB_MAX_CONN_LIFETIME", "maximum connection lifetime", func(c *Config) *time.Duration { return &c.Pool.MaxConnLifetime }),
+	durationOption("db-max-conn-idle-time", "AVITO_DB_MAX_CONN_IDLE_TIME", "maximum connection idle time", func(c *Config) *time.Duration { return &c.Pool.MaxConnIdleTime }),
+	durationOption("db-health-check-period", "AVITO_DB_HEALTH_CHECK_PERIOD", "pool health check period", func(c *Config) *time.Duration { return &c.Pool.HealthCheckPeriod }),
+	durationOption("db-acquire-timeout", "AVITO_DB_ACQUIRE_TIMEOUT", "maximum wait for a pool connection", func(c *Config) *time.Duration { return &c.Pool.AcquireTimeout }),
+
+	stringOption("listen-address", "AVITO_LISTEN_ADDRESS", "HTTP listen address", func(c *Config) *string { return &c.ListenAddress }),
+	stringOption("log-level", "AVITO_LOG_LEVEL", "log level", func(c *Config) *string { return &c.LogLevel }),
+	stringOption("reports-dir", "AVITO_REPORTS_DIR", "directory for report files", func(c *Config) *string { return &c.ReportsDir }),
+	stringOption("base-url", "AVITO_BASE_URL", "public URL of the service for report links", func(c *Config) *string { return &c.BaseURL }),
+	stringOption("report-storage", "AVITO_REPORT_STORAGE", "report storage: local, memory or s3", func(c *Config) *string { return &c.Reports.Storage }),
+	durationOption("report-retention", "AVITO_REPORT_RETENTION", "age after which reports are deleted", func(c *Config) *time.Duration { return &c.Reports.Retention }),
+	intOption("report-workers", "AVITO_REPORT_WORKERS", "reports built at once", func(c *Config) *int { return &c.Reports.Workers }),
+	stringOption("s3-endpoint", "AVITO_S3_ENDPOINT", "S3 endpoint URL", func(c *Config) *string { return &c.Reports.S3.Endpoint }),
+	stringOption("s3-bucket", "AVITO_S3_BUCKET", "S3 bucket for reports", func(c *Config) *string { return &c.Reports.S3.Bucket }),
+	stringOption("s3-prefix", "AVITO_S3_PREFIX", "S3 key prefix for reports", func(c *Config) *string { return &c.Reports.S3.Prefix }),
+	stringOption("s3-access-key", "AVITO_S3_ACCESS_KEY", "S3 access key", func(c *Config) *string { return &c.Reports.S3.AccessKey }),
+	stringOption("s3-secret-key", "AVITO_S3_SECRET_KEY", "S3 secret key", func(c *Config) *string { return &c.Reports.S3.SecretKey }),
+	boolOption("migrate-on-start", "AVITO_MIGRATE_ON_START", "apply migrations on start", func(c *Config) *bool { return &c.MigrateOnStart }),
+	durationOption("shutdown-timeout", "AVITO_SHUTDOWN_TIMEOUT", "grace period for in-flight requests", func(c *Config) *time.Duration { return &c.ShutdownTimeout }),
+	stringOption("admin-token", "AVITO_ADMIN_TOKEN", "bearer token for the admin endpoints", func(c *Config) *string { return &c.AdminToken }),
+	stringOption("rates-file", "AVITO_RATES_FILE", "JSON file with exchange rates", func(c *Config) *string { return &c.RatesFile }),
+	durationOption("request-timeout", "AVITO_REQUEST_TIMEOUT", "default request timeout", func(c *Config) *time.Duration { return &c.Timeouts.Default }),
+
+	durationOption("reservation-ttl", "AVITO_RESERVATION_TTL", "time before an unresolved order expires", func(c *Config) *time.Duration { return &c.Reservations.TTL }),
+	durationOption("reservation-check-interval", "AVITO_RESERVATION_CHECK_INTERVAL", "how often expired orders are released", func(c *Config) *time.Duration { return &c.Reservations.CheckInterval }),
+	intOption("reservation-batch-size", "AVITO_RESERVATION_BATCH_SIZE", "orders released per query", func(c *Config) *int { return &c.Reservations.BatchSize }),
+
+	durationOption("idempotency-ttl", "AVITO_IDEMPOTENCY_TTL", "time an Idempotency-Key response is replayed", func(c *Config) *time.Duration { return &c.Idempotency.TTL }),
+}
+
+func stringOption(name, env, usage string, field func(*Config) *string) option {
+	return option{name: name, env: env, usage: usage, set: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func int32Option(name, env, usage string, field func(*Config) *int32) option {
+	return option{name: name, env: env, usage: usage, set: func(c *Config, value string) error {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		*field(c) = int32(n)
+		return nil
+	}}
+}
+
+func intOption(name, env, usage string, field func(*Config) *int) option {
+	return option{name: name, env: env, usage: usage, set: func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+func boolOption(name, env, usage string, field func(*Config) *bool) option {
+	return option{name: name, env: env, usage: usage, set: func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = b
+		return nil
+	}}
+}
+
+func durationOption(name, env, usage string, field func(*Config) *time.Duration) option {
+	return option{name: name, env: env, usage: usage, set: func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = d
+		return nil
+	}}
+}
@@ -1,11 +1,12 @@
 package controller
 
 import (
-	"encoding/csv"
+	"context"
 	"errors"
 	"fmt"
-	"os"
-	"strconv"
+	"io"
+	"sort"
+	"sync"
 	"time"
 
 	Err "Avito/internal/errors"
@@ -17,248 +18,501 @@ import (
 )
 
 type IController interface {
-	Balance(userID uuid.UUID) (*model.User, error)
-	Enrollment(userID uuid.UUID, funds float64) error
-	Transfer(senderID, recipientID uuid.UUID, funds float64) error
-	Order(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error
-	OrderSuccess(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error
-	OrderFailed(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error
-	Report(year, month string) (string, error)
-	History(userID uuid.UUID, offset, limit int) ([]model.History, error)
+	Health(ctx context.Context) error
+	Balance(ctx context.Context, userID uuid.UUID, currency string) (*model.User, error)
+	Enrollment(ctx context.Context, userID uuid.UUID, funds model.Money) error
+	Transfer(ctx context.Context, senderID, recipientID uuid.UUID, funds model.Money) error
+	Withdraw(ctx context.Context, userID uuid.UUID, funds model.Money) error
+	Order(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money, ttl time.Duration) error
+	GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error)
+	OrderSuccess(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money) error
+	OrderFailed(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money) error
+	Refund(ctx context.Context, orderID uuid.UUID, amount model.Money) error
+	ExpireOrders(ctx context.Context, limit int) (int, error)
+	Report(ctx context.Context, q model.ReportQuery) ([]model.Report, error)
+	EnqueueReport(ctx context.Context, q model.ReportQuery) (*model.ReportJob, error)
+	ReportJob(ctx context.Context, id uuid.UUID) (*model.ReportJob, error)
+	RunReportJob(ctx context.Context, timeout time.Duration) (bool, error)
+	OpenReport(ctx context.Context, id uuid.UUID) (io.ReadCloser, error)
+	PurgeReports(ctx context.Context, before time.Time) (int, error)
+	History(ctx context.Context, q model.HistoryQuery) (*model.HistoryPage, error)
+	Reconcile(ctx context.Context, fix bool) ([]model.Discrepancy, error)
+	Rates(ctx context.Context) model.Rates
+	SetRates(ctx context.Context, rates model.Rates) error
+	StartIdempotent(ctx context.Context, key, requestHash string) (*model.Idempotency, error)
+	FinishIdempotent(ctx context.Context, key string, status int, body []byte) error
+	ReleaseIdempotent(ctx context.Context, key string) error
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error)
+	Services(ctx context.Context) ([]model.Service, error)
+	Service(ctx context.Context, id uuid.UUID) (*model.Service, error)
+	CreateService(ctx context.Context, s model.Service) (*model.Service, error)
+	UpdateService(ctx context.Context, s model.Service) (*model.Service, error)
+	DeleteService(ctx context.Context, id uuid.UUID) error
 }
 
 type controller struct {
-	repository IRepository
+	repository     IRepository
+	storage        IStorage
+	reservationTTL ReservationTTL
+	withdrawals    Withdrawals
+	fees           Fees
+
+	ratesMu sync.RWMutex
+	rates   model.Rates
 }
 
-func NewController(repository IRepository) (IController, error) {
+// NewController builds the controller; rates decide which currencies are accepted and how balances convert.
+func NewController(repository IRepository, storage IStorage, reservationTTL ReservationTTL, withdrawals Withdrawals, fees Fees, rates model.Rates) (IController, error) {
 	if repository == nil {
 		return nil, Err.ErrNoRepository
 	}
-	return &controller{repository: repository}, nil
+	if storage == nil {
+		return nil, Err.ErrNoStorage
+	}
+	if err := rates.Validate(); err != nil {
+		return nil, err
+	}
+	return &controller{repository: repository, storage: storage, reservationTTL: reservationTTL, withdrawals: withdrawals, fees: fees, rates: rates}, nil
 }
 
 //go:generate minimock -g -i
 type IRepository interface {
-	Balance(userID uuid.UUID) (*model.User, error)
-	Enrollment(user model.User, funds float64) error
-	Transfer(sender, recipient model.User, funds float64) error
-	AddUser(user model.User) error
-	Order(user model.User, order model.Order) error
-	GetOrder(orderID uuid.UUID) (*model.Order, error)
-	OrderSuccess(order model.Order) error
-	OrderFailed(user model.User, order model.Order) error
-	Report(time.Time) ([]model.Report, error)
-	History(userID uuid.UUID, limit, offset int) ([]model.History, error)
+	Ping(ctx context.Context) error
+	Balance(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	Enrollment(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) error
+	Transfer(ctx context.Context, senderID, recipientID uuid.UUID, funds, fee model.Money, date time.Time) error
+	Withdraw(ctx context.Context, userID uuid.UUID, funds, minBalance model.Money, date time.Time) error
+	Order(ctx context.Context, order model.Order) error
+	GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error)
+	OrderSuccess(ctx context.Context, order model.Order, captured, fee model.Money, date time.Time) error
+	Refund(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) error
+	ReleaseOrder(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) error
+	ExpiredOrders(ctx context.Context, date time.Time, limit int) ([]model.Order, error)
+	Report(ctx context.Context, q model.ReportQuery) ([]model.Report, error)
+	CreateReportJob(ctx context.Context, job model.ReportJob) error
+	GetReportJob(ctx context.Context, id uuid.UUID) (*model.ReportJob, error)
+	ClaimReportJob(ctx context.Context, date, staleBefore time.Time) (*model.ReportJob, error)
+	FinishReportJob(ctx context.Context, job model.ReportJob, date time.Time) error
+	DeleteReportJobs(ctx context.Context, before time.Time) (int, error)
+	History(ctx context.Context, q model.HistoryQuery) (*model.HistoryPage, error)
+	Discrepancies(ctx context.Context) ([]model.Discrepancy, error)
+	Adjust(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.Discrepancy, error)
+	CreateIdempotencyKey(ctx context.Context, key model.Idempotency, staleBefore time.Time) (bool, error)
+	GetIdempotencyKey(ctx context.Context, key string) (*model.Idempotency, error)
+	SaveIdempotencyResponse(ctx context.Context, key string, status int, body []byte) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	DeleteIdempotencyKeys(ctx context.Context, before time.Time) (int, error)
+	CreateService(ctx context.Context, s model.Service) error
+	GetService(ctx context.Context, id uuid.UUID) (*model.Service, error)
+	Services(ctx context.Context) ([]model.Service, error)
+	UpdateService(ctx context.Context, s model.Service) error
+	DeleteService(ctx context.Context, id uuid.UUID) error
+}
+
+func (c *controller) Health(ctx context.Context) error {
+	logrus.Infoln("Starting controller.Health")
+
+	err := c.repository.Ping(ctx)
+
+	logrus.Infoln("Ending controller.Health")
+	return err
 }
 
-func (c *controller) Balance(userID uuid.UUID) (*model.User, error) {
+// Balance returns the user's wallets. Without a currency Funds is the DefaultCurrency wallet;
+// with one, Funds is the sum of all wallets converted into it.
+func (c *controller) Balance(ctx context.Context, userID uuid.UUID, currency string) (*model.User, error) {
 	logrus.Infoln("Starting controller.Balance")
 
-	user, err := c.repository.Balance(userID)
+	rates := c.currentRates()
+	if currency != "" && !rates.Supported(currency) {
+		logrus.Errorf("%s: %s\n", Err.ErrUnsupportedCurrency, currency)
+		logrus.Infoln("Ending controller.Balance")
+		return nil, Err.ErrUnsupportedCurrency.WithDetails(Err.Field("currency", "no exchange rate for "+currency))
+	}
+
+	user, err := c.repository.Balance(ctx, userID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = Err.ErrUserNotFound.WithDetails(Err.Field("id", "does not exist"))
+		}
 		logrus.Infoln("Ending controller.Balance")
 		return nil, err
 	}
 
-	logrus.Infoln("Ending controller.Balance")
-	return user, err
-}
-
-func (c *controller) Enrollment(userID uuid.UUID, funds float64) error {
-	logrus.Infoln("Starting controller.Accrual")
-
-	user := model.User{ID: userID, Funds: funds}
+	if currency == "" {
+		user.Currency, user.Funds = model.DefaultCurrency, model.NewMoney(0, model.DefaultCurrency)
+		for _, w := range user.Wallets {
+			if w.Currency == model.DefaultCurrency {
+				user.Funds = w.Funds
+			}
+		}
+		logrus.Infoln("Ending controller.Balance")
+		return user, nil
+	}
 
-	balance, err := c.repository.Balance(userID)
-	if err != nil {
-		if !errors.Is(err, pgx.ErrNoRows) {
-			logrus.Infoln("Ending controller.Enrollment")
-			return err
+	user.Currency, user.Funds = currency, model.NewMoney(0, currency)
+	for _, w := range user.Wallets {
+		converted, err := rates.Convert(w.Funds, currency)
+		if err == nil {
+			user.Funds, err = user.Funds.Add(converted)
 		}
+		if err != nil {
+			logrus.Errorf("Convert %s %s to %s: %s\n", w.Funds, w.Currency, currency, err)
+			if errors.Is(err, Err.ErrUnsupportedCurrency) {
+				err = Err.ErrUnsupportedCurrency.WithDetails(Err.Field("currency", "no exchange rate for the wallet in "+w.Currency))
+			}
+			logrus.Infoln("Ending controller.Balance")
+			return nil, err
+		}
+	}
 
-		user.DateCreate = time.Now()
-		user.LastUpdate = time.Now()
+	logrus.Infoln("Ending controller.Balance")
+	return user, nil
+}
 
-		err = c.repository.AddUser(user)
+func (c *controller) Enrollment(ctx context.Context, userID uuid.UUID, funds model.Money) error {
+	logrus.Infoln("Starting controller.Enrollment")
 
+	if err := c.checkCurrency("currency", funds); err != nil {
 		logrus.Infoln("Ending controller.Enrollment")
 		return err
 	}
 
-	user.Funds += balance.Funds
-	user.LastUpdate = time.Now()
-
-	err = c.repository.Enrollment(user, funds)
+	err := c.repository.Enrollment(ctx, userID, funds, time.Now())
 
 	logrus.Infoln("Ending controller.Enrollment")
 	return err
 }
 
-func (c *controller) Transfer(senderID, recipientID uuid.UUID, funds float64) error {
+// Transfer moves funds to the recipient; the sender also pays the transfer fee.
+func (c *controller) Transfer(ctx context.Context, senderID, recipientID uuid.UUID, funds model.Money) error {
 	logrus.Infoln("Starting controller.Transfer")
 
-	sender, err := c.repository.Balance(senderID)
-	if err != nil {
+	if senderID == recipientID {
+		logrus.Errorf("%s sender and recipient: %s\n", Err.ErrBadRequest, senderID)
 		logrus.Infoln("Ending controller.Transfer")
-		return err
+		return Err.Validation(Err.Field("recipient_id", "must differ from sender_id"))
 	}
 
-	if sender.Funds < funds {
-		logrus.Errorf("%s sender.Fuds: %s, funds: %s\n", Err.ErrInsufficientFunds, sender.Funds, funds)
+	if err := c.checkCurrency("currency", funds); err != nil {
 		logrus.Infoln("Ending controller.Transfer")
-		return Err.ErrInsufficientFunds
+		return err
 	}
 
-	recipient, err := c.repository.Balance(recipientID)
+	fee, err := c.fees.Transfer(funds)
 	if err != nil {
+		logrus.Errorf("Fee %s: %s\n", funds, err)
 		logrus.Infoln("Ending controller.Transfer")
 		return err
 	}
 
-	sender.Funds -= funds
-	sender.LastUpdate = time.Now()
-
-	recipient.Funds += funds
-	recipient.LastUpdate = time.Now()
-
-	err = c.repository.Transfer(*sender, *recipient, funds)
+	err = c.repository.Transfer(ctx, senderID, recipientID, funds, fee, time.Now())
+	if errors.Is(err, Err.ErrInsufficientFunds) {
+		logrus.Errorf("%s sender: %s, funds: %s, fee: %s\n", err, senderID, funds, fee)
+		if fee.IsPositive() {
+			err = Err.ErrInsufficientFunds.WithDetails(Err.Field("funds", "the balance must cover the amount and the fee "+fee.String()))
+		}
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = c.missingUsers(ctx, map[string]uuid.UUID{"sender_id": senderID, "recipient_id": recipientID})
+	}
 
 	logrus.Infoln("Ending controller.Transfer")
 	return err
 }
 
-func (c *controller) Order(userID, serviceID, orderID uuid.UUID, serviceName string, funds float64) error {
+// Order reserves funds. The reservation expires after ttl or, when ttl is zero,
+// after the TTL configured for the service.
+func (c *controller) Order(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, funds model.Money, ttl time.Duration) error {
 	logrus.Infoln("Starting controller.Order")
 
-	user, err := c.repository.Balance(userID)
-	if err != nil {
+	if err := c.checkCurrency("currency", funds); err != nil {
 		logrus.Infoln("Ending controller.Order")
 		return err
 	}
 
-	if funds > user.Funds {
-		logrus.Errorf("%s user.Funds: %s, cost: %s\n", Err.ErrInsufficientFunds, user.Funds, funds)
+	service, err := c.repository.GetService(ctx, serviceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = Err.ErrServiceNotFound.WithDetails(Err.Field("service_id", "is not in the catalog"))
+	}
+	if err != nil {
 		logrus.Infoln("Ending controller.Order")
-		return Err.ErrInsufficientFunds
+		return err
 	}
+	if err := service.CheckOrder(serviceName, funds); err != nil {
+		logrus.Errorf("%s service: %s, name: %s, cost: %s\n", err, serviceID, serviceName, funds)
+		logrus.Infoln("Ending controller.Order")
+		return err
+	}
+	serviceName = service.Name
 
-	user.Funds -= funds
-	user.LastUpdate = time.Now()
-
-	order := model.Order{ID: orderID, UserID: userID, ServiceID: serviceID, ServiceName: serviceName, DateCreate: time.Now(), Funds: funds}
+	order := model.Order{ID: orderID, UserID: userID, ServiceID: serviceID, ServiceName: serviceName, DateCreate: time.Now(), Funds: funds, Currency: funds.CurrencyCode()}
+	if ttl == 0 {
+		ttl = c.reservationTTL.For(serviceID)
+	}
+	if ttl > 0 {
+		expiresAt := order.DateCreate.Add(ttl)
+		order.ExpiresAt = &expiresAt
+	}
 
-	err = c.repository.Order(*user, order)
+	err = c.repository.Order(ctx, order)
+	if errors.Is(err, Err.ErrInsufficientFunds) {
+		logrus.Errorf("%s user: %s, cost: %s\n", err, userID, funds)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = Err.ErrUserNotFound.WithDetails(Err.Field("user_id", "does not exist"))
+	}
 
 	logrus.Infoln("Ending controller.Order")
 	return err
 }
 
-func (c *controller) OrderSuccess(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error {
+func (c *controller) GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error) {
+	logrus.Infoln("Starting controller.GetOrder")
+
+	order, err := c.repository.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = Err.ErrOrderNotFound.WithDetails(Err.Field("order_id", "does not exist"))
+		}
+		logrus.Infoln("Ending controller.GetOrder")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending controller.GetOrder")
+	return order, nil
+}
+
+// OrderSuccess captures cost, which may be less than the reservation; the rest is returned to the user.
+// The commission on cost is withheld from the service revenue.
+func (c *controller) OrderSuccess(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money) error {
 	logrus.Infoln("Starting controller.OrderSuccess")
 
-	order, err := c.repository.GetOrder(orderID)
+	order, err := c.GetOrder(ctx, orderID)
 	if err != nil {
 		logrus.Infoln("Ending controller.OrderSuccess")
 		return err
 	}
 
-	if userID != order.UserID || serviceID != order.ServiceID || orderID != order.ID || serviceName != order.ServiceName || cost != order.Funds {
-		logrus.Errorln(Err.ErrBadRequest)
-		return Err.ErrBadRequest
+	serviceName = c.orderServiceName(ctx, order, serviceName)
+	if mismatch := orderMismatch(order, userID, serviceID, serviceName, nil); mismatch != nil {
+		logrus.Errorln(mismatch)
+		logrus.Infoln("Ending controller.OrderSuccess")
+		return mismatch
 	}
 
-	err = c.repository.OrderSuccess(model.Order{ID: orderID, UserID: userID, ServiceID: serviceID, ServiceName: serviceName, DateCreate: order.DateCreate, Funds: order.Funds})
+	cost = inOrderCurrency(order, cost)
+	if !cost.SameCurrency(order.Funds) {
+		logrus.Errorf("%s order: %s, cost: %s %s\n", Err.ErrCurrencyMismatch, orderID, cost, cost.CurrencyCode())
+		logrus.Infoln("Ending controller.OrderSuccess")
+		return Err.ErrCurrencyMismatch.WithDetails(Err.Field("currency", "must be the order currency "+order.Funds.CurrencyCode()))
+	}
 
-	logrus.Infoln("Ending controller.OrderSuccess")
-	return err
-}
+	if order.Funds.LessThan(cost) {
+		logrus.Errorf("%s order: %s, cost: %s\n", Err.ErrCaptureExceedsReservation, orderID, cost)
+		logrus.Infoln("Ending controller.OrderSuccess")
+		return Err.ErrCaptureExceedsReservation.WithDetails(Err.Field("cost", "must not exceed the reserved "+order.Funds.String()))
+	}
 
-func (c *controller) OrderFailed(userID, serviceID, orderID uuid.UUID, serviceName string, cost float64) error {
-	logrus.Infoln("Starting controller.OrderFailed")
+	if err := checkTransition(order, model.OrderConfirmed); err != nil {
+		logrus.Errorf("%s order: %s\n", err, orderID)
+		logrus.Infoln("Ending controller.OrderSuccess")
+		return err
+	}
 
-	order, err := c.repository.GetOrder(orderID)
+	fee, err := c.fees.Order(order.ServiceID, cost)
 	if err != nil {
-		logrus.Infoln("Ending controller.OrderFailed")
+		logrus.Errorf("Fee %s: %s\n", cost, err)
+		logrus.Infoln("Ending controller.OrderSuccess")
 		return err
 	}
 
-	if userID != order.UserID || serviceID != order.ServiceID || orderID != order.ID || serviceName != order.ServiceName || cost != order.Funds {
-		logrus.Errorln(Err.ErrBadRequest)
-		return Err.ErrBadRequest
+	err = c.repository.OrderSuccess(ctx, *order, cost, fee, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Resolved concurrently after GetOrder.
+		err = Err.ErrOrderTransition.WithDetails(Err.Field("status", "order is no longer reserved"))
 	}
 
-	user, err := c.repository.Balance(userID)
+	logrus.Infoln("Ending controller.OrderSuccess")
+	return err
+}
+
+func (c *controller) OrderFailed(ctx context.Context, userID, serviceID, orderID uuid.UUID, serviceName string, cost model.Money) error {
+	logrus.Infoln("Starting controller.OrderFailed")
+
+	order, err := c.GetOrder(ctx, orderID)
 	if err != nil {
 		logrus.Infoln("Ending controller.OrderFailed")
 		return err
 	}
 
-	user.Funds += cost
-	user.LastUpdate = time.Now()
+	cost = inOrderCurrency(order, cost)
+	serviceName = c.orderServiceName(ctx, order, serviceName)
+	if mismatch := orderMismatch(order, userID, serviceID, serviceName, &cost); mismatch != nil {
+		logrus.Errorln(mismatch)
+		logrus.Infoln("Ending controller.OrderFailed")
+		return mismatch
+	}
 
-	err = c.repository.OrderFailed(*user, *order)
+	err = c.release(ctx, order, model.OrderCancelled, time.Now())
 
 	logrus.Infoln("Ending controller.OrderFailed")
 	return err
 }
 
-func (c *controller) Report(year, month string) (string, error) {
-	logrus.Infoln("Starting controller.Report")
+// Refund returns part of a confirmed order to the user; refunds add up to at most the captured amount.
+func (c *controller) Refund(ctx context.Context, orderID uuid.UUID, amount model.Money) error {
+	logrus.Infoln("Starting controller.Refund")
 
-	date := fmt.Sprintf("%s-%s-01", year, month)
-	t, err := time.Parse("2006-01-02", date)
+	order, err := c.GetOrder(ctx, orderID)
 	if err != nil {
-		logrus.Errorf("Parse %s: %s\n", date, err)
-		logrus.Infoln("Ending controller.Report")
-		return "", Err.ErrBadRequest
+		logrus.Infoln("Ending controller.Refund")
+		return err
 	}
 
-	rep, err := c.repository.Report(t)
-	if err != nil {
-		logrus.Infoln("Ending controller.Report")
-		return "", err
+	if order.Status != model.OrderConfirmed {
+		logrus.Errorf("%s order: %s, status: %s\n", Err.ErrOrderTransition, orderID, order.Status)
+		logrus.Infoln("Ending controller.Refund")
+		return Err.ErrOrderTransition.WithDetails(Err.Field("status", fmt.Sprintf("order is %s, only confirmed orders can be refunded", order.Status)))
 	}
 
-	var report [][]string
-	for _, r := range rep {
-		var slice []string
-		slice = append(slice, r.ServiceName, strconv.FormatFloat(r.Revenue, 'f', -1, 64))
-		report = append(report, slice)
+	amount = inOrderCurrency(order, amount)
+	if !amount.SameCurrency(order.Funds) {
+		logrus.Errorf("%s order: %s, amount: %s %s\n", Err.ErrCurrencyMismatch, orderID, amount, amount.CurrencyCode())
+		logrus.Infoln("Ending controller.Refund")
+		return Err.ErrCurrencyMismatch.WithDetails(Err.Field("currency", "must be the order currency "+order.Funds.CurrencyCode()))
 	}
 
-	id := uuid.New().String()
-	csvFile, err := os.Create("./reports/" + id + ".csv")
+	refundable, err := order.Captured.Sub(order.Refunded)
 	if err != nil {
-		logrus.Errorln("Create: ", err)
-		logrus.Infoln("Ending controller.Report")
-		return "", err
+		logrus.Infoln("Ending controller.Refund")
+		return err
+	}
+	if refundable.LessThan(amount) {
+		logrus.Errorf("%s order: %s, amount: %s\n", Err.ErrRefundExceedsCapture, orderID, amount)
+		logrus.Infoln("Ending controller.Refund")
+		return Err.ErrRefundExceedsCapture.WithDetails(Err.Field("amount", "must not exceed the refundable "+refundable.String()))
 	}
 
-	csvWriter := csv.NewWriter(csvFile)
-	for _, row := range report {
-		_ = csvWriter.Write(row)
+	err = c.repository.Refund(ctx, orderID, amount, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Another refund of the same order got there first.
+		err = Err.ErrRefundExceedsCapture
 	}
-	csvWriter.Flush()
 
-	logrus.Infoln("Ending controller.Report")
-	return id, nil
+	logrus.Infoln("Ending controller.Refund")
+	return err
 }
 
-func (c *controller) History(userID uuid.UUID, limit, offset int) ([]model.History, error) {
+func (c *controller) History(ctx context.Context, q model.HistoryQuery) (*model.HistoryPage, error) {
 	logrus.Infoln("Starting controller.History")
 
-	if _, err := c.repository.Balance(userID); err != nil {
+	if err := q.Validate(); err != nil {
+		logrus.Errorf("Validate %+v: %s\n", q, err)
 		logrus.Infoln("Ending controller.History")
 		return nil, err
 	}
 
-	report, err := c.repository.History(userID, limit, offset)
+	if _, err := c.repository.Balance(ctx, q.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = Err.ErrUserNotFound.WithDetails(Err.Field("id", "does not exist"))
+		}
+		logrus.Infoln("Ending controller.History")
+		return nil, err
+	}
+
+	page, err := c.repository.History(ctx, q)
 	if err != nil {
 		logrus.Infoln("Ending controller.History")
 		return nil, err
 	}
 
 	logrus.Infoln("Ending controller.History")
-	return report, nil
+	return page, nil
+}
+
+// inOrderCurrency gives an amount sent without a currency the currency of the order.
+func inOrderCurrency(order *model.Order, m model.Money) model.Money {
+	if m.Currency == "" {
+		m.Currency = order.Funds.CurrencyCode()
+	}
+	return m
+}
+
+// orderMismatch lists the request fields that differ from the stored reservation.
+// A nil cost is not compared.
+func orderMismatch(order *model.Order, userID, serviceID uuid.UUID, serviceName string, cost *model.Money) error {
+	var details []Err.FieldError
+	if userID != order.UserID {
+		details = append(details, Err.Field("user_id", "does not match the order"))
+	}
+	if serviceID != order.ServiceID {
+		details = append(details, Err.Field("service_id", "does not match the order"))
+	}
+	if serviceName != order.ServiceName {
+		details = append(details, Err.Field("service_name", "does not match the order"))
+	}
+	if cost != nil && !cost.Equal(order.Funds) {
+		details = append(details, Err.Field("cost", "does not match the order"))
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return Err.ErrOrderMismatch.WithDetails(details...)
+}
+
+// orderServiceName maps the service name a caller gave for an order to the name the order
+// was reserved under. The caller may leave the name out or use the current catalog name.
+func (c *controller) orderServiceName(ctx context.Context, order *model.Order, name string) string {
+	if name == "" || name == order.ServiceName {
+		return order.ServiceName
+	}
+	service, err := c.repository.GetService(ctx, order.ServiceID)
+	if err != nil {
+		logrus.Errorf("Service %s of order %s: %s\n", order.ServiceID, order.ID, err)
+		return name
+	}
+	if service.Name == name {
+		return order.ServiceName
+	}
+	return name
+}
+
+// release returns the reserved funds of a cancelled or expired order.
+func (c *controller) release(ctx context.Context, order *model.Order, status model.OrderStatus, date time.Time) error {
+	if err := checkTransition(order, status); err != nil {
+		logrus.Errorf("%s order: %s\n", err, order.ID)
+		return err
+	}
+
+	err := c.repository.ReleaseOrder(ctx, *order, status, date)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Resolved concurrently after the order was read.
+		err = Err.ErrOrderTransition.WithDetails(Err.Field("status", "order is no longer reserved"))
+	}
+	return err
+}
+
+// checkTransition rejects status changes the order lifecycle does not allow.
+func checkTransition(order *model.Order, next model.OrderStatus) error {
+	if order.Status.CanTransitionTo(next) {
+		return nil
+	}
+	return Err.ErrOrderTransition.WithDetails(Err.Field("status", fmt.Sprintf("order is %s, cannot become %s", order.Status, next)))
+}
+
+// missingUsers tells which of the users does not exist.
+func (c *controller) missingUsers(ctx context.Context, users map[string]uuid.UUID) error {
+	var details []Err.FieldError
+	for field, id := range users {
+		_, err := c.repository.Balance(ctx, id)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			details = append(details, Err.Field(field, "does not exist"))
+		case err != nil:
+			return err
+		}
+	}
+	sort.Slice(details, func(i, j int) bool { return details[i].Field < details[j].Field })
+	return Err.ErrUserNotFound.WithDetails(details...)
 }
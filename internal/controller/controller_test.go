@@ -3,6 +3,11 @@ package controller
 import (
 	Err "Avito/internal/errors"
 	"Avito/internal/model"
+	"Avito/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -12,203 +17,1006 @@ import (
 )
 
 func TestController_Balance(t *testing.T) {
-	mRepo := NewIRepositoryMock(t)
-
-	c, err := NewController(mRepo)
-	require.NoError(t, err)
+	rates := model.Rates{Base: model.DefaultCurrency, Rates: map[string]json.Number{"USD": "0.01"}}
 
 	t.Run("failed", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, rates)
+		require.NoError(t, err)
+
 		mRepo.BalanceMock.Return(nil, pgx.ErrNoRows)
 
-		res, err := c.Balance(uuid.New())
-		require.ErrorIs(t, err, pgx.ErrNoRows)
+		res, err := c.Balance(context.Background(), uuid.New(), "")
+		require.ErrorIs(t, err, Err.ErrUserNotFound)
 		require.Nil(t, res)
 	})
 
+	t.Run("failed: unsupported currency", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, rates)
+		require.NoError(t, err)
+
+		res, err := c.Balance(context.Background(), uuid.New(), "EUR")
+		require.ErrorIs(t, err, Err.ErrUnsupportedCurrency)
+		require.Nil(t, res)
+		require.Zero(t, mRepo.BalanceAfterCounter())
+	})
+
+	wallets := []model.Wallet{
+		{Currency: model.DefaultCurrency, Funds: model.NewMoney(1000, model.DefaultCurrency)},
+		{Currency: "USD", Funds: model.NewMoney(250, "USD")},
+	}
+
 	t.Run("success", func(t *testing.T) {
-		m := &model.User{
-			ID:         uuid.New(),
-			Funds:      10,
-			DateCreate: time.Now(),
-			LastUpdate: time.Now(),
-		}
-		mRepo.BalanceMock.Return(m, nil)
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, rates)
+		require.NoError(t, err)
+
+		mRepo.BalanceMock.Return(&model.User{ID: uuid.New(), DateCreate: time.Now(), LastUpdate: time.Now(), Wallets: wallets}, nil)
 
-		res, err := c.Balance(uuid.New())
+		res, err := c.Balance(context.Background(), uuid.New(), "")
 		require.NoError(t, err)
-		require.Equal(t, res, m)
+		require.Equal(t, model.DefaultCurrency, res.Currency)
+		require.True(t, res.Funds.Equal(model.NewMoney(1000, model.DefaultCurrency)))
+		require.Len(t, res.Wallets, 2)
+	})
+
+	t.Run("success: converted", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, rates)
+		require.NoError(t, err)
+
+		mRepo.BalanceMock.Return(&model.User{ID: uuid.New(), Wallets: wallets}, nil)
+
+		res, err := c.Balance(context.Background(), uuid.New(), "USD")
+		require.NoError(t, err)
+		require.Equal(t, "USD", res.Currency)
+		require.True(t, res.Funds.Equal(model.NewMoney(260, "USD")))
 	})
 }
 
 func TestController_Transfer(t *testing.T) {
-	mRepo := NewIRepositoryMock(t)
+	t.Run("failed: insufficient funds", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
 
-	c, err := NewController(mRepo)
-	require.NoError(t, err)
+		mRepo.TransferMock.Return(Err.ErrInsufficientFunds)
 
-	t.Run("failed", func(t *testing.T) {
-		sender := &model.User{
-			ID:         uuid.New(),
-			Funds:      10,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
-		}
-		receiver := &model.User{
-			ID:         uuid.New(),
-			Funds:      0,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
-		}
+		err = c.Transfer(context.Background(), uuid.New(), uuid.New(), model.NewMoney(100000, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrInsufficientFunds)
+	})
 
-		mRepo.BalanceMock.Set(func(userID uuid.UUID) (up1 *model.User, err error) {
-			if userID == sender.ID {
-				return sender, nil
+	t.Run("failed: unknown recipient", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		senderID, recipientID := uuid.New(), uuid.New()
+		mRepo.TransferMock.Return(pgx.ErrNoRows)
+		mRepo.BalanceMock.Set(func(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+			if userID == recipientID {
+				return nil, pgx.ErrNoRows
 			}
-			return receiver, nil
+			return &model.User{ID: userID}, nil
 		})
 
-		err := c.Transfer(sender.ID, receiver.ID, 1000)
-		require.ErrorIs(t, err, Err.ErrInsufficientFunds)
+		err = c.Transfer(context.Background(), senderID, recipientID, model.NewMoney(500, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrUserNotFound)
+
+		var e *Err.Error
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, []Err.FieldError{Err.Field("recipient_id", "does not exist")}, e.Details)
+	})
+
+	t.Run("failed: same user", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		id := uuid.New()
+		err = c.Transfer(context.Background(), id, id, model.NewMoney(500, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrBadRequest)
 	})
 
 	t.Run("success", func(t *testing.T) {
-		sender := &model.User{
-			ID:         uuid.New(),
-			Funds:      10,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
-		}
-		receiver := &model.User{
-			ID:         uuid.New(),
-			Funds:      0,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
-		}
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
 
-		mRepo.BalanceMock.Set(func(userID uuid.UUID) (up1 *model.User, err error) {
-			if userID == sender.ID {
-				return sender, nil
-			}
-			return receiver, nil
+		senderID, recipientID := uuid.New(), uuid.New()
+		funds := model.NewMoney(500, model.DefaultCurrency)
+
+		mRepo.TransferMock.Set(func(ctx context.Context, s, r uuid.UUID, f, fee model.Money, date time.Time) (err error) {
+			require.Equal(t, senderID, s)
+			require.Equal(t, recipientID, r)
+			require.Equal(t, funds, f)
+			return nil
 		})
 
-		mRepo.TransferMock.Return(nil)
+		err = c.Transfer(context.Background(), senderID, recipientID, funds)
+		require.NoError(t, err)
+	})
 
-		err := c.Transfer(sender.ID, receiver.ID, 5)
+	t.Run("fee", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		fees := Fees{Transfers: model.FeeRule{Percent: "1", Fixed: map[string]model.Money{"RUB": model.NewMoney(100, "RUB")}}}
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, fees, model.DefaultRates())
 		require.NoError(t, err)
+
+		mRepo.TransferMock.Set(func(ctx context.Context, s, r uuid.UUID, f, fee model.Money, date time.Time) (err error) {
+			require.True(t, fee.Equal(model.NewMoney(150, model.DefaultCurrency)))
+			return Err.ErrInsufficientFunds
+		})
+
+		err = c.Transfer(context.Background(), uuid.New(), uuid.New(), model.NewMoney(5000, model.DefaultCurrency))
+		var e *Err.Error
+		require.ErrorAs(t, err, &e)
+		require.ErrorIs(t, e, Err.ErrInsufficientFunds)
+		require.Equal(t, []Err.FieldError{Err.Field("funds", "the balance must cover the amount and the fee 1.50")}, e.Details)
 	})
 }
 
 func TestController_Report(t *testing.T) {
 	mRepo := NewIRepositoryMock(t)
 
-	c, err := NewController(mRepo)
+	c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
 	require.NoError(t, err)
 
+	q := model.MonthQuery(time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC))
+
 	t.Run("failed", func(t *testing.T) {
-		res, err := c.Report("wrong year", "wrong month")
+		bad := q
+		bad.To = bad.From
+		res, err := c.EnqueueReport(context.Background(), bad)
 		require.ErrorIs(t, err, Err.ErrBadRequest)
 		require.Nil(t, res)
+
+		bad = q
+		bad.Period = "year"
+		_, err = c.Report(context.Background(), bad)
+		require.ErrorIs(t, err, Err.ErrBadRequest)
 	})
 
-	t.Run("success", func(t *testing.T) {
+	var job model.ReportJob
+	t.Run("enqueue", func(t *testing.T) {
+		mRepo.CreateReportJobMock.Set(func(ctx context.Context, j model.ReportJob) error {
+			job = j
+			return nil
+		})
+
+		res, err := c.EnqueueReport(context.Background(), q)
+		require.NoError(t, err)
+		require.Equal(t, model.ReportPending, res.Status)
+		require.Equal(t, job, *res)
+	})
+
+	t.Run("run", func(t *testing.T) {
 		reports := []model.Report{
 			{
 				ServiceName: uuid.New().String(),
-				Revenue:     1000,
+				Revenue:     model.NewMoney(100000, model.DefaultCurrency),
+				Currency:    model.DefaultCurrency,
 			},
 		}
-		mRepo.ReportMock.Return(reports, nil)
+		started := time.Now()
+		mRepo.ClaimReportJobMock.Set(func(ctx context.Context, date, staleBefore time.Time) (*model.ReportJob, error) {
+			require.Equal(t, time.Minute, date.Sub(staleBefore))
+			claimed := job
+			claimed.Status, claimed.StartedAt = model.ReportRunning, &started
+			return &claimed, nil
+		})
+		mRepo.ReportMock.Set(func(ctx context.Context, rq model.ReportQuery) ([]model.Report, error) {
+			require.Equal(t, q, rq)
+			return reports, nil
+		})
+		var finished model.ReportJob
+		mRepo.FinishReportJobMock.Set(func(ctx context.Context, j model.ReportJob, date time.Time) error {
+			finished = j
+			return nil
+		})
+
+		ran, err := c.RunReportJob(context.Background(), time.Minute)
+		require.NoError(t, err)
+		require.True(t, ran)
+		require.Equal(t, model.ReportDone, finished.Status)
+		require.Equal(t, &started, finished.StartedAt)
 
-		res, err := c.Report("2022", "05")
+		r, err := c.OpenReport(context.Background(), job.ID)
 		require.NoError(t, err)
-		require.NotEmpty(t, res)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "service_name,revenue,currency\n"+reports[0].ServiceName+",1000.00,RUB\n", string(data))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := c.OpenReport(context.Background(), uuid.New())
+		require.ErrorIs(t, err, Err.ErrReportNotFound)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		mRepo.DeleteReportJobsMock.Return(1, nil)
+
+		n, err := c.PurgeReports(context.Background(), time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	})
+}
+
+func TestController_RunReportJob(t *testing.T) {
+	t.Run("empty queue", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.ClaimReportJobMock.Return(nil, pgx.ErrNoRows)
+
+		ran, err := c.RunReportJob(context.Background(), time.Minute)
+		require.NoError(t, err)
+		require.False(t, ran)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		job := model.ReportJob{ID: uuid.New(), Status: model.ReportRunning, Query: model.MonthQuery(time.Now())}
+		mRepo.ClaimReportJobMock.Return(&job, nil)
+		mRepo.ReportMock.Return(nil, errors.New("connection reset"))
+		var finished model.ReportJob
+		mRepo.FinishReportJobMock.Set(func(ctx context.Context, j model.ReportJob, date time.Time) error {
+			finished = j
+			return nil
+		})
+
+		ran, err := c.RunReportJob(context.Background(), time.Minute)
+		require.NoError(t, err)
+		require.True(t, ran)
+		require.Equal(t, model.ReportFailed, finished.Status)
+		require.Equal(t, Err.ErrInternal.Message, finished.Error)
+
+		_, err = c.OpenReport(context.Background(), job.ID)
+		require.ErrorIs(t, err, Err.ErrReportNotFound)
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		job := model.ReportJob{ID: uuid.New(), Status: model.ReportRunning, Query: model.MonthQuery(time.Now())}
+		mRepo.ClaimReportJobMock.Return(&job, nil)
+		mRepo.ReportMock.Set(func(ctx context.Context, q model.ReportQuery) ([]model.Report, error) {
+			cancel()
+			return nil, ctx.Err()
+		})
+		var finished model.ReportJob
+		mRepo.FinishReportJobMock.Set(func(ctx context.Context, j model.ReportJob, date time.Time) error {
+			require.NoError(t, ctx.Err())
+			finished = j
+			return nil
+		})
+
+		ran, err := c.RunReportJob(ctx, time.Minute)
+		require.NoError(t, err)
+		require.True(t, ran)
+		require.Equal(t, model.ReportPending, finished.Status)
+	})
+}
+
+func TestController_ReportJob(t *testing.T) {
+	mRepo := NewIRepositoryMock(t)
+	c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+	require.NoError(t, err)
+
+	mRepo.GetReportJobMock.Return(nil, pgx.ErrNoRows)
+
+	_, err = c.ReportJob(context.Background(), uuid.New())
+	require.ErrorIs(t, err, Err.ErrReportNotFound)
+}
+
+func TestController_History(t *testing.T) {
+	t.Run("failed: bad query", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		_, err = c.History(context.Background(), model.HistoryQuery{UserID: uuid.New(), Limit: 10, Sort: "name"})
+		require.ErrorIs(t, err, Err.ErrBadRequest)
+		require.Zero(t, mRepo.BalanceAfterCounter())
+	})
+
+	t.Run("failed: unknown user", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.BalanceMock.Return(nil, pgx.ErrNoRows)
+
+		res, err := c.History(context.Background(), model.HistoryQuery{UserID: uuid.New(), Limit: 10, Sort: model.SortDate})
+		require.ErrorIs(t, err, Err.ErrUserNotFound)
+		require.Nil(t, res)
+		require.Zero(t, mRepo.HistoryAfterCounter())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		q := model.HistoryQuery{UserID: uuid.New(), Limit: 10, Sort: model.SortAmount, Types: []model.HistoryType{model.HistoryPurchase}}
+		page := &model.HistoryPage{Entries: []model.History{{UserID: q.UserID, Type: model.HistoryPurchase, Amount: model.NewMoney(-100, model.DefaultCurrency)}}}
+		mRepo.BalanceMock.Return(&model.User{ID: q.UserID}, nil)
+		mRepo.HistoryMock.Expect(context.Background(), q).Return(page, nil)
+
+		res, err := c.History(context.Background(), q)
+		require.NoError(t, err)
+		require.Equal(t, page, res)
 	})
 }
 
 func TestController_Enrollment(t *testing.T) {
 	mRepo := NewIRepositoryMock(t)
 
-	c, err := NewController(mRepo)
+	c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
 	require.NoError(t, err)
 
-	t.Run("success: add new user", func(t *testing.T) {
-		m := &model.User{
-			ID:         uuid.New(),
-			Funds:      10,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
+	t.Run("success", func(t *testing.T) {
+		userID := uuid.New()
+		funds := model.NewMoney(1000, model.DefaultCurrency)
+
+		mRepo.EnrollmentMock.Set(func(ctx context.Context, id uuid.UUID, enrolled model.Money, date time.Time) (err error) {
+			require.Equal(t, userID, id)
+			require.Equal(t, funds, enrolled)
+			require.False(t, date.IsZero())
+
+			return nil
+		})
+
+		err := c.Enrollment(context.Background(), userID, funds)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed: unsupported currency", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		err = c.Enrollment(context.Background(), uuid.New(), model.NewMoney(100, "USD"))
+		require.ErrorIs(t, err, Err.ErrUnsupportedCurrency)
+		require.Zero(t, mRepo.EnrollmentAfterCounter())
+	})
+}
+
+func TestController_Order(t *testing.T) {
+	t.Run("failed", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetServiceMock.Return(&model.Service{Name: "service", Active: true}, nil)
+		mRepo.OrderMock.Return(Err.ErrInsufficientFunds)
+
+		err = c.Order(context.Background(), uuid.New(), uuid.New(), uuid.New(), "service", model.NewMoney(10000, model.DefaultCurrency), 0)
+		require.ErrorIs(t, err, Err.ErrInsufficientFunds)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		userID, serviceID, orderID := uuid.New(), uuid.New(), uuid.New()
+		cost := model.NewMoney(10000, model.DefaultCurrency)
+
+		mRepo.GetServiceMock.Expect(context.Background(), serviceID).Return(&model.Service{ID: serviceID, Name: "service", Active: true}, nil)
+		mRepo.OrderMock.Set(func(ctx context.Context, order model.Order) (err error) {
+			require.Equal(t, orderID, order.ID)
+			require.Equal(t, userID, order.UserID)
+			require.Equal(t, serviceID, order.ServiceID)
+			require.Equal(t, cost, order.Funds)
+			return nil
+		})
+
+		err = c.Order(context.Background(), userID, serviceID, orderID, "service", cost, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		serviceID := uuid.New()
+		ttl := ReservationTTL{Default: time.Hour, Services: map[uuid.UUID]time.Duration{serviceID: time.Minute}}
+
+		tests := []struct {
+			name      string
+			serviceID uuid.UUID
+			ttl       time.Duration
+			want      time.Duration
+		}{
+			{"default", uuid.New(), 0, time.Hour},
+			{"service", serviceID, 0, time.Minute},
+			{"request", serviceID, 5 * time.Second, 5 * time.Second},
 		}
+		for _, tt := range tests {
+			mRepo := NewIRepositoryMock(t)
+			c, err := NewController(mRepo, storage.NewMemory(), ttl, Withdrawals{}, Fees{}, model.DefaultRates())
+			require.NoError(t, err)
+
+			mRepo.GetServiceMock.Return(&model.Service{ID: tt.serviceID, Name: "service", Active: true}, nil)
+			mRepo.OrderMock.Set(func(ctx context.Context, order model.Order) (err error) {
+				require.NotNil(t, order.ExpiresAt, tt.name)
+				require.Equal(t, tt.want, order.ExpiresAt.Sub(order.DateCreate), tt.name)
+				return nil
+			})
+
+			err = c.Order(context.Background(), uuid.New(), tt.serviceID, uuid.New(), "service", model.NewMoney(100, model.DefaultCurrency), tt.ttl)
+			require.NoError(t, err)
+		}
+	})
 
-		mRepo.BalanceMock.Return(nil, pgx.ErrNoRows)
+	t.Run("no expiry", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
 
-		mRepo.AddUserMock.Set(func(user model.User) (err error) {
-			require.Equal(t, m.ID, user.ID)
-			require.Equal(t, m.Funds, user.Funds)
+		mRepo.GetServiceMock.Return(&model.Service{Name: "service", Active: true}, nil)
+		mRepo.OrderMock.Set(func(ctx context.Context, order model.Order) (err error) {
+			require.Nil(t, order.ExpiresAt)
+			return nil
+		})
 
+		err = c.Order(context.Background(), uuid.New(), uuid.New(), uuid.New(), "service", model.NewMoney(100, model.DefaultCurrency), 0)
+		require.NoError(t, err)
+	})
+}
+
+func TestController_OrderCatalog(t *testing.T) {
+	price := model.NewMoney(500, model.DefaultCurrency)
+	tests := []struct {
+		name    string
+		service *model.Service
+		err     error
+		order   string
+		cost    model.Money
+		want    error
+	}{
+		{"missing", nil, pgx.ErrNoRows, "", price, Err.ErrServiceNotFound},
+		{"inactive", &model.Service{Name: "service"}, nil, "", price, Err.ErrServiceInactive},
+		{"name", &model.Service{Name: "service", Active: true}, nil, "other", price, Err.ErrBadRequest},
+		{"price", &model.Service{Name: "service", Price: &price, Active: true}, nil, "", model.NewMoney(400, model.DefaultCurrency), Err.ErrBadRequest},
+	}
+	for _, tt := range tests {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetServiceMock.Return(tt.service, tt.err)
+
+		err = c.Order(context.Background(), uuid.New(), uuid.New(), uuid.New(), tt.order, tt.cost, 0)
+		require.ErrorIs(t, err, tt.want, tt.name)
+	}
+
+	t.Run("catalog name", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetServiceMock.Return(&model.Service{Name: "Доставка", Price: &price, Active: true}, nil)
+		mRepo.OrderMock.Set(func(ctx context.Context, order model.Order) (err error) {
+			require.Equal(t, "Доставка", order.ServiceName)
 			return nil
 		})
 
-		err := c.Enrollment(m.ID, m.Funds)
+		err = c.Order(context.Background(), uuid.New(), uuid.New(), uuid.New(), "", price, 0)
 		require.NoError(t, err)
 	})
+}
 
-	t.Run("success: enrollment balance", func(t *testing.T) {
-		var funds float64 = 10
+func TestController_Service(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
 
-		m := &model.User{
-			ID:         uuid.New(),
-			Funds:      10,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
+		_, err = c.CreateService(context.Background(), model.Service{Name: " "})
+		require.ErrorIs(t, err, Err.ErrBadRequest)
+
+		price := model.NewMoney(500, "USD")
+		mRepo.CreateServiceMock.Set(func(ctx context.Context, s model.Service) (err error) {
+			require.NotEqual(t, uuid.Nil, s.ID)
+			require.Equal(t, "USD", s.Currency)
+			require.False(t, s.DateCreate.IsZero())
+			return nil
+		})
+		service, err := c.CreateService(context.Background(), model.Service{Name: "service", Price: &price, Active: true})
+		require.NoError(t, err)
+		require.Equal(t, "service", service.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetServiceMock.Return(nil, pgx.ErrNoRows)
+		mRepo.UpdateServiceMock.Return(pgx.ErrNoRows)
+		mRepo.DeleteServiceMock.Return(pgx.ErrNoRows)
+
+		_, err = c.Service(context.Background(), uuid.New())
+		require.ErrorIs(t, err, Err.ErrServiceNotFound)
+		_, err = c.UpdateService(context.Background(), model.Service{ID: uuid.New(), Name: "service"})
+		require.ErrorIs(t, err, Err.ErrServiceNotFound)
+		err = c.DeleteService(context.Background(), uuid.New())
+		require.ErrorIs(t, err, Err.ErrServiceNotFound)
+	})
+
+	t.Run("in use", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.DeleteServiceMock.Return(Err.ErrServiceInUse)
+
+		err = c.DeleteService(context.Background(), uuid.New())
+		var e *Err.Error
+		require.ErrorAs(t, err, &e)
+		require.ErrorIs(t, e, Err.ErrServiceInUse)
+		require.Len(t, e.Details, 1)
+	})
+}
+
+func TestController_ExpireOrders(t *testing.T) {
+	mRepo := NewIRepositoryMock(t)
+	c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+	require.NoError(t, err)
+
+	orders := []model.Order{
+		{ID: uuid.New(), UserID: uuid.New(), Funds: model.NewMoney(100, model.DefaultCurrency), Status: model.OrderReserved},
+		{ID: uuid.New(), UserID: uuid.New(), Funds: model.NewMoney(200, model.DefaultCurrency), Status: model.OrderReserved},
+		{ID: uuid.New(), UserID: uuid.New(), Funds: model.NewMoney(300, model.DefaultCurrency), Status: model.OrderReserved},
+	}
+
+	var released []uuid.UUID
+	mRepo.ReleaseOrderMock.Set(func(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) (err error) {
+		require.Equal(t, model.OrderExpired, status)
+		released = append(released, order.ID)
+		switch order.ID {
+		case orders[0].ID:
+			// Confirmed by the client meanwhile.
+			return pgx.ErrNoRows
+		case orders[1].ID:
+			return errors.New("ledger transaction is not balanced")
 		}
+		return nil
+	})
 
-		mRepo.BalanceMock.Return(m, nil)
+	t.Run("skips resolved orders", func(t *testing.T) {
+		released = nil
+		mRepo.ExpiredOrdersMock.Return([]model.Order{orders[0], orders[2]}, nil)
 
-		mRepo.EnrollmentMock.Set(func(user model.User, funds float64) (err error) {
+		n, err := c.ExpireOrders(context.Background(), 10)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []uuid.UUID{orders[0].ID, orders[2].ID}, released)
+	})
+
+	t.Run("failed order does not block the batch", func(t *testing.T) {
+		released = nil
+		mRepo.ExpiredOrdersMock.Return(orders, nil)
 
-			require.Equal(t, m.ID, user.ID)
-			require.Equal(t, m.Funds+funds, funds)
+		n, err := c.ExpireOrders(context.Background(), 10)
+		require.ErrorIs(t, err, Err.ErrExpireFailed)
+		require.Equal(t, 3, n)
+		require.Equal(t, []uuid.UUID{orders[0].ID, orders[1].ID, orders[2].ID}, released)
+	})
+}
 
+func TestController_OrderSuccess(t *testing.T) {
+	order := &model.Order{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		ServiceID:   uuid.New(),
+		ServiceName: "service",
+		DateCreate:  time.Now(),
+		Funds:       model.NewMoney(10000, model.DefaultCurrency),
+		Status:      model.OrderReserved,
+	}
+
+	t.Run("failed: capture exceeds reservation", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+
+		err = c.OrderSuccess(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, model.NewMoney(10001, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrCaptureExceedsReservation)
+	})
+
+	t.Run("success: partial capture", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		captured := model.NewMoney(7550, model.DefaultCurrency)
+		mRepo.GetOrderMock.Return(order, nil)
+		mRepo.OrderSuccessMock.Set(func(ctx context.Context, o model.Order, c, fee model.Money, date time.Time) (err error) {
+			require.Equal(t, order.ID, o.ID)
+			require.Equal(t, captured, c)
 			return nil
 		})
 
-		err := c.Enrollment(m.ID, funds)
+		err = c.OrderSuccess(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, captured)
+		require.NoError(t, err)
+	})
+
+	t.Run("success: commission", func(t *testing.T) {
+		fees := Fees{
+			Orders:   model.FeeRule{Percent: "10"},
+			Services: map[uuid.UUID]model.FeeRule{order.ServiceID: {Fixed: map[string]model.Money{"RUB": model.NewMoney(5000, "RUB")}}},
+		}
+		tests := []struct {
+			name     string
+			rules    Fees
+			captured model.Money
+			want     model.Money
+		}{
+			{"default rule", Fees{Orders: fees.Orders}, model.NewMoney(7550, model.DefaultCurrency), model.NewMoney(755, model.DefaultCurrency)},
+			{"service rule", fees, model.NewMoney(7550, model.DefaultCurrency), model.NewMoney(5000, model.DefaultCurrency)},
+			{"capped by the capture", fees, model.NewMoney(3000, model.DefaultCurrency), model.NewMoney(3000, model.DefaultCurrency)},
+		}
+		for _, tt := range tests {
+			mRepo := NewIRepositoryMock(t)
+			c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, tt.rules, model.DefaultRates())
+			require.NoError(t, err)
+
+			mRepo.GetOrderMock.Return(order, nil)
+			mRepo.OrderSuccessMock.Set(func(ctx context.Context, o model.Order, c, fee model.Money, date time.Time) (err error) {
+				require.True(t, fee.Equal(tt.want), "%s: %s", tt.name, fee)
+				return nil
+			})
+
+			err = c.OrderSuccess(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, tt.captured)
+			require.NoError(t, err, tt.name)
+		}
+	})
+	t.Run("failed: currency mismatch", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+
+		err = c.OrderSuccess(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, model.NewMoney(100, "USD"))
+		require.ErrorIs(t, err, Err.ErrCurrencyMismatch)
+		require.Zero(t, mRepo.OrderSuccessAfterCounter())
+	})
+}
+
+func TestController_Refund(t *testing.T) {
+	order := &model.Order{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Funds:    model.NewMoney(10000, model.DefaultCurrency),
+		Status:   model.OrderConfirmed,
+		Captured: model.NewMoney(8000, model.DefaultCurrency),
+		Refunded: model.NewMoney(5000, model.DefaultCurrency),
+	}
+
+	t.Run("failed: not confirmed", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		reserved := *order
+		reserved.Status = model.OrderReserved
+		mRepo.GetOrderMock.Return(&reserved, nil)
+
+		err = c.Refund(context.Background(), order.ID, model.NewMoney(100, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrOrderTransition)
+	})
+
+	t.Run("failed: exceeds captured", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+
+		err = c.Refund(context.Background(), order.ID, model.NewMoney(3001, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrRefundExceedsCapture)
+	})
+
+	t.Run("failed: concurrent refund", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+		mRepo.RefundMock.Return(pgx.ErrNoRows)
+
+		err = c.Refund(context.Background(), order.ID, model.NewMoney(3000, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrRefundExceedsCapture)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+		mRepo.RefundMock.Return(nil)
+
+		err = c.Refund(context.Background(), order.ID, model.NewMoney(3000, model.DefaultCurrency))
 		require.NoError(t, err)
 	})
 }
 
-func TestController_Order(t *testing.T) {
-	mRepo := NewIRepositoryMock(t)
+func TestController_OrderFailed(t *testing.T) {
+	order := &model.Order{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		ServiceID:   uuid.New(),
+		ServiceName: "service",
+		DateCreate:  time.Now(),
+		Funds:       model.NewMoney(10000, model.DefaultCurrency),
+		Status:      model.OrderReserved,
+	}
+
+	t.Run("failed: mismatched order", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+
+		err = c.OrderFailed(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, model.NewMoney(1, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrOrderMismatch)
+
+		var e *Err.Error
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, []Err.FieldError{Err.Field("cost", "does not match the order")}, e.Details)
+	})
+
+	t.Run("failed: already resolved", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+		mRepo.ReleaseOrderMock.Return(pgx.ErrNoRows)
+
+		err = c.OrderFailed(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, order.Funds)
+		require.ErrorIs(t, err, Err.ErrOrderTransition)
+	})
+
+	t.Run("failed: already confirmed", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		confirmed := *order
+		confirmed.Status = model.OrderConfirmed
+		mRepo.GetOrderMock.Return(&confirmed, nil)
+
+		err = c.OrderFailed(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, order.Funds)
+		require.ErrorIs(t, err, Err.ErrOrderTransition)
+	})
+
+	t.Run("failed: unknown order", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(nil, pgx.ErrNoRows)
 
-	c, err := NewController(mRepo)
+		err = c.OrderFailed(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, order.Funds)
+		require.ErrorIs(t, err, Err.ErrOrderNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.GetOrderMock.Return(order, nil)
+		mRepo.ReleaseOrderMock.Return(nil)
+
+		err = c.OrderFailed(context.Background(), order.UserID, order.ServiceID, order.ID, order.ServiceName, order.Funds)
+		require.NoError(t, err)
+	})
+}
+
+func TestController_Reconcile(t *testing.T) {
+	first := model.Discrepancy{UserID: uuid.New(), Balance: model.NewMoney(100, model.DefaultCurrency)}
+	second := model.Discrepancy{UserID: uuid.New(), Held: model.NewMoney(50, model.DefaultCurrency)}
+
+	t.Run("report only", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.DiscrepanciesMock.Return([]model.Discrepancy{first, second}, nil)
+
+		res, err := c.Reconcile(context.Background(), false)
+		require.NoError(t, err)
+		require.Equal(t, []model.Discrepancy{first, second}, res)
+		require.Zero(t, mRepo.AdjustAfterCounter())
+	})
+
+	t.Run("fix skips users that came right", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.DiscrepanciesMock.Return([]model.Discrepancy{first, second}, nil)
+		mRepo.AdjustMock.Set(func(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.Discrepancy, error) {
+			if userID == second.UserID {
+				return nil, pgx.ErrNoRows
+			}
+			adjusted := first
+			adjusted.Adjusted = true
+			return []model.Discrepancy{adjusted}, nil
+		})
+
+		res, err := c.Reconcile(context.Background(), true)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, first.UserID, res[0].UserID)
+		require.True(t, res[0].Adjusted)
+	})
+}
+
+func TestController_StartIdempotent(t *testing.T) {
+	t.Run("new key", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.CreateIdempotencyKeyMock.Return(true, nil)
+
+		stored, err := c.StartIdempotent(context.Background(), "key", "hash")
+		require.NoError(t, err)
+		require.Nil(t, stored)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		m := &model.Idempotency{Key: "key", RequestHash: "hash", Status: 200, Body: []byte(`{"message": "Success"}`)}
+		mRepo.CreateIdempotencyKeyMock.Return(false, nil)
+		mRepo.GetIdempotencyKeyMock.Return(m, nil)
+
+		stored, err := c.StartIdempotent(context.Background(), "key", "hash")
+		require.NoError(t, err)
+		require.Equal(t, m, stored)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.CreateIdempotencyKeyMock.Return(false, nil)
+		mRepo.GetIdempotencyKeyMock.Return(&model.Idempotency{Key: "key", RequestHash: "other", Status: 200}, nil)
+
+		_, err = c.StartIdempotent(context.Background(), "key", "hash")
+		require.ErrorIs(t, err, Err.ErrIdempotencyConflict)
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.CreateIdempotencyKeyMock.Return(false, nil)
+		mRepo.GetIdempotencyKeyMock.Return(&model.Idempotency{Key: "key", RequestHash: "hash"}, nil)
+
+		_, err = c.StartIdempotent(context.Background(), "key", "hash")
+		require.ErrorIs(t, err, Err.ErrIdempotencyInProgress)
+	})
+}
+
+func TestController_FinishIdempotent(t *testing.T) {
+	mRepo := NewIRepositoryMock(t)
+	c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
 	require.NoError(t, err)
 
-	t.Run("failed", func(t *testing.T) {
-		m := &model.User{
-			ID:         uuid.New(),
-			Funds:      10,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
-		}
+	mRepo.SaveIdempotencyResponseMock.Return(nil)
+	require.NoError(t, c.FinishIdempotent(context.Background(), "key", 400, []byte(`{}`)))
+
+	mRepo.DeleteIdempotencyKeyMock.Return(nil)
+	require.NoError(t, c.FinishIdempotent(context.Background(), "key", 500, nil))
+	require.NoError(t, c.ReleaseIdempotent(context.Background(), "key"))
+}
 
-		mRepo.BalanceMock.Return(m, nil)
+func TestController_PurgeIdempotencyKeys(t *testing.T) {
+	t.Run("older than ttl", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		before := time.Now().Add(-time.Hour)
+		mRepo.DeleteIdempotencyKeysMock.Set(func(ctx context.Context, date time.Time) (int, error) {
+			require.Equal(t, before, date)
+			return 3, nil
+		})
+
+		n, err := c.PurgeIdempotencyKeys(context.Background(), before)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+	})
 
-		err := c.Order(m.ID, uuid.New(), uuid.New(), uuid.New().String(), 100)
+	t.Run("keeps running requests", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, Withdrawals{}, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.DeleteIdempotencyKeysMock.Set(func(ctx context.Context, date time.Time) (int, error) {
+			require.True(t, date.Before(time.Now().Add(-idempotencyLockTimeout+time.Second)))
+			return 0, nil
+		})
+
+		_, err = c.PurgeIdempotencyKeys(context.Background(), time.Now())
+		require.NoError(t, err)
+	})
+}
+
+func TestController_Withdraw(t *testing.T) {
+	withdrawals := Withdrawals{MinBalance: map[string]model.Money{model.DefaultCurrency: model.NewMoney(1000, model.DefaultCurrency)}}
+
+	t.Run("failed: unknown user", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, withdrawals, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.WithdrawMock.Return(pgx.ErrNoRows)
+
+		err = c.Withdraw(context.Background(), uuid.New(), model.NewMoney(100, model.DefaultCurrency))
+		require.ErrorIs(t, err, Err.ErrUserNotFound)
+	})
+
+	t.Run("failed: below minimum balance", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, withdrawals, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
+
+		mRepo.WithdrawMock.Return(Err.ErrInsufficientFunds)
+
+		err = c.Withdraw(context.Background(), uuid.New(), model.NewMoney(100, model.DefaultCurrency))
 		require.ErrorIs(t, err, Err.ErrInsufficientFunds)
+		var e *Err.Error
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Details, 1)
 	})
 
-	t.Run("success", func(t *testing.T) {
-		m := &model.User{
-			ID:         uuid.New(),
-			Funds:      1000,
-			DateCreate: time.Time{},
-			LastUpdate: time.Time{},
-		}
+	t.Run("failed: unsupported currency", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, withdrawals, Fees{}, model.DefaultRates())
+		require.NoError(t, err)
 
-		mRepo.BalanceMock.Return(m, nil)
+		err = c.Withdraw(context.Background(), uuid.New(), model.NewMoney(100, "USD"))
+		require.ErrorIs(t, err, Err.ErrUnsupportedCurrency)
+		require.Zero(t, mRepo.WithdrawAfterCounter())
+	})
 
-		err := c.Order(m.ID, uuid.New(), uuid.New(), uuid.New().String(), 100)
+	t.Run("success", func(t *testing.T) {
+		mRepo := NewIRepositoryMock(t)
+		c, err := NewController(mRepo, storage.NewMemory(), ReservationTTL{}, withdrawals, Fees{}, model.DefaultRates())
 		require.NoError(t, err)
+
+		userID, funds := uuid.New(), model.NewMoney(100, model.DefaultCurrency)
+		mRepo.WithdrawMock.Set(func(ctx context.Context, id uuid.UUID, withdrawn, minBalance model.Money, date time.Time) (err error) {
+			require.Equal(t, userID, id)
+			require.Equal(t, funds, withdrawn)
+			require.True(t, minBalance.Equal(model.NewMoney(1000, model.DefaultCurrency)))
+			return nil
+		})
+
+		require.NoError(t, c.Withdraw(context.Background(), userID, funds))
 	})
 }
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ReservationTTL is how long funds stay reserved before the order expires.
+// Services overrides Default for single services; a zero TTL means no expiry.
+type ReservationTTL struct {
+	Default  time.Duration
+	Services map[uuid.UUID]time.Duration
+}
+
+func (t ReservationTTL) For(serviceID uuid.UUID) time.Duration {
+	if ttl, ok := t.Services[serviceID]; ok {
+		return ttl
+	}
+	return t.Default
+}
+
+// ExpireOrders releases up to limit reservations whose TTL has passed and
+// returns how many were found. Orders resolved concurrently are skipped. An order that
+// fails to be released is logged and skipped as well, so it does not hold back the rest
+// of the batch; the failures are reported together as ErrExpireFailed.
+func (c *controller) ExpireOrders(ctx context.Context, limit int) (int, error) {
+	logrus.Infoln("Starting controller.ExpireOrders")
+
+	date := time.Now()
+	orders, err := c.repository.ExpiredOrders(ctx, date, limit)
+	if err != nil {
+		logrus.Infoln("Ending controller.ExpireOrders")
+		return 0, err
+	}
+
+	var (
+		failed   int
+		firstErr error
+	)
+	for i := range orders {
+		err := c.release(ctx, &orders[i], model.OrderExpired, date)
+		if errors.Is(err, Err.ErrOrderTransition) {
+			continue
+		}
+		if err != nil {
+			logrus.Errorf("Expire %s: %s\n", orders[i].ID, err)
+			if ctx.Err() != nil {
+				logrus.Infoln("Ending controller.ExpireOrders")
+				return 0, err
+			}
+			if failed == 0 {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+		logrus.Infof("Order %s expired, %s returned to %s\n", orders[i].ID, orders[i].Funds, orders[i].UserID)
+	}
+
+	if failed > 0 {
+		logrus.Infoln("Ending controller.ExpireOrders")
+		return len(orders), fmt.Errorf("%w: %d of %d, first: %s", Err.ErrExpireFailed, failed, len(orders), firstErr)
+	}
+
+	logrus.Infoln("Ending controller.ExpireOrders")
+	return len(orders), nil
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// Fees are the commission rules. Transfers is charged to the sender of a transfer on top
+// of the amount. Orders is withheld from the service revenue when an order is captured;
+// Services overrides it for single services.
+type Fees struct {
+	Transfers model.FeeRule
+	Orders    model.FeeRule
+	Services  map[uuid.UUID]model.FeeRule
+}
+
+// Transfer is the fee for a transfer of funds.
+func (f Fees) Transfer(funds model.Money) (model.Money, error) {
+	return f.Transfers.Fee(funds)
+}
+
+// Order is the commission on the captured amount of an order of the service.
+// It never exceeds the captured amount.
+func (f Fees) Order(serviceID uuid.UUID, captured model.Money) (model.Money, error) {
+	rule, ok := f.Services[serviceID]
+	if !ok {
+		rule = f.Orders
+	}
+	fee, err := rule.Fee(captured)
+	if err != nil {
+		return model.Money{}, err
+	}
+	if captured.LessThan(fee) {
+		fee = captured
+	}
+	return fee, nil
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// idempotencyLockTimeout is how long an unfinished request keeps its key
+// before a retry may take it over.
+const idempotencyLockTimeout = time.Minute
+
+// StartIdempotent claims key for a request with the given hash. It returns nil when
+// the caller should process the request, or the stored response to replay.
+func (c *controller) StartIdempotent(ctx context.Context, key, requestHash string) (*model.Idempotency, error) {
+	logrus.Infoln("Starting controller.StartIdempotent")
+
+	now := time.Now()
+	created, err := c.repository.CreateIdempotencyKey(ctx, model.Idempotency{Key: key, RequestHash: requestHash, DateCreate: now}, now.Add(-idempotencyLockTimeout))
+	if err != nil {
+		logrus.Infoln("Ending controller.StartIdempotent")
+		return nil, err
+	}
+	if created {
+		logrus.Infoln("Ending controller.StartIdempotent")
+		return nil, nil
+	}
+
+	stored, err := c.repository.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		logrus.Infoln("Ending controller.StartIdempotent")
+		return nil, err
+	}
+
+	if stored.RequestHash != requestHash {
+		logrus.Errorf("%s: %s\n", Err.ErrIdempotencyConflict, key)
+		logrus.Infoln("Ending controller.StartIdempotent")
+		return nil, Err.ErrIdempotencyConflict
+	}
+	if stored.Status == 0 {
+		logrus.Errorf("%s: %s\n", Err.ErrIdempotencyInProgress, key)
+		logrus.Infoln("Ending controller.StartIdempotent")
+		return nil, Err.ErrIdempotencyInProgress
+	}
+
+	logrus.Infoln("Ending controller.StartIdempotent")
+	return stored, nil
+}
+
+// FinishIdempotent stores the response for replay. Server errors are not stored:
+// the key is released so that the client can retry.
+func (c *controller) FinishIdempotent(ctx context.Context, key string, status int, body []byte) error {
+	logrus.Infoln("Starting controller.FinishIdempotent")
+
+	var err error
+	if status >= 500 {
+		err = c.repository.DeleteIdempotencyKey(ctx, key)
+	} else {
+		err = c.repository.SaveIdempotencyResponse(ctx, key, status, body)
+	}
+
+	logrus.Infoln("Ending controller.FinishIdempotent")
+	return err
+}
+
+// ReleaseIdempotent frees the key of a request that was cancelled or timed out before it
+// finished, so that a retry with the same key is processed instead of replayed.
+func (c *controller) ReleaseIdempotent(ctx context.Context, key string) error {
+	logrus.Infoln("Starting controller.ReleaseIdempotent")
+
+	err := c.repository.DeleteIdempotencyKey(ctx, key)
+
+	logrus.Infoln("Ending controller.ReleaseIdempotent")
+	return err
+}
+
+// PurgeIdempotencyKeys deletes the keys created before the given time, so that their
+// responses are no longer replayed. Keys of requests that may still be running are kept.
+func (c *controller) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error) {
+	logrus.Infoln("Starting controller.PurgeIdempotencyKeys")
+
+	if locked := time.Now().Add(-idempotencyLockTimeout); before.After(locked) {
+		before = locked
+	}
+	n, err := c.repository.DeleteIdempotencyKeys(ctx, before)
+	if err != nil {
+		logrus.Errorf("DeleteIdempotencyKeys %s: %s\n", before, err)
+		logrus.Infoln("Ending controller.PurgeIdempotencyKeys")
+		return n, err
+	}
+	if n > 0 {
+		logrus.Infof("Deleted %d idempotency keys created before %s\n", n, before)
+	}
+
+	logrus.Infoln("Ending controller.PurgeIdempotencyKeys")
+	return n, nil
+}
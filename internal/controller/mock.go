@@ -6,6 +6,7 @@ package controller
 
 import (
 	"Avito/internal/model"
+	"context"
 	"sync"
 	mm_atomic "sync/atomic"
 	"time"
@@ -19,65 +20,185 @@ import (
 type IRepositoryMock struct {
 	t minimock.Tester
 
-	funcAddUser          func(user model.User) (err error)
-	inspectFuncAddUser   func(user model.User)
-	afterAddUserCounter  uint64
-	beforeAddUserCounter uint64
-	AddUserMock          mIRepositoryMockAddUser
+	funcAdjust          func(ctx context.Context, userID uuid.UUID, date time.Time) (da1 []model.Discrepancy, err error)
+	inspectFuncAdjust   func(ctx context.Context, userID uuid.UUID, date time.Time)
+	afterAdjustCounter  uint64
+	beforeAdjustCounter uint64
+	AdjustMock          mIRepositoryMockAdjust
 
-	funcBalance          func(userID uuid.UUID) (up1 *model.User, err error)
-	inspectFuncBalance   func(userID uuid.UUID)
+	funcBalance          func(ctx context.Context, userID uuid.UUID) (up1 *model.User, err error)
+	inspectFuncBalance   func(ctx context.Context, userID uuid.UUID)
 	afterBalanceCounter  uint64
 	beforeBalanceCounter uint64
 	BalanceMock          mIRepositoryMockBalance
 
-	funcEnrollment          func(user model.User, funds float64) (err error)
-	inspectFuncEnrollment   func(user model.User, funds float64)
+	funcClaimReportJob          func(ctx context.Context, date time.Time, staleBefore time.Time) (rp1 *model.ReportJob, err error)
+	inspectFuncClaimReportJob   func(ctx context.Context, date time.Time, staleBefore time.Time)
+	afterClaimReportJobCounter  uint64
+	beforeClaimReportJobCounter uint64
+	ClaimReportJobMock          mIRepositoryMockClaimReportJob
+
+	funcCreateIdempotencyKey          func(ctx context.Context, key model.Idempotency, staleBefore time.Time) (b1 bool, err error)
+	inspectFuncCreateIdempotencyKey   func(ctx context.Context, key model.Idempotency, staleBefore time.Time)
+	afterCreateIdempotencyKeyCounter  uint64
+	beforeCreateIdempotencyKeyCounter uint64
+	CreateIdempotencyKeyMock          mIRepositoryMockCreateIdempotencyKey
+
+	funcCreateReportJob          func(ctx context.Context, job model.ReportJob) (err error)
+	inspectFuncCreateReportJob   func(ctx context.Context, job model.ReportJob)
+	afterCreateReportJobCounter  uint64
+	beforeCreateReportJobCounter uint64
+	CreateReportJobMock          mIRepositoryMockCreateReportJob
+
+	funcCreateService          func(ctx context.Context, s model.Service) (err error)
+	inspectFuncCreateService   func(ctx context.Context, s model.Service)
+	afterCreateServiceCounter  uint64
+	beforeCreateServiceCounter uint64
+	CreateServiceMock          mIRepositoryMockCreateService
+
+	funcDeleteIdempotencyKey          func(ctx context.Context, key string) (err error)
+	inspectFuncDeleteIdempotencyKey   func(ctx context.Context, key string)
+	afterDeleteIdempotencyKeyCounter  uint64
+	beforeDeleteIdempotencyKeyCounter uint64
+	DeleteIdempotencyKeyMock          mIRepositoryMockDeleteIdempotencyKey
+
+	funcDeleteIdempotencyKeys          func(ctx context.Context, before time.Time) (i1 int, err error)
+	inspectFuncDeleteIdempotencyKeys   func(ctx context.Context, before time.Time)
+	afterDeleteIdempotencyKeysCounter  uint64
+	beforeDeleteIdempotencyKeysCounter uint64
+	DeleteIdempotencyKeysMock          mIRepositoryMockDeleteIdempotencyKeys
+
+	funcDeleteReportJobs          func(ctx context.Context, before time.Time) (i1 int, err error)
+	inspectFuncDeleteReportJobs   func(ctx context.Context, before time.Time)
+	afterDeleteReportJobsCounter  uint64
+	beforeDeleteReportJobsCounter uint64
+	DeleteReportJobsMock          mIRepositoryMockDeleteReportJobs
+
+	funcDeleteService          func(ctx context.Context, id uuid.UUID) (err error)
+	inspectFuncDeleteService   func(ctx context.Context, id uuid.UUID)
+	afterDeleteServiceCounter  uint64
+	beforeDeleteServiceCounter uint64
+	DeleteServiceMock          mIRepositoryMockDeleteService
+
+	funcDiscrepancies          func(ctx context.Context) (da1 []model.Discrepancy, err error)
+	inspectFuncDiscrepancies   func(ctx context.Context)
+	afterDiscrepanciesCounter  uint64
+	beforeDiscrepanciesCounter uint64
+	DiscrepanciesMock          mIRepositoryMockDiscrepancies
+
+	funcEnrollment          func(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) (err error)
+	inspectFuncEnrollment   func(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time)
 	afterEnrollmentCounter  uint64
 	beforeEnrollmentCounter uint64
 	EnrollmentMock          mIRepositoryMockEnrollment
 
-	funcGetOrder          func(orderID uuid.UUID) (op1 *model.Order, err error)
-	inspectFuncGetOrder   func(orderID uuid.UUID)
+	funcExpiredOrders          func(ctx context.Context, date time.Time, limit int) (oa1 []model.Order, err error)
+	inspectFuncExpiredOrders   func(ctx context.Context, date time.Time, limit int)
+	afterExpiredOrdersCounter  uint64
+	beforeExpiredOrdersCounter uint64
+	ExpiredOrdersMock          mIRepositoryMockExpiredOrders
+
+	funcFinishReportJob          func(ctx context.Context, job model.ReportJob, date time.Time) (err error)
+	inspectFuncFinishReportJob   func(ctx context.Context, job model.ReportJob, date time.Time)
+	afterFinishReportJobCounter  uint64
+	beforeFinishReportJobCounter uint64
+	FinishReportJobMock          mIRepositoryMockFinishReportJob
+
+	funcGetIdempotencyKey          func(ctx context.Context, key string) (ip1 *model.Idempotency, err error)
+	inspectFuncGetIdempotencyKey   func(ctx context.Context, key string)
+	afterGetIdempotencyKeyCounter  uint64
+	beforeGetIdempotencyKeyCounter uint64
+	GetIdempotencyKeyMock          mIRepositoryMockGetIdempotencyKey
+
+	funcGetOrder          func(ctx context.Context, orderID uuid.UUID) (op1 *model.Order, err error)
+	inspectFuncGetOrder   func(ctx context.Context, orderID uuid.UUID)
 	afterGetOrderCounter  uint64
 	beforeGetOrderCounter uint64
 	GetOrderMock          mIRepositoryMockGetOrder
 
-	funcHistory          func(userID uuid.UUID, limit int, offset int) (ha1 []model.History, err error)
-	inspectFuncHistory   func(userID uuid.UUID, limit int, offset int)
+	funcGetReportJob          func(ctx context.Context, id uuid.UUID) (rp1 *model.ReportJob, err error)
+	inspectFuncGetReportJob   func(ctx context.Context, id uuid.UUID)
+	afterGetReportJobCounter  uint64
+	beforeGetReportJobCounter uint64
+	GetReportJobMock          mIRepositoryMockGetReportJob
+
+	funcGetService          func(ctx context.Context, id uuid.UUID) (sp1 *model.Service, err error)
+	inspectFuncGetService   func(ctx context.Context, id uuid.UUID)
+	afterGetServiceCounter  uint64
+	beforeGetServiceCounter uint64
+	GetServiceMock          mIRepositoryMockGetService
+
+	funcHistory          func(ctx context.Context, q model.HistoryQuery) (hp1 *model.HistoryPage, err error)
+	inspectFuncHistory   func(ctx context.Context, q model.HistoryQuery)
 	afterHistoryCounter  uint64
 	beforeHistoryCounter uint64
 	HistoryMock          mIRepositoryMockHistory
 
-	funcOrder          func(user model.User, order model.Order) (err error)
-	inspectFuncOrder   func(user model.User, order model.Order)
+	funcOrder          func(ctx context.Context, order model.Order) (err error)
+	inspectFuncOrder   func(ctx context.Context, order model.Order)
 	afterOrderCounter  uint64
 	beforeOrderCounter uint64
 	OrderMock          mIRepositoryMockOrder
 
-	funcOrderFailed          func(user model.User, order model.Order) (err error)
-	inspectFuncOrderFailed   func(user model.User, order model.Order)
-	afterOrderFailedCounter  uint64
-	beforeOrderFailedCounter uint64
-	OrderFailedMock          mIRepositoryMockOrderFailed
-
-	funcOrderSuccess          func(order model.Order) (err error)
-	inspectFuncOrderSuccess   func(order model.Order)
+	funcOrderSuccess          func(ctx context.Context, order model.Order, captured model.Money, fee model.Money, date time.Time) (err error)
+	inspectFuncOrderSuccess   func(ctx context.Context, order model.Order, captured model.Money, fee model.Money, date time.Time)
 	afterOrderSuccessCounter  uint64
 	beforeOrderSuccessCounter uint64
 	OrderSuccessMock          mIRepositoryMockOrderSuccess
 
-	funcReport          func(t1 time.Time) (ra1 []model.Report, err error)
-	inspectFuncReport   func(t1 time.Time)
+	funcPing          func(ctx context.Context) (err error)
+	inspectFuncPing   func(ctx context.Context)
+	afterPingCounter  uint64
+	beforePingCounter uint64
+	PingMock          mIRepositoryMockPing
+
+	funcRefund          func(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) (err error)
+	inspectFuncRefund   func(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time)
+	afterRefundCounter  uint64
+	beforeRefundCounter uint64
+	RefundMock          mIRepositoryMockRefund
+
+	funcReleaseOrder          func(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) (err error)
+	inspectFuncReleaseOrder   func(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time)
+	afterReleaseOrderCounter  uint64
+	beforeReleaseOrderCounter uint64
+	ReleaseOrderMock          mIRepositoryMockReleaseOrder
+
+	funcReport          func(ctx context.Context, q model.ReportQuery) (ra1 []model.Report, err error)
+	inspectFuncReport   func(ctx context.Context, q model.ReportQuery)
 	afterReportCounter  uint64
 	beforeReportCounter uint64
 	ReportMock          mIRepositoryMockReport
 
-	funcTransfer          func(sender model.User, recipient model.User, funds float64) (err error)
-	inspectFuncTransfer   func(sender model.User, recipient model.User, funds float64)
+	funcSaveIdempotencyResponse          func(ctx context.Context, key string, status int, body []byte) (err error)
+	inspectFuncSaveIdempotencyResponse   func(ctx context.Context, key string, status int, body []byte)
+	afterSaveIdempotencyResponseCounter  uint64
+	beforeSaveIdempotencyResponseCounter uint64
+	SaveIdempotencyResponseMock          mIRepositoryMockSaveIdempotencyResponse
+
+	funcServices          func(ctx context.Context) (sa1 []model.Service, err error)
+	inspectFuncServices   func(ctx context.Context)
+	afterServicesCounter  uint64
+	beforeServicesCounter uint64
+	ServicesMock          mIRepositoryMockServices
+
+	funcTransfer          func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID, funds model.Money, fee model.Money, date time.Time) (err error)
+	inspectFuncTransfer   func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID, funds model.Money, fee model.Money, date time.Time)
 	afterTransferCounter  uint64
 	beforeTransferCounter uint64
 	TransferMock          mIRepositoryMockTransfer
+
+	funcUpdateService          func(ctx context.Context, s model.Service) (err error)
+	inspectFuncUpdateService   func(ctx context.Context, s model.Service)
+	afterUpdateServiceCounter  uint64
+	beforeUpdateServiceCounter uint64
+	UpdateServiceMock          mIRepositoryMockUpdateService
+
+	funcWithdraw          func(ctx context.Context, userID uuid.UUID, funds model.Money, minBalance model.Money, date time.Time) (err error)
+	inspectFuncWithdraw   func(ctx context.Context, userID uuid.UUID, funds model.Money, minBalance model.Money, date time.Time)
+	afterWithdrawCounter  uint64
+	beforeWithdrawCounter uint64
+	WithdrawMock          mIRepositoryMockWithdraw
 }
 
 // NewIRepositoryMock returns a mock for IRepository
@@ -87,251 +208,314 @@ func NewIRepositoryMock(t minimock.Tester) *IRepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
-	m.AddUserMock = mIRepositoryMockAddUser{mock: m}
-	m.AddUserMock.callArgs = []*IRepositoryMockAddUserParams{}
+	m.AdjustMock = mIRepositoryMockAdjust{mock: m}
+	m.AdjustMock.callArgs = []*IRepositoryMockAdjustParams{}
 
 	m.BalanceMock = mIRepositoryMockBalance{mock: m}
 	m.BalanceMock.callArgs = []*IRepositoryMockBalanceParams{}
 
+	m.ClaimReportJobMock = mIRepositoryMockClaimReportJob{mock: m}
+	m.ClaimReportJobMock.callArgs = []*IRepositoryMockClaimReportJobParams{}
+
+	m.CreateIdempotencyKeyMock = mIRepositoryMockCreateIdempotencyKey{mock: m}
+	m.CreateIdempotencyKeyMock.callArgs = []*IRepositoryMockCreateIdempotencyKeyParams{}
+
+	m.CreateReportJobMock = mIRepositoryMockCreateReportJob{mock: m}
+	m.CreateReportJobMock.callArgs = []*IRepositoryMockCreateReportJobParams{}
+
+	m.CreateServiceMock = mIRepositoryMockCreateService{mock: m}
+	m.CreateServiceMock.callArgs = []*IRepositoryMockCreateServiceParams{}
+
+	m.DeleteIdempotencyKeyMock = mIRepositoryMockDeleteIdempotencyKey{mock: m}
+	m.DeleteIdempotencyKeyMock.callArgs = []*IRepositoryMockDeleteIdempotencyKeyParams{}
+
+	m.DeleteIdempotencyKeysMock = mIRepositoryMockDeleteIdempotencyKeys{mock: m}
+	m.DeleteIdempotencyKeysMock.callArgs = []*IRepositoryMockDeleteIdempotencyKeysParams{}
+
+	m.DeleteReportJobsMock = mIRepositoryMockDeleteReportJobs{mock: m}
+	m.DeleteReportJobsMock.callArgs = []*IRepositoryMockDeleteReportJobsParams{}
+
+	m.DeleteServiceMock = mIRepositoryMockDeleteService{mock: m}
+	m.DeleteServiceMock.callArgs = []*IRepositoryMockDeleteServiceParams{}
+
+	m.DiscrepanciesMock = mIRepositoryMockDiscrepancies{mock: m}
+	m.DiscrepanciesMock.callArgs = []*IRepositoryMockDiscrepanciesParams{}
+
 	m.EnrollmentMock = mIRepositoryMockEnrollment{mock: m}
 	m.EnrollmentMock.callArgs = []*IRepositoryMockEnrollmentParams{}
 
+	m.ExpiredOrdersMock = mIRepositoryMockExpiredOrders{mock: m}
+	m.ExpiredOrdersMock.callArgs = []*IRepositoryMockExpiredOrdersParams{}
+
+	m.FinishReportJobMock = mIRepositoryMockFinishReportJob{mock: m}
+	m.FinishReportJobMock.callArgs = []*IRepositoryMockFinishReportJobParams{}
+
+	m.GetIdempotencyKeyMock = mIRepositoryMockGetIdempotencyKey{mock: m}
+	m.GetIdempotencyKeyMock.callArgs = []*IRepositoryMockGetIdempotencyKeyParams{}
+
 	m.GetOrderMock = mIRepositoryMockGetOrder{mock: m}
 	m.GetOrderMock.callArgs = []*IRepositoryMockGetOrderParams{}
 
+	m.GetReportJobMock = mIRepositoryMockGetReportJob{mock: m}
+	m.GetReportJobMock.callArgs = []*IRepositoryMockGetReportJobParams{}
+
+	m.GetServiceMock = mIRepositoryMockGetService{mock: m}
+	m.GetServiceMock.callArgs = []*IRepositoryMockGetServiceParams{}
+
 	m.HistoryMock = mIRepositoryMockHistory{mock: m}
 	m.HistoryMock.callArgs = []*IRepositoryMockHistoryParams{}
 
 	m.OrderMock = mIRepositoryMockOrder{mock: m}
 	m.OrderMock.callArgs = []*IRepositoryMockOrderParams{}
 
-	m.OrderFailedMock = mIRepositoryMockOrderFailed{mock: m}
-	m.OrderFailedMock.callArgs = []*IRepositoryMockOrderFailedParams{}
-
 	m.OrderSuccessMock = mIRepositoryMockOrderSuccess{mock: m}
 	m.OrderSuccessMock.callArgs = []*IRepositoryMockOrderSuccessParams{}
 
+	m.PingMock = mIRepositoryMockPing{mock: m}
+	m.PingMock.callArgs = []*IRepositoryMockPingParams{}
+
+	m.RefundMock = mIRepositoryMockRefund{mock: m}
+	m.RefundMock.callArgs = []*IRepositoryMockRefundParams{}
+
+	m.ReleaseOrderMock = mIRepositoryMockReleaseOrder{mock: m}
+	m.ReleaseOrderMock.callArgs = []*IRepositoryMockReleaseOrderParams{}
+
 	m.ReportMock = mIRepositoryMockReport{mock: m}
 	m.ReportMock.callArgs = []*IRepositoryMockReportParams{}
 
+	m.SaveIdempotencyResponseMock = mIRepositoryMockSaveIdempotencyResponse{mock: m}
+	m.SaveIdempotencyResponseMock.callArgs = []*IRepositoryMockSaveIdempotencyResponseParams{}
+
+	m.ServicesMock = mIRepositoryMockServices{mock: m}
+	m.ServicesMock.callArgs = []*IRepositoryMockServicesParams{}
+
 	m.TransferMock = mIRepositoryMockTransfer{mock: m}
 	m.TransferMock.callArgs = []*IRepositoryMockTransferParams{}
 
+	m.UpdateServiceMock = mIRepositoryMockUpdateService{mock: m}
+	m.UpdateServiceMock.callArgs = []*IRepositoryMockUpdateServiceParams{}
+
+	m.WithdrawMock = mIRepositoryMockWithdraw{mock: m}
+	m.WithdrawMock.callArgs = []*IRepositoryMockWithdrawParams{}
+
 	return m
 }
 
-type mIRepositoryMockAddUser struct {
+type mIRepositoryMockAdjust struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockAddUserExpectation
-	expectations       []*IRepositoryMockAddUserExpectation
+	defaultExpectation *IRepositoryMockAdjustExpectation
+	expectations       []*IRepositoryMockAdjustExpectation
 
-	callArgs []*IRepositoryMockAddUserParams
+	callArgs []*IRepositoryMockAdjustParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockAddUserExpectation specifies expectation struct of the IRepository.AddUser
-type IRepositoryMockAddUserExpectation struct {
+// IRepositoryMockAdjustExpectation specifies expectation struct of the IRepository.Adjust
+type IRepositoryMockAdjustExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockAddUserParams
-	results *IRepositoryMockAddUserResults
+	params  *IRepositoryMockAdjustParams
+	results *IRepositoryMockAdjustResults
 	Counter uint64
 }
 
-// IRepositoryMockAddUserParams contains parameters of the IRepository.AddUser
-type IRepositoryMockAddUserParams struct {
-	user model.User
+// IRepositoryMockAdjustParams contains parameters of the IRepository.Adjust
+type IRepositoryMockAdjustParams struct {
+	ctx    context.Context
+	userID uuid.UUID
+	date   time.Time
 }
 
-// IRepositoryMockAddUserResults contains results of the IRepository.AddUser
-type IRepositoryMockAddUserResults struct {
+// IRepositoryMockAdjustResults contains results of the IRepository.Adjust
+type IRepositoryMockAdjustResults struct {
+	da1 []model.Discrepancy
 	err error
 }
 
-// Expect sets up expected params for IRepository.AddUser
-func (mmAddUser *mIRepositoryMockAddUser) Expect(user model.User) *mIRepositoryMockAddUser {
-	if mmAddUser.mock.funcAddUser != nil {
-		mmAddUser.mock.t.Fatalf("IRepositoryMock.AddUser mock is already set by Set")
+// Expect sets up expected params for IRepository.Adjust
+func (mmAdjust *mIRepositoryMockAdjust) Expect(ctx context.Context, userID uuid.UUID, date time.Time) *mIRepositoryMockAdjust {
+	if mmAdjust.mock.funcAdjust != nil {
+		mmAdjust.mock.t.Fatalf("IRepositoryMock.Adjust mock is already set by Set")
 	}
 
-	if mmAddUser.defaultExpectation == nil {
-		mmAddUser.defaultExpectation = &IRepositoryMockAddUserExpectation{}
+	if mmAdjust.defaultExpectation == nil {
+		mmAdjust.defaultExpectation = &IRepositoryMockAdjustExpectation{}
 	}
 
-	mmAddUser.defaultExpectation.params = &IRepositoryMockAddUserParams{user}
-	for _, e := range mmAddUser.expectations {
-		if minimock.Equal(e.params, mmAddUser.defaultExpectation.params) {
-			mmAddUser.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAddUser.defaultExpectation.params)
+	mmAdjust.defaultExpectation.params = &IRepositoryMockAdjustParams{ctx, userID, date}
+	for _, e := range mmAdjust.expectations {
+		if minimock.Equal(e.params, mmAdjust.defaultExpectation.params) {
+			mmAdjust.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAdjust.defaultExpectation.params)
 		}
 	}
 
-	return mmAddUser
+	return mmAdjust
 }
 
-// Inspect accepts an inspector function that has same arguments as the IRepository.AddUser
-func (mmAddUser *mIRepositoryMockAddUser) Inspect(f func(user model.User)) *mIRepositoryMockAddUser {
-	if mmAddUser.mock.inspectFuncAddUser != nil {
-		mmAddUser.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.AddUser")
+// Inspect accepts an inspector function that has same arguments as the IRepository.Adjust
+func (mmAdjust *mIRepositoryMockAdjust) Inspect(f func(ctx context.Context, userID uuid.UUID, date time.Time)) *mIRepositoryMockAdjust {
+	if mmAdjust.mock.inspectFuncAdjust != nil {
+		mmAdjust.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Adjust")
 	}
 
-	mmAddUser.mock.inspectFuncAddUser = f
+	mmAdjust.mock.inspectFuncAdjust = f
 
-	return mmAddUser
+	return mmAdjust
 }
 
-// Return sets up results that will be returned by IRepository.AddUser
-func (mmAddUser *mIRepositoryMockAddUser) Return(err error) *IRepositoryMock {
-	if mmAddUser.mock.funcAddUser != nil {
-		mmAddUser.mock.t.Fatalf("IRepositoryMock.AddUser mock is already set by Set")
+// Return sets up results that will be returned by IRepository.Adjust
+func (mmAdjust *mIRepositoryMockAdjust) Return(da1 []model.Discrepancy, err error) *IRepositoryMock {
+	if mmAdjust.mock.funcAdjust != nil {
+		mmAdjust.mock.t.Fatalf("IRepositoryMock.Adjust mock is already set by Set")
 	}
 
-	if mmAddUser.defaultExpectation == nil {
-		mmAddUser.defaultExpectation = &IRepositoryMockAddUserExpectation{mock: mmAddUser.mock}
+	if mmAdjust.defaultExpectation == nil {
+		mmAdjust.defaultExpectation = &IRepositoryMockAdjustExpectation{mock: mmAdjust.mock}
 	}
-	mmAddUser.defaultExpectation.results = &IRepositoryMockAddUserResults{err}
-	return mmAddUser.mock
+	mmAdjust.defaultExpectation.results = &IRepositoryMockAdjustResults{da1, err}
+	return mmAdjust.mock
 }
 
-//Set uses given function f to mock the IRepository.AddUser method
-func (mmAddUser *mIRepositoryMockAddUser) Set(f func(user model.User) (err error)) *IRepositoryMock {
-	if mmAddUser.defaultExpectation != nil {
-		mmAddUser.mock.t.Fatalf("Default expectation is already set for the IRepository.AddUser method")
+// Set uses given function f to mock the IRepository.Adjust method
+func (mmAdjust *mIRepositoryMockAdjust) Set(f func(ctx context.Context, userID uuid.UUID, date time.Time) (da1 []model.Discrepancy, err error)) *IRepositoryMock {
+	if mmAdjust.defaultExpectation != nil {
+		mmAdjust.mock.t.Fatalf("Default expectation is already set for the IRepository.Adjust method")
 	}
 
-	if len(mmAddUser.expectations) > 0 {
-		mmAddUser.mock.t.Fatalf("Some expectations are already set for the IRepository.AddUser method")
+	if len(mmAdjust.expectations) > 0 {
+		mmAdjust.mock.t.Fatalf("Some expectations are already set for the IRepository.Adjust method")
 	}
 
-	mmAddUser.mock.funcAddUser = f
-	return mmAddUser.mock
+	mmAdjust.mock.funcAdjust = f
+	return mmAdjust.mock
 }
 
-// When sets expectation for the IRepository.AddUser which will trigger the result defined by the following
+// When sets expectation for the IRepository.Adjust which will trigger the result defined by the following
 // Then helper
-func (mmAddUser *mIRepositoryMockAddUser) When(user model.User) *IRepositoryMockAddUserExpectation {
-	if mmAddUser.mock.funcAddUser != nil {
-		mmAddUser.mock.t.Fatalf("IRepositoryMock.AddUser mock is already set by Set")
+func (mmAdjust *mIRepositoryMockAdjust) When(ctx context.Context, userID uuid.UUID, date time.Time) *IRepositoryMockAdjustExpectation {
+	if mmAdjust.mock.funcAdjust != nil {
+		mmAdjust.mock.t.Fatalf("IRepositoryMock.Adjust mock is already set by Set")
 	}
 
-	expectation := &IRepositoryMockAddUserExpectation{
-		mock:   mmAddUser.mock,
-		params: &IRepositoryMockAddUserParams{user},
+	expectation := &IRepositoryMockAdjustExpectation{
+		mock:   mmAdjust.mock,
+		params: &IRepositoryMockAdjustParams{ctx, userID, date},
 	}
-	mmAddUser.expectations = append(mmAddUser.expectations, expectation)
+	mmAdjust.expectations = append(mmAdjust.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IRepository.AddUser return parameters for the expectation previously defined by the When method
-func (e *IRepositoryMockAddUserExpectation) Then(err error) *IRepositoryMock {
-	e.results = &IRepositoryMockAddUserResults{err}
+// Then sets up IRepository.Adjust return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockAdjustExpectation) Then(da1 []model.Discrepancy, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockAdjustResults{da1, err}
 	return e.mock
 }
 
-// AddUser implements IRepository
-func (mmAddUser *IRepositoryMock) AddUser(user model.User) (err error) {
-	mm_atomic.AddUint64(&mmAddUser.beforeAddUserCounter, 1)
-	defer mm_atomic.AddUint64(&mmAddUser.afterAddUserCounter, 1)
+// Adjust implements IRepository
+func (mmAdjust *IRepositoryMock) Adjust(ctx context.Context, userID uuid.UUID, date time.Time) (da1 []model.Discrepancy, err error) {
+	mm_atomic.AddUint64(&mmAdjust.beforeAdjustCounter, 1)
+	defer mm_atomic.AddUint64(&mmAdjust.afterAdjustCounter, 1)
 
-	if mmAddUser.inspectFuncAddUser != nil {
-		mmAddUser.inspectFuncAddUser(user)
+	if mmAdjust.inspectFuncAdjust != nil {
+		mmAdjust.inspectFuncAdjust(ctx, userID, date)
 	}
 
-	mm_params := &IRepositoryMockAddUserParams{user}
+	mm_params := &IRepositoryMockAdjustParams{ctx, userID, date}
 
 	// Record call args
-	mmAddUser.AddUserMock.mutex.Lock()
-	mmAddUser.AddUserMock.callArgs = append(mmAddUser.AddUserMock.callArgs, mm_params)
-	mmAddUser.AddUserMock.mutex.Unlock()
+	mmAdjust.AdjustMock.mutex.Lock()
+	mmAdjust.AdjustMock.callArgs = append(mmAdjust.AdjustMock.callArgs, mm_params)
+	mmAdjust.AdjustMock.mutex.Unlock()
 
-	for _, e := range mmAddUser.AddUserMock.expectations {
+	for _, e := range mmAdjust.AdjustMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.da1, e.results.err
 		}
 	}
 
-	if mmAddUser.AddUserMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmAddUser.AddUserMock.defaultExpectation.Counter, 1)
-		mm_want := mmAddUser.AddUserMock.defaultExpectation.params
-		mm_got := IRepositoryMockAddUserParams{user}
+	if mmAdjust.AdjustMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAdjust.AdjustMock.defaultExpectation.Counter, 1)
+		mm_want := mmAdjust.AdjustMock.defaultExpectation.params
+		mm_got := IRepositoryMockAdjustParams{ctx, userID, date}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmAddUser.t.Errorf("IRepositoryMock.AddUser got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmAdjust.t.Errorf("IRepositoryMock.Adjust got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmAddUser.AddUserMock.defaultExpectation.results
+		mm_results := mmAdjust.AdjustMock.defaultExpectation.results
 		if mm_results == nil {
-			mmAddUser.t.Fatal("No results are set for the IRepositoryMock.AddUser")
+			mmAdjust.t.Fatal("No results are set for the IRepositoryMock.Adjust")
 		}
-		return (*mm_results).err
+		return (*mm_results).da1, (*mm_results).err
 	}
-	if mmAddUser.funcAddUser != nil {
-		return mmAddUser.funcAddUser(user)
+	if mmAdjust.funcAdjust != nil {
+		return mmAdjust.funcAdjust(ctx, userID, date)
 	}
-	mmAddUser.t.Fatalf("Unexpected call to IRepositoryMock.AddUser. %v", user)
+	mmAdjust.t.Fatalf("Unexpected call to IRepositoryMock.Adjust. %v %v %v", ctx, userID, date)
 	return
 }
 
-// AddUserAfterCounter returns a count of finished IRepositoryMock.AddUser invocations
-func (mmAddUser *IRepositoryMock) AddUserAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmAddUser.afterAddUserCounter)
+// AdjustAfterCounter returns a count of finished IRepositoryMock.Adjust invocations
+func (mmAdjust *IRepositoryMock) AdjustAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAdjust.afterAdjustCounter)
 }
 
-// AddUserBeforeCounter returns a count of IRepositoryMock.AddUser invocations
-func (mmAddUser *IRepositoryMock) AddUserBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmAddUser.beforeAddUserCounter)
+// AdjustBeforeCounter returns a count of IRepositoryMock.Adjust invocations
+func (mmAdjust *IRepositoryMock) AdjustBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAdjust.beforeAdjustCounter)
 }
 
-// Calls returns a list of arguments used in each call to IRepositoryMock.AddUser.
+// Calls returns a list of arguments used in each call to IRepositoryMock.Adjust.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmAddUser *mIRepositoryMockAddUser) Calls() []*IRepositoryMockAddUserParams {
-	mmAddUser.mutex.RLock()
+func (mmAdjust *mIRepositoryMockAdjust) Calls() []*IRepositoryMockAdjustParams {
+	mmAdjust.mutex.RLock()
 
-	argCopy := make([]*IRepositoryMockAddUserParams, len(mmAddUser.callArgs))
-	copy(argCopy, mmAddUser.callArgs)
+	argCopy := make([]*IRepositoryMockAdjustParams, len(mmAdjust.callArgs))
+	copy(argCopy, mmAdjust.callArgs)
 
-	mmAddUser.mutex.RUnlock()
+	mmAdjust.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockAddUserDone returns true if the count of the AddUser invocations corresponds
+// MinimockAdjustDone returns true if the count of the Adjust invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockAddUserDone() bool {
-	for _, e := range m.AddUserMock.expectations {
+func (m *IRepositoryMock) MinimockAdjustDone() bool {
+	for _, e := range m.AdjustMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.AddUserMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddUserCounter) < 1 {
+	if m.AdjustMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAdjustCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcAddUser != nil && mm_atomic.LoadUint64(&m.afterAddUserCounter) < 1 {
+	if m.funcAdjust != nil && mm_atomic.LoadUint64(&m.afterAdjustCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockAddUserInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockAddUserInspect() {
-	for _, e := range m.AddUserMock.expectations {
+// MinimockAdjustInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockAdjustInspect() {
+	for _, e := range m.AdjustMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.AddUser with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.Adjust with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.AddUserMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAddUserCounter) < 1 {
-		if m.AddUserMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.AddUser")
+	if m.AdjustMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterAdjustCounter) < 1 {
+		if m.AdjustMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Adjust")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.AddUser with params: %#v", *m.AddUserMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.Adjust with params: %#v", *m.AdjustMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcAddUser != nil && mm_atomic.LoadUint64(&m.afterAddUserCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.AddUser")
+	if m.funcAdjust != nil && mm_atomic.LoadUint64(&m.afterAdjustCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Adjust")
 	}
 }
 
@@ -354,6 +538,7 @@ type IRepositoryMockBalanceExpectation struct {
 
 // IRepositoryMockBalanceParams contains parameters of the IRepository.Balance
 type IRepositoryMockBalanceParams struct {
+	ctx    context.Context
 	userID uuid.UUID
 }
 
@@ -364,7 +549,7 @@ type IRepositoryMockBalanceResults struct {
 }
 
 // Expect sets up expected params for IRepository.Balance
-func (mmBalance *mIRepositoryMockBalance) Expect(userID uuid.UUID) *mIRepositoryMockBalance {
+func (mmBalance *mIRepositoryMockBalance) Expect(ctx context.Context, userID uuid.UUID) *mIRepositoryMockBalance {
 	if mmBalance.mock.funcBalance != nil {
 		mmBalance.mock.t.Fatalf("IRepositoryMock.Balance mock is already set by Set")
 	}
@@ -373,7 +558,7 @@ func (mmBalance *mIRepositoryMockBalance) Expect(userID uuid.UUID) *mIRepository
 		mmBalance.defaultExpectation = &IRepositoryMockBalanceExpectation{}
 	}
 
-	mmBalance.defaultExpectation.params = &IRepositoryMockBalanceParams{userID}
+	mmBalance.defaultExpectation.params = &IRepositoryMockBalanceParams{ctx, userID}
 	for _, e := range mmBalance.expectations {
 		if minimock.Equal(e.params, mmBalance.defaultExpectation.params) {
 			mmBalance.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmBalance.defaultExpectation.params)
@@ -384,7 +569,7 @@ func (mmBalance *mIRepositoryMockBalance) Expect(userID uuid.UUID) *mIRepository
 }
 
 // Inspect accepts an inspector function that has same arguments as the IRepository.Balance
-func (mmBalance *mIRepositoryMockBalance) Inspect(f func(userID uuid.UUID)) *mIRepositoryMockBalance {
+func (mmBalance *mIRepositoryMockBalance) Inspect(f func(ctx context.Context, userID uuid.UUID)) *mIRepositoryMockBalance {
 	if mmBalance.mock.inspectFuncBalance != nil {
 		mmBalance.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Balance")
 	}
@@ -407,8 +592,8 @@ func (mmBalance *mIRepositoryMockBalance) Return(up1 *model.User, err error) *IR
 	return mmBalance.mock
 }
 
-//Set uses given function f to mock the IRepository.Balance method
-func (mmBalance *mIRepositoryMockBalance) Set(f func(userID uuid.UUID) (up1 *model.User, err error)) *IRepositoryMock {
+// Set uses given function f to mock the IRepository.Balance method
+func (mmBalance *mIRepositoryMockBalance) Set(f func(ctx context.Context, userID uuid.UUID) (up1 *model.User, err error)) *IRepositoryMock {
 	if mmBalance.defaultExpectation != nil {
 		mmBalance.mock.t.Fatalf("Default expectation is already set for the IRepository.Balance method")
 	}
@@ -423,14 +608,14 @@ func (mmBalance *mIRepositoryMockBalance) Set(f func(userID uuid.UUID) (up1 *mod
 
 // When sets expectation for the IRepository.Balance which will trigger the result defined by the following
 // Then helper
-func (mmBalance *mIRepositoryMockBalance) When(userID uuid.UUID) *IRepositoryMockBalanceExpectation {
+func (mmBalance *mIRepositoryMockBalance) When(ctx context.Context, userID uuid.UUID) *IRepositoryMockBalanceExpectation {
 	if mmBalance.mock.funcBalance != nil {
 		mmBalance.mock.t.Fatalf("IRepositoryMock.Balance mock is already set by Set")
 	}
 
 	expectation := &IRepositoryMockBalanceExpectation{
 		mock:   mmBalance.mock,
-		params: &IRepositoryMockBalanceParams{userID},
+		params: &IRepositoryMockBalanceParams{ctx, userID},
 	}
 	mmBalance.expectations = append(mmBalance.expectations, expectation)
 	return expectation
@@ -443,15 +628,15 @@ func (e *IRepositoryMockBalanceExpectation) Then(up1 *model.User, err error) *IR
 }
 
 // Balance implements IRepository
-func (mmBalance *IRepositoryMock) Balance(userID uuid.UUID) (up1 *model.User, err error) {
+func (mmBalance *IRepositoryMock) Balance(ctx context.Context, userID uuid.UUID) (up1 *model.User, err error) {
 	mm_atomic.AddUint64(&mmBalance.beforeBalanceCounter, 1)
 	defer mm_atomic.AddUint64(&mmBalance.afterBalanceCounter, 1)
 
 	if mmBalance.inspectFuncBalance != nil {
-		mmBalance.inspectFuncBalance(userID)
+		mmBalance.inspectFuncBalance(ctx, userID)
 	}
 
-	mm_params := &IRepositoryMockBalanceParams{userID}
+	mm_params := &IRepositoryMockBalanceParams{ctx, userID}
 
 	// Record call args
 	mmBalance.BalanceMock.mutex.Lock()
@@ -468,7 +653,7 @@ func (mmBalance *IRepositoryMock) Balance(userID uuid.UUID) (up1 *model.User, er
 	if mmBalance.BalanceMock.defaultExpectation != nil {
 		mm_atomic.AddUint64(&mmBalance.BalanceMock.defaultExpectation.Counter, 1)
 		mm_want := mmBalance.BalanceMock.defaultExpectation.params
-		mm_got := IRepositoryMockBalanceParams{userID}
+		mm_got := IRepositoryMockBalanceParams{ctx, userID}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmBalance.t.Errorf("IRepositoryMock.Balance got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -480,9 +665,9 @@ func (mmBalance *IRepositoryMock) Balance(userID uuid.UUID) (up1 *model.User, er
 		return (*mm_results).up1, (*mm_results).err
 	}
 	if mmBalance.funcBalance != nil {
-		return mmBalance.funcBalance(userID)
+		return mmBalance.funcBalance(ctx, userID)
 	}
-	mmBalance.t.Fatalf("Unexpected call to IRepositoryMock.Balance. %v", userID)
+	mmBalance.t.Fatalf("Unexpected call to IRepositoryMock.Balance. %v %v", ctx, userID)
 	return
 }
 
@@ -551,1334 +736,4812 @@ func (m *IRepositoryMock) MinimockBalanceInspect() {
 	}
 }
 
-type mIRepositoryMockEnrollment struct {
+type mIRepositoryMockClaimReportJob struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockEnrollmentExpectation
-	expectations       []*IRepositoryMockEnrollmentExpectation
+	defaultExpectation *IRepositoryMockClaimReportJobExpectation
+	expectations       []*IRepositoryMockClaimReportJobExpectation
 
-	callArgs []*IRepositoryMockEnrollmentParams
+	callArgs []*IRepositoryMockClaimReportJobParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockEnrollmentExpectation specifies expectation struct of the IRepository.Enrollment
-type IRepositoryMockEnrollmentExpectation struct {
+// IRepositoryMockClaimReportJobExpectation specifies expectation struct of the IRepository.ClaimReportJob
+type IRepositoryMockClaimReportJobExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockEnrollmentParams
-	results *IRepositoryMockEnrollmentResults
+	params  *IRepositoryMockClaimReportJobParams
+	results *IRepositoryMockClaimReportJobResults
 	Counter uint64
 }
 
-// IRepositoryMockEnrollmentParams contains parameters of the IRepository.Enrollment
-type IRepositoryMockEnrollmentParams struct {
-	user  model.User
-	funds float64
+// IRepositoryMockClaimReportJobParams contains parameters of the IRepository.ClaimReportJob
+type IRepositoryMockClaimReportJobParams struct {
+	ctx         context.Context
+	date        time.Time
+	staleBefore time.Time
 }
 
-// IRepositoryMockEnrollmentResults contains results of the IRepository.Enrollment
-type IRepositoryMockEnrollmentResults struct {
+// IRepositoryMockClaimReportJobResults contains results of the IRepository.ClaimReportJob
+type IRepositoryMockClaimReportJobResults struct {
+	rp1 *model.ReportJob
 	err error
 }
 
-// Expect sets up expected params for IRepository.Enrollment
-func (mmEnrollment *mIRepositoryMockEnrollment) Expect(user model.User, funds float64) *mIRepositoryMockEnrollment {
-	if mmEnrollment.mock.funcEnrollment != nil {
-		mmEnrollment.mock.t.Fatalf("IRepositoryMock.Enrollment mock is already set by Set")
+// Expect sets up expected params for IRepository.ClaimReportJob
+func (mmClaimReportJob *mIRepositoryMockClaimReportJob) Expect(ctx context.Context, date time.Time, staleBefore time.Time) *mIRepositoryMockClaimReportJob {
+	if mmClaimReportJob.mock.funcClaimReportJob != nil {
+		mmClaimReportJob.mock.t.Fatalf("IRepositoryMock.ClaimReportJob mock is already set by Set")
 	}
 
-	if mmEnrollment.defaultExpectation == nil {
-		mmEnrollment.defaultExpectation = &IRepositoryMockEnrollmentExpectation{}
+	if mmClaimReportJob.defaultExpectation == nil {
+		mmClaimReportJob.defaultExpectation = &IRepositoryMockClaimReportJobExpectation{}
 	}
 
-	mmEnrollment.defaultExpectation.params = &IRepositoryMockEnrollmentParams{user, funds}
-	for _, e := range mmEnrollment.expectations {
-		if minimock.Equal(e.params, mmEnrollment.defaultExpectation.params) {
-			mmEnrollment.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmEnrollment.defaultExpectation.params)
+	mmClaimReportJob.defaultExpectation.params = &IRepositoryMockClaimReportJobParams{ctx, date, staleBefore}
+	for _, e := range mmClaimReportJob.expectations {
+		if minimock.Equal(e.params, mmClaimReportJob.defaultExpectation.params) {
+			mmClaimReportJob.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmClaimReportJob.defaultExpectation.params)
 		}
 	}
 
-	return mmEnrollment
+	return mmClaimReportJob
 }
 
-// Inspect accepts an inspector function that has same arguments as the IRepository.Enrollment
-func (mmEnrollment *mIRepositoryMockEnrollment) Inspect(f func(user model.User, funds float64)) *mIRepositoryMockEnrollment {
-	if mmEnrollment.mock.inspectFuncEnrollment != nil {
-		mmEnrollment.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Enrollment")
+// Inspect accepts an inspector function that has same arguments as the IRepository.ClaimReportJob
+func (mmClaimReportJob *mIRepositoryMockClaimReportJob) Inspect(f func(ctx context.Context, date time.Time, staleBefore time.Time)) *mIRepositoryMockClaimReportJob {
+	if mmClaimReportJob.mock.inspectFuncClaimReportJob != nil {
+		mmClaimReportJob.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.ClaimReportJob")
 	}
 
-	mmEnrollment.mock.inspectFuncEnrollment = f
+	mmClaimReportJob.mock.inspectFuncClaimReportJob = f
 
-	return mmEnrollment
+	return mmClaimReportJob
 }
 
-// Return sets up results that will be returned by IRepository.Enrollment
-func (mmEnrollment *mIRepositoryMockEnrollment) Return(err error) *IRepositoryMock {
-	if mmEnrollment.mock.funcEnrollment != nil {
-		mmEnrollment.mock.t.Fatalf("IRepositoryMock.Enrollment mock is already set by Set")
+// Return sets up results that will be returned by IRepository.ClaimReportJob
+func (mmClaimReportJob *mIRepositoryMockClaimReportJob) Return(rp1 *model.ReportJob, err error) *IRepositoryMock {
+	if mmClaimReportJob.mock.funcClaimReportJob != nil {
+		mmClaimReportJob.mock.t.Fatalf("IRepositoryMock.ClaimReportJob mock is already set by Set")
 	}
 
-	if mmEnrollment.defaultExpectation == nil {
-		mmEnrollment.defaultExpectation = &IRepositoryMockEnrollmentExpectation{mock: mmEnrollment.mock}
+	if mmClaimReportJob.defaultExpectation == nil {
+		mmClaimReportJob.defaultExpectation = &IRepositoryMockClaimReportJobExpectation{mock: mmClaimReportJob.mock}
 	}
-	mmEnrollment.defaultExpectation.results = &IRepositoryMockEnrollmentResults{err}
-	return mmEnrollment.mock
+	mmClaimReportJob.defaultExpectation.results = &IRepositoryMockClaimReportJobResults{rp1, err}
+	return mmClaimReportJob.mock
 }
 
-//Set uses given function f to mock the IRepository.Enrollment method
-func (mmEnrollment *mIRepositoryMockEnrollment) Set(f func(user model.User, funds float64) (err error)) *IRepositoryMock {
-	if mmEnrollment.defaultExpectation != nil {
-		mmEnrollment.mock.t.Fatalf("Default expectation is already set for the IRepository.Enrollment method")
+// Set uses given function f to mock the IRepository.ClaimReportJob method
+func (mmClaimReportJob *mIRepositoryMockClaimReportJob) Set(f func(ctx context.Context, date time.Time, staleBefore time.Time) (rp1 *model.ReportJob, err error)) *IRepositoryMock {
+	if mmClaimReportJob.defaultExpectation != nil {
+		mmClaimReportJob.mock.t.Fatalf("Default expectation is already set for the IRepository.ClaimReportJob method")
 	}
 
-	if len(mmEnrollment.expectations) > 0 {
-		mmEnrollment.mock.t.Fatalf("Some expectations are already set for the IRepository.Enrollment method")
+	if len(mmClaimReportJob.expectations) > 0 {
+		mmClaimReportJob.mock.t.Fatalf("Some expectations are already set for the IRepository.ClaimReportJob method")
 	}
 
-	mmEnrollment.mock.funcEnrollment = f
-	return mmEnrollment.mock
+	mmClaimReportJob.mock.funcClaimReportJob = f
+	return mmClaimReportJob.mock
 }
 
-// When sets expectation for the IRepository.Enrollment which will trigger the result defined by the following
+// When sets expectation for the IRepository.ClaimReportJob which will trigger the result defined by the following
 // Then helper
-func (mmEnrollment *mIRepositoryMockEnrollment) When(user model.User, funds float64) *IRepositoryMockEnrollmentExpectation {
-	if mmEnrollment.mock.funcEnrollment != nil {
-		mmEnrollment.mock.t.Fatalf("IRepositoryMock.Enrollment mock is already set by Set")
+func (mmClaimReportJob *mIRepositoryMockClaimReportJob) When(ctx context.Context, date time.Time, staleBefore time.Time) *IRepositoryMockClaimReportJobExpectation {
+	if mmClaimReportJob.mock.funcClaimReportJob != nil {
+		mmClaimReportJob.mock.t.Fatalf("IRepositoryMock.ClaimReportJob mock is already set by Set")
 	}
 
-	expectation := &IRepositoryMockEnrollmentExpectation{
-		mock:   mmEnrollment.mock,
-		params: &IRepositoryMockEnrollmentParams{user, funds},
+	expectation := &IRepositoryMockClaimReportJobExpectation{
+		mock:   mmClaimReportJob.mock,
+		params: &IRepositoryMockClaimReportJobParams{ctx, date, staleBefore},
 	}
-	mmEnrollment.expectations = append(mmEnrollment.expectations, expectation)
+	mmClaimReportJob.expectations = append(mmClaimReportJob.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IRepository.Enrollment return parameters for the expectation previously defined by the When method
-func (e *IRepositoryMockEnrollmentExpectation) Then(err error) *IRepositoryMock {
-	e.results = &IRepositoryMockEnrollmentResults{err}
+// Then sets up IRepository.ClaimReportJob return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockClaimReportJobExpectation) Then(rp1 *model.ReportJob, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockClaimReportJobResults{rp1, err}
 	return e.mock
 }
 
-// Enrollment implements IRepository
-func (mmEnrollment *IRepositoryMock) Enrollment(user model.User, funds float64) (err error) {
-	mm_atomic.AddUint64(&mmEnrollment.beforeEnrollmentCounter, 1)
-	defer mm_atomic.AddUint64(&mmEnrollment.afterEnrollmentCounter, 1)
+// ClaimReportJob implements IRepository
+func (mmClaimReportJob *IRepositoryMock) ClaimReportJob(ctx context.Context, date time.Time, staleBefore time.Time) (rp1 *model.ReportJob, err error) {
+	mm_atomic.AddUint64(&mmClaimReportJob.beforeClaimReportJobCounter, 1)
+	defer mm_atomic.AddUint64(&mmClaimReportJob.afterClaimReportJobCounter, 1)
 
-	if mmEnrollment.inspectFuncEnrollment != nil {
-		mmEnrollment.inspectFuncEnrollment(user, funds)
+	if mmClaimReportJob.inspectFuncClaimReportJob != nil {
+		mmClaimReportJob.inspectFuncClaimReportJob(ctx, date, staleBefore)
 	}
 
-	mm_params := &IRepositoryMockEnrollmentParams{user, funds}
+	mm_params := &IRepositoryMockClaimReportJobParams{ctx, date, staleBefore}
 
 	// Record call args
-	mmEnrollment.EnrollmentMock.mutex.Lock()
-	mmEnrollment.EnrollmentMock.callArgs = append(mmEnrollment.EnrollmentMock.callArgs, mm_params)
-	mmEnrollment.EnrollmentMock.mutex.Unlock()
+	mmClaimReportJob.ClaimReportJobMock.mutex.Lock()
+	mmClaimReportJob.ClaimReportJobMock.callArgs = append(mmClaimReportJob.ClaimReportJobMock.callArgs, mm_params)
+	mmClaimReportJob.ClaimReportJobMock.mutex.Unlock()
 
-	for _, e := range mmEnrollment.EnrollmentMock.expectations {
+	for _, e := range mmClaimReportJob.ClaimReportJobMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.rp1, e.results.err
 		}
 	}
 
-	if mmEnrollment.EnrollmentMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmEnrollment.EnrollmentMock.defaultExpectation.Counter, 1)
-		mm_want := mmEnrollment.EnrollmentMock.defaultExpectation.params
-		mm_got := IRepositoryMockEnrollmentParams{user, funds}
+	if mmClaimReportJob.ClaimReportJobMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmClaimReportJob.ClaimReportJobMock.defaultExpectation.Counter, 1)
+		mm_want := mmClaimReportJob.ClaimReportJobMock.defaultExpectation.params
+		mm_got := IRepositoryMockClaimReportJobParams{ctx, date, staleBefore}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmEnrollment.t.Errorf("IRepositoryMock.Enrollment got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmClaimReportJob.t.Errorf("IRepositoryMock.ClaimReportJob got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmEnrollment.EnrollmentMock.defaultExpectation.results
+		mm_results := mmClaimReportJob.ClaimReportJobMock.defaultExpectation.results
 		if mm_results == nil {
-			mmEnrollment.t.Fatal("No results are set for the IRepositoryMock.Enrollment")
+			mmClaimReportJob.t.Fatal("No results are set for the IRepositoryMock.ClaimReportJob")
 		}
-		return (*mm_results).err
+		return (*mm_results).rp1, (*mm_results).err
 	}
-	if mmEnrollment.funcEnrollment != nil {
-		return mmEnrollment.funcEnrollment(user, funds)
+	if mmClaimReportJob.funcClaimReportJob != nil {
+		return mmClaimReportJob.funcClaimReportJob(ctx, date, staleBefore)
 	}
-	mmEnrollment.t.Fatalf("Unexpected call to IRepositoryMock.Enrollment. %v %v", user, funds)
+	mmClaimReportJob.t.Fatalf("Unexpected call to IRepositoryMock.ClaimReportJob. %v %v %v", ctx, date, staleBefore)
 	return
 }
 
-// EnrollmentAfterCounter returns a count of finished IRepositoryMock.Enrollment invocations
-func (mmEnrollment *IRepositoryMock) EnrollmentAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmEnrollment.afterEnrollmentCounter)
+// ClaimReportJobAfterCounter returns a count of finished IRepositoryMock.ClaimReportJob invocations
+func (mmClaimReportJob *IRepositoryMock) ClaimReportJobAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimReportJob.afterClaimReportJobCounter)
 }
 
-// EnrollmentBeforeCounter returns a count of IRepositoryMock.Enrollment invocations
-func (mmEnrollment *IRepositoryMock) EnrollmentBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmEnrollment.beforeEnrollmentCounter)
+// ClaimReportJobBeforeCounter returns a count of IRepositoryMock.ClaimReportJob invocations
+func (mmClaimReportJob *IRepositoryMock) ClaimReportJobBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimReportJob.beforeClaimReportJobCounter)
 }
 
-// Calls returns a list of arguments used in each call to IRepositoryMock.Enrollment.
+// Calls returns a list of arguments used in each call to IRepositoryMock.ClaimReportJob.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmEnrollment *mIRepositoryMockEnrollment) Calls() []*IRepositoryMockEnrollmentParams {
-	mmEnrollment.mutex.RLock()
+func (mmClaimReportJob *mIRepositoryMockClaimReportJob) Calls() []*IRepositoryMockClaimReportJobParams {
+	mmClaimReportJob.mutex.RLock()
 
-	argCopy := make([]*IRepositoryMockEnrollmentParams, len(mmEnrollment.callArgs))
-	copy(argCopy, mmEnrollment.callArgs)
+	argCopy := make([]*IRepositoryMockClaimReportJobParams, len(mmClaimReportJob.callArgs))
+	copy(argCopy, mmClaimReportJob.callArgs)
 
-	mmEnrollment.mutex.RUnlock()
+	mmClaimReportJob.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockEnrollmentDone returns true if the count of the Enrollment invocations corresponds
+// MinimockClaimReportJobDone returns true if the count of the ClaimReportJob invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockEnrollmentDone() bool {
-	for _, e := range m.EnrollmentMock.expectations {
+func (m *IRepositoryMock) MinimockClaimReportJobDone() bool {
+	for _, e := range m.ClaimReportJobMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.EnrollmentMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
+	if m.ClaimReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterClaimReportJobCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcEnrollment != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
+	if m.funcClaimReportJob != nil && mm_atomic.LoadUint64(&m.afterClaimReportJobCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockEnrollmentInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockEnrollmentInspect() {
-	for _, e := range m.EnrollmentMock.expectations {
+// MinimockClaimReportJobInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockClaimReportJobInspect() {
+	for _, e := range m.ClaimReportJobMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.Enrollment with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.ClaimReportJob with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.EnrollmentMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
-		if m.EnrollmentMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.Enrollment")
+	if m.ClaimReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterClaimReportJobCounter) < 1 {
+		if m.ClaimReportJobMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.ClaimReportJob")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.Enrollment with params: %#v", *m.EnrollmentMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.ClaimReportJob with params: %#v", *m.ClaimReportJobMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcEnrollment != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.Enrollment")
+	if m.funcClaimReportJob != nil && mm_atomic.LoadUint64(&m.afterClaimReportJobCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.ClaimReportJob")
 	}
 }
 
-type mIRepositoryMockGetOrder struct {
+type mIRepositoryMockCreateIdempotencyKey struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockGetOrderExpectation
-	expectations       []*IRepositoryMockGetOrderExpectation
+	defaultExpectation *IRepositoryMockCreateIdempotencyKeyExpectation
+	expectations       []*IRepositoryMockCreateIdempotencyKeyExpectation
 
-	callArgs []*IRepositoryMockGetOrderParams
+	callArgs []*IRepositoryMockCreateIdempotencyKeyParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockGetOrderExpectation specifies expectation struct of the IRepository.GetOrder
-type IRepositoryMockGetOrderExpectation struct {
+// IRepositoryMockCreateIdempotencyKeyExpectation specifies expectation struct of the IRepository.CreateIdempotencyKey
+type IRepositoryMockCreateIdempotencyKeyExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockGetOrderParams
-	results *IRepositoryMockGetOrderResults
+	params  *IRepositoryMockCreateIdempotencyKeyParams
+	results *IRepositoryMockCreateIdempotencyKeyResults
 	Counter uint64
 }
 
-// IRepositoryMockGetOrderParams contains parameters of the IRepository.GetOrder
-type IRepositoryMockGetOrderParams struct {
-	orderID uuid.UUID
+// IRepositoryMockCreateIdempotencyKeyParams contains parameters of the IRepository.CreateIdempotencyKey
+type IRepositoryMockCreateIdempotencyKeyParams struct {
+	ctx         context.Context
+	key         model.Idempotency
+	staleBefore time.Time
 }
 
-// IRepositoryMockGetOrderResults contains results of the IRepository.GetOrder
-type IRepositoryMockGetOrderResults struct {
-	op1 *model.Order
+// IRepositoryMockCreateIdempotencyKeyResults contains results of the IRepository.CreateIdempotencyKey
+type IRepositoryMockCreateIdempotencyKeyResults struct {
+	b1  bool
 	err error
 }
 
-// Expect sets up expected params for IRepository.GetOrder
-func (mmGetOrder *mIRepositoryMockGetOrder) Expect(orderID uuid.UUID) *mIRepositoryMockGetOrder {
-	if mmGetOrder.mock.funcGetOrder != nil {
-		mmGetOrder.mock.t.Fatalf("IRepositoryMock.GetOrder mock is already set by Set")
+// Expect sets up expected params for IRepository.CreateIdempotencyKey
+func (mmCreateIdempotencyKey *mIRepositoryMockCreateIdempotencyKey) Expect(ctx context.Context, key model.Idempotency, staleBefore time.Time) *mIRepositoryMockCreateIdempotencyKey {
+	if mmCreateIdempotencyKey.mock.funcCreateIdempotencyKey != nil {
+		mmCreateIdempotencyKey.mock.t.Fatalf("IRepositoryMock.CreateIdempotencyKey mock is already set by Set")
 	}
 
-	if mmGetOrder.defaultExpectation == nil {
-		mmGetOrder.defaultExpectation = &IRepositoryMockGetOrderExpectation{}
+	if mmCreateIdempotencyKey.defaultExpectation == nil {
+		mmCreateIdempotencyKey.defaultExpectation = &IRepositoryMockCreateIdempotencyKeyExpectation{}
 	}
 
-	mmGetOrder.defaultExpectation.params = &IRepositoryMockGetOrderParams{orderID}
-	for _, e := range mmGetOrder.expectations {
-		if minimock.Equal(e.params, mmGetOrder.defaultExpectation.params) {
-			mmGetOrder.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetOrder.defaultExpectation.params)
+	mmCreateIdempotencyKey.defaultExpectation.params = &IRepositoryMockCreateIdempotencyKeyParams{ctx, key, staleBefore}
+	for _, e := range mmCreateIdempotencyKey.expectations {
+		if minimock.Equal(e.params, mmCreateIdempotencyKey.defaultExpectation.params) {
+			mmCreateIdempotencyKey.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateIdempotencyKey.defaultExpectation.params)
 		}
 	}
 
-	return mmGetOrder
+	return mmCreateIdempotencyKey
 }
 
-// Inspect accepts an inspector function that has same arguments as the IRepository.GetOrder
-func (mmGetOrder *mIRepositoryMockGetOrder) Inspect(f func(orderID uuid.UUID)) *mIRepositoryMockGetOrder {
-	if mmGetOrder.mock.inspectFuncGetOrder != nil {
-		mmGetOrder.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.GetOrder")
+// Inspect accepts an inspector function that has same arguments as the IRepository.CreateIdempotencyKey
+func (mmCreateIdempotencyKey *mIRepositoryMockCreateIdempotencyKey) Inspect(f func(ctx context.Context, key model.Idempotency, staleBefore time.Time)) *mIRepositoryMockCreateIdempotencyKey {
+	if mmCreateIdempotencyKey.mock.inspectFuncCreateIdempotencyKey != nil {
+		mmCreateIdempotencyKey.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.CreateIdempotencyKey")
 	}
 
-	mmGetOrder.mock.inspectFuncGetOrder = f
+	mmCreateIdempotencyKey.mock.inspectFuncCreateIdempotencyKey = f
 
-	return mmGetOrder
+	return mmCreateIdempotencyKey
 }
 
-// Return sets up results that will be returned by IRepository.GetOrder
-func (mmGetOrder *mIRepositoryMockGetOrder) Return(op1 *model.Order, err error) *IRepositoryMock {
-	if mmGetOrder.mock.funcGetOrder != nil {
-		mmGetOrder.mock.t.Fatalf("IRepositoryMock.GetOrder mock is already set by Set")
+// Return sets up results that will be returned by IRepository.CreateIdempotencyKey
+func (mmCreateIdempotencyKey *mIRepositoryMockCreateIdempotencyKey) Return(b1 bool, err error) *IRepositoryMock {
+	if mmCreateIdempotencyKey.mock.funcCreateIdempotencyKey != nil {
+		mmCreateIdempotencyKey.mock.t.Fatalf("IRepositoryMock.CreateIdempotencyKey mock is already set by Set")
 	}
 
-	if mmGetOrder.defaultExpectation == nil {
-		mmGetOrder.defaultExpectation = &IRepositoryMockGetOrderExpectation{mock: mmGetOrder.mock}
+	if mmCreateIdempotencyKey.defaultExpectation == nil {
+		mmCreateIdempotencyKey.defaultExpectation = &IRepositoryMockCreateIdempotencyKeyExpectation{mock: mmCreateIdempotencyKey.mock}
 	}
-	mmGetOrder.defaultExpectation.results = &IRepositoryMockGetOrderResults{op1, err}
-	return mmGetOrder.mock
+	mmCreateIdempotencyKey.defaultExpectation.results = &IRepositoryMockCreateIdempotencyKeyResults{b1, err}
+	return mmCreateIdempotencyKey.mock
 }
 
-//Set uses given function f to mock the IRepository.GetOrder method
-func (mmGetOrder *mIRepositoryMockGetOrder) Set(f func(orderID uuid.UUID) (op1 *model.Order, err error)) *IRepositoryMock {
-	if mmGetOrder.defaultExpectation != nil {
-		mmGetOrder.mock.t.Fatalf("Default expectation is already set for the IRepository.GetOrder method")
+// Set uses given function f to mock the IRepository.CreateIdempotencyKey method
+func (mmCreateIdempotencyKey *mIRepositoryMockCreateIdempotencyKey) Set(f func(ctx context.Context, key model.Idempotency, staleBefore time.Time) (b1 bool, err error)) *IRepositoryMock {
+	if mmCreateIdempotencyKey.defaultExpectation != nil {
+		mmCreateIdempotencyKey.mock.t.Fatalf("Default expectation is already set for the IRepository.CreateIdempotencyKey method")
 	}
 
-	if len(mmGetOrder.expectations) > 0 {
-		mmGetOrder.mock.t.Fatalf("Some expectations are already set for the IRepository.GetOrder method")
+	if len(mmCreateIdempotencyKey.expectations) > 0 {
+		mmCreateIdempotencyKey.mock.t.Fatalf("Some expectations are already set for the IRepository.CreateIdempotencyKey method")
 	}
 
-	mmGetOrder.mock.funcGetOrder = f
-	return mmGetOrder.mock
+	mmCreateIdempotencyKey.mock.funcCreateIdempotencyKey = f
+	return mmCreateIdempotencyKey.mock
 }
 
-// When sets expectation for the IRepository.GetOrder which will trigger the result defined by the following
+// When sets expectation for the IRepository.CreateIdempotencyKey which will trigger the result defined by the following
 // Then helper
-func (mmGetOrder *mIRepositoryMockGetOrder) When(orderID uuid.UUID) *IRepositoryMockGetOrderExpectation {
-	if mmGetOrder.mock.funcGetOrder != nil {
-		mmGetOrder.mock.t.Fatalf("IRepositoryMock.GetOrder mock is already set by Set")
+func (mmCreateIdempotencyKey *mIRepositoryMockCreateIdempotencyKey) When(ctx context.Context, key model.Idempotency, staleBefore time.Time) *IRepositoryMockCreateIdempotencyKeyExpectation {
+	if mmCreateIdempotencyKey.mock.funcCreateIdempotencyKey != nil {
+		mmCreateIdempotencyKey.mock.t.Fatalf("IRepositoryMock.CreateIdempotencyKey mock is already set by Set")
 	}
 
-	expectation := &IRepositoryMockGetOrderExpectation{
-		mock:   mmGetOrder.mock,
-		params: &IRepositoryMockGetOrderParams{orderID},
+	expectation := &IRepositoryMockCreateIdempotencyKeyExpectation{
+		mock:   mmCreateIdempotencyKey.mock,
+		params: &IRepositoryMockCreateIdempotencyKeyParams{ctx, key, staleBefore},
 	}
-	mmGetOrder.expectations = append(mmGetOrder.expectations, expectation)
+	mmCreateIdempotencyKey.expectations = append(mmCreateIdempotencyKey.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IRepository.GetOrder return parameters for the expectation previously defined by the When method
-func (e *IRepositoryMockGetOrderExpectation) Then(op1 *model.Order, err error) *IRepositoryMock {
-	e.results = &IRepositoryMockGetOrderResults{op1, err}
+// Then sets up IRepository.CreateIdempotencyKey return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockCreateIdempotencyKeyExpectation) Then(b1 bool, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockCreateIdempotencyKeyResults{b1, err}
 	return e.mock
 }
 
-// GetOrder implements IRepository
-func (mmGetOrder *IRepositoryMock) GetOrder(orderID uuid.UUID) (op1 *model.Order, err error) {
-	mm_atomic.AddUint64(&mmGetOrder.beforeGetOrderCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetOrder.afterGetOrderCounter, 1)
+// CreateIdempotencyKey implements IRepository
+func (mmCreateIdempotencyKey *IRepositoryMock) CreateIdempotencyKey(ctx context.Context, key model.Idempotency, staleBefore time.Time) (b1 bool, err error) {
+	mm_atomic.AddUint64(&mmCreateIdempotencyKey.beforeCreateIdempotencyKeyCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateIdempotencyKey.afterCreateIdempotencyKeyCounter, 1)
 
-	if mmGetOrder.inspectFuncGetOrder != nil {
-		mmGetOrder.inspectFuncGetOrder(orderID)
+	if mmCreateIdempotencyKey.inspectFuncCreateIdempotencyKey != nil {
+		mmCreateIdempotencyKey.inspectFuncCreateIdempotencyKey(ctx, key, staleBefore)
 	}
 
-	mm_params := &IRepositoryMockGetOrderParams{orderID}
+	mm_params := &IRepositoryMockCreateIdempotencyKeyParams{ctx, key, staleBefore}
 
 	// Record call args
-	mmGetOrder.GetOrderMock.mutex.Lock()
-	mmGetOrder.GetOrderMock.callArgs = append(mmGetOrder.GetOrderMock.callArgs, mm_params)
-	mmGetOrder.GetOrderMock.mutex.Unlock()
+	mmCreateIdempotencyKey.CreateIdempotencyKeyMock.mutex.Lock()
+	mmCreateIdempotencyKey.CreateIdempotencyKeyMock.callArgs = append(mmCreateIdempotencyKey.CreateIdempotencyKeyMock.callArgs, mm_params)
+	mmCreateIdempotencyKey.CreateIdempotencyKeyMock.mutex.Unlock()
 
-	for _, e := range mmGetOrder.GetOrderMock.expectations {
+	for _, e := range mmCreateIdempotencyKey.CreateIdempotencyKeyMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.op1, e.results.err
+			return e.results.b1, e.results.err
 		}
 	}
 
-	if mmGetOrder.GetOrderMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetOrder.GetOrderMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetOrder.GetOrderMock.defaultExpectation.params
-		mm_got := IRepositoryMockGetOrderParams{orderID}
+	if mmCreateIdempotencyKey.CreateIdempotencyKeyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateIdempotencyKey.CreateIdempotencyKeyMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateIdempotencyKey.CreateIdempotencyKeyMock.defaultExpectation.params
+		mm_got := IRepositoryMockCreateIdempotencyKeyParams{ctx, key, staleBefore}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetOrder.t.Errorf("IRepositoryMock.GetOrder got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmCreateIdempotencyKey.t.Errorf("IRepositoryMock.CreateIdempotencyKey got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetOrder.GetOrderMock.defaultExpectation.results
+		mm_results := mmCreateIdempotencyKey.CreateIdempotencyKeyMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetOrder.t.Fatal("No results are set for the IRepositoryMock.GetOrder")
+			mmCreateIdempotencyKey.t.Fatal("No results are set for the IRepositoryMock.CreateIdempotencyKey")
 		}
-		return (*mm_results).op1, (*mm_results).err
+		return (*mm_results).b1, (*mm_results).err
 	}
-	if mmGetOrder.funcGetOrder != nil {
-		return mmGetOrder.funcGetOrder(orderID)
+	if mmCreateIdempotencyKey.funcCreateIdempotencyKey != nil {
+		return mmCreateIdempotencyKey.funcCreateIdempotencyKey(ctx, key, staleBefore)
 	}
-	mmGetOrder.t.Fatalf("Unexpected call to IRepositoryMock.GetOrder. %v", orderID)
+	mmCreateIdempotencyKey.t.Fatalf("Unexpected call to IRepositoryMock.CreateIdempotencyKey. %v %v %v", ctx, key, staleBefore)
 	return
 }
 
-// GetOrderAfterCounter returns a count of finished IRepositoryMock.GetOrder invocations
-func (mmGetOrder *IRepositoryMock) GetOrderAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetOrder.afterGetOrderCounter)
+// CreateIdempotencyKeyAfterCounter returns a count of finished IRepositoryMock.CreateIdempotencyKey invocations
+func (mmCreateIdempotencyKey *IRepositoryMock) CreateIdempotencyKeyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateIdempotencyKey.afterCreateIdempotencyKeyCounter)
 }
 
-// GetOrderBeforeCounter returns a count of IRepositoryMock.GetOrder invocations
-func (mmGetOrder *IRepositoryMock) GetOrderBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetOrder.beforeGetOrderCounter)
+// CreateIdempotencyKeyBeforeCounter returns a count of IRepositoryMock.CreateIdempotencyKey invocations
+func (mmCreateIdempotencyKey *IRepositoryMock) CreateIdempotencyKeyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateIdempotencyKey.beforeCreateIdempotencyKeyCounter)
 }
 
-// Calls returns a list of arguments used in each call to IRepositoryMock.GetOrder.
+// Calls returns a list of arguments used in each call to IRepositoryMock.CreateIdempotencyKey.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetOrder *mIRepositoryMockGetOrder) Calls() []*IRepositoryMockGetOrderParams {
-	mmGetOrder.mutex.RLock()
+func (mmCreateIdempotencyKey *mIRepositoryMockCreateIdempotencyKey) Calls() []*IRepositoryMockCreateIdempotencyKeyParams {
+	mmCreateIdempotencyKey.mutex.RLock()
 
-	argCopy := make([]*IRepositoryMockGetOrderParams, len(mmGetOrder.callArgs))
-	copy(argCopy, mmGetOrder.callArgs)
+	argCopy := make([]*IRepositoryMockCreateIdempotencyKeyParams, len(mmCreateIdempotencyKey.callArgs))
+	copy(argCopy, mmCreateIdempotencyKey.callArgs)
 
-	mmGetOrder.mutex.RUnlock()
+	mmCreateIdempotencyKey.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetOrderDone returns true if the count of the GetOrder invocations corresponds
+// MinimockCreateIdempotencyKeyDone returns true if the count of the CreateIdempotencyKey invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockGetOrderDone() bool {
-	for _, e := range m.GetOrderMock.expectations {
+func (m *IRepositoryMock) MinimockCreateIdempotencyKeyDone() bool {
+	for _, e := range m.CreateIdempotencyKeyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetOrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
+	if m.CreateIdempotencyKeyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterCreateIdempotencyKeyCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetOrder != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
+	if m.funcCreateIdempotencyKey != nil && mm_atomic.LoadUint64(&m.afterCreateIdempotencyKeyCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockGetOrderInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockGetOrderInspect() {
-	for _, e := range m.GetOrderMock.expectations {
+// MinimockCreateIdempotencyKeyInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockCreateIdempotencyKeyInspect() {
+	for _, e := range m.CreateIdempotencyKeyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.GetOrder with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.CreateIdempotencyKey with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetOrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
-		if m.GetOrderMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.GetOrder")
+	if m.CreateIdempotencyKeyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterCreateIdempotencyKeyCounter) < 1 {
+		if m.CreateIdempotencyKeyMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.CreateIdempotencyKey")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.GetOrder with params: %#v", *m.GetOrderMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.CreateIdempotencyKey with params: %#v", *m.CreateIdempotencyKeyMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetOrder != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.GetOrder")
+	if m.funcCreateIdempotencyKey != nil && mm_atomic.LoadUint64(&m.afterCreateIdempotencyKeyCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.CreateIdempotencyKey")
 	}
 }
 
-type mIRepositoryMockHistory struct {
+type mIRepositoryMockCreateReportJob struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockHistoryExpectation
-	expectations       []*IRepositoryMockHistoryExpectation
+	defaultExpectation *IRepositoryMockCreateReportJobExpectation
+	expectations       []*IRepositoryMockCreateReportJobExpectation
 
-	callArgs []*IRepositoryMockHistoryParams
+	callArgs []*IRepositoryMockCreateReportJobParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockHistoryExpectation specifies expectation struct of the IRepository.History
-type IRepositoryMockHistoryExpectation struct {
+// IRepositoryMockCreateReportJobExpectation specifies expectation struct of the IRepository.CreateReportJob
+type IRepositoryMockCreateReportJobExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockHistoryParams
-	results *IRepositoryMockHistoryResults
+	params  *IRepositoryMockCreateReportJobParams
+	results *IRepositoryMockCreateReportJobResults
 	Counter uint64
 }
 
-// IRepositoryMockHistoryParams contains parameters of the IRepository.History
-type IRepositoryMockHistoryParams struct {
-	userID uuid.UUID
-	limit  int
-	offset int
+// IRepositoryMockCreateReportJobParams contains parameters of the IRepository.CreateReportJob
+type IRepositoryMockCreateReportJobParams struct {
+	ctx context.Context
+	job model.ReportJob
 }
 
-// IRepositoryMockHistoryResults contains results of the IRepository.History
-type IRepositoryMockHistoryResults struct {
-	ha1 []model.History
+// IRepositoryMockCreateReportJobResults contains results of the IRepository.CreateReportJob
+type IRepositoryMockCreateReportJobResults struct {
 	err error
 }
 
-// Expect sets up expected params for IRepository.History
-func (mmHistory *mIRepositoryMockHistory) Expect(userID uuid.UUID, limit int, offset int) *mIRepositoryMockHistory {
-	if mmHistory.mock.funcHistory != nil {
-		mmHistory.mock.t.Fatalf("IRepositoryMock.History mock is already set by Set")
+// Expect sets up expected params for IRepository.CreateReportJob
+func (mmCreateReportJob *mIRepositoryMockCreateReportJob) Expect(ctx context.Context, job model.ReportJob) *mIRepositoryMockCreateReportJob {
+	if mmCreateReportJob.mock.funcCreateReportJob != nil {
+		mmCreateReportJob.mock.t.Fatalf("IRepositoryMock.CreateReportJob mock is already set by Set")
 	}
 
-	if mmHistory.defaultExpectation == nil {
-		mmHistory.defaultExpectation = &IRepositoryMockHistoryExpectation{}
+	if mmCreateReportJob.defaultExpectation == nil {
+		mmCreateReportJob.defaultExpectation = &IRepositoryMockCreateReportJobExpectation{}
 	}
 
-	mmHistory.defaultExpectation.params = &IRepositoryMockHistoryParams{userID, limit, offset}
-	for _, e := range mmHistory.expectations {
-		if minimock.Equal(e.params, mmHistory.defaultExpectation.params) {
-			mmHistory.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHistory.defaultExpectation.params)
+	mmCreateReportJob.defaultExpectation.params = &IRepositoryMockCreateReportJobParams{ctx, job}
+	for _, e := range mmCreateReportJob.expectations {
+		if minimock.Equal(e.params, mmCreateReportJob.defaultExpectation.params) {
+			mmCreateReportJob.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateReportJob.defaultExpectation.params)
 		}
 	}
 
-	return mmHistory
+	return mmCreateReportJob
 }
 
-// Inspect accepts an inspector function that has same arguments as the IRepository.History
-func (mmHistory *mIRepositoryMockHistory) Inspect(f func(userID uuid.UUID, limit int, offset int)) *mIRepositoryMockHistory {
-	if mmHistory.mock.inspectFuncHistory != nil {
-		mmHistory.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.History")
+// Inspect accepts an inspector function that has same arguments as the IRepository.CreateReportJob
+func (mmCreateReportJob *mIRepositoryMockCreateReportJob) Inspect(f func(ctx context.Context, job model.ReportJob)) *mIRepositoryMockCreateReportJob {
+	if mmCreateReportJob.mock.inspectFuncCreateReportJob != nil {
+		mmCreateReportJob.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.CreateReportJob")
 	}
 
-	mmHistory.mock.inspectFuncHistory = f
+	mmCreateReportJob.mock.inspectFuncCreateReportJob = f
 
-	return mmHistory
+	return mmCreateReportJob
 }
 
-// Return sets up results that will be returned by IRepository.History
-func (mmHistory *mIRepositoryMockHistory) Return(ha1 []model.History, err error) *IRepositoryMock {
-	if mmHistory.mock.funcHistory != nil {
-		mmHistory.mock.t.Fatalf("IRepositoryMock.History mock is already set by Set")
+// Return sets up results that will be returned by IRepository.CreateReportJob
+func (mmCreateReportJob *mIRepositoryMockCreateReportJob) Return(err error) *IRepositoryMock {
+	if mmCreateReportJob.mock.funcCreateReportJob != nil {
+		mmCreateReportJob.mock.t.Fatalf("IRepositoryMock.CreateReportJob mock is already set by Set")
 	}
 
-	if mmHistory.defaultExpectation == nil {
-		mmHistory.defaultExpectation = &IRepositoryMockHistoryExpectation{mock: mmHistory.mock}
+	if mmCreateReportJob.defaultExpectation == nil {
+		mmCreateReportJob.defaultExpectation = &IRepositoryMockCreateReportJobExpectation{mock: mmCreateReportJob.mock}
 	}
-	mmHistory.defaultExpectation.results = &IRepositoryMockHistoryResults{ha1, err}
-	return mmHistory.mock
+	mmCreateReportJob.defaultExpectation.results = &IRepositoryMockCreateReportJobResults{err}
+	return mmCreateReportJob.mock
 }
 
-//Set uses given function f to mock the IRepository.History method
-func (mmHistory *mIRepositoryMockHistory) Set(f func(userID uuid.UUID, limit int, offset int) (ha1 []model.History, err error)) *IRepositoryMock {
-	if mmHistory.defaultExpectation != nil {
-		mmHistory.mock.t.Fatalf("Default expectation is already set for the IRepository.History method")
+// Set uses given function f to mock the IRepository.CreateReportJob method
+func (mmCreateReportJob *mIRepositoryMockCreateReportJob) Set(f func(ctx context.Context, job model.ReportJob) (err error)) *IRepositoryMock {
+	if mmCreateReportJob.defaultExpectation != nil {
+		mmCreateReportJob.mock.t.Fatalf("Default expectation is already set for the IRepository.CreateReportJob method")
 	}
 
-	if len(mmHistory.expectations) > 0 {
-		mmHistory.mock.t.Fatalf("Some expectations are already set for the IRepository.History method")
+	if len(mmCreateReportJob.expectations) > 0 {
+		mmCreateReportJob.mock.t.Fatalf("Some expectations are already set for the IRepository.CreateReportJob method")
 	}
 
-	mmHistory.mock.funcHistory = f
-	return mmHistory.mock
+	mmCreateReportJob.mock.funcCreateReportJob = f
+	return mmCreateReportJob.mock
 }
 
-// When sets expectation for the IRepository.History which will trigger the result defined by the following
+// When sets expectation for the IRepository.CreateReportJob which will trigger the result defined by the following
 // Then helper
-func (mmHistory *mIRepositoryMockHistory) When(userID uuid.UUID, limit int, offset int) *IRepositoryMockHistoryExpectation {
-	if mmHistory.mock.funcHistory != nil {
-		mmHistory.mock.t.Fatalf("IRepositoryMock.History mock is already set by Set")
+func (mmCreateReportJob *mIRepositoryMockCreateReportJob) When(ctx context.Context, job model.ReportJob) *IRepositoryMockCreateReportJobExpectation {
+	if mmCreateReportJob.mock.funcCreateReportJob != nil {
+		mmCreateReportJob.mock.t.Fatalf("IRepositoryMock.CreateReportJob mock is already set by Set")
 	}
 
-	expectation := &IRepositoryMockHistoryExpectation{
-		mock:   mmHistory.mock,
-		params: &IRepositoryMockHistoryParams{userID, limit, offset},
+	expectation := &IRepositoryMockCreateReportJobExpectation{
+		mock:   mmCreateReportJob.mock,
+		params: &IRepositoryMockCreateReportJobParams{ctx, job},
 	}
-	mmHistory.expectations = append(mmHistory.expectations, expectation)
+	mmCreateReportJob.expectations = append(mmCreateReportJob.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IRepository.History return parameters for the expectation previously defined by the When method
-func (e *IRepositoryMockHistoryExpectation) Then(ha1 []model.History, err error) *IRepositoryMock {
-	e.results = &IRepositoryMockHistoryResults{ha1, err}
+// Then sets up IRepository.CreateReportJob return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockCreateReportJobExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockCreateReportJobResults{err}
 	return e.mock
 }
 
-// History implements IRepository
-func (mmHistory *IRepositoryMock) History(userID uuid.UUID, limit int, offset int) (ha1 []model.History, err error) {
-	mm_atomic.AddUint64(&mmHistory.beforeHistoryCounter, 1)
-	defer mm_atomic.AddUint64(&mmHistory.afterHistoryCounter, 1)
+// CreateReportJob implements IRepository
+func (mmCreateReportJob *IRepositoryMock) CreateReportJob(ctx context.Context, job model.ReportJob) (err error) {
+	mm_atomic.AddUint64(&mmCreateReportJob.beforeCreateReportJobCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateReportJob.afterCreateReportJobCounter, 1)
 
-	if mmHistory.inspectFuncHistory != nil {
-		mmHistory.inspectFuncHistory(userID, limit, offset)
+	if mmCreateReportJob.inspectFuncCreateReportJob != nil {
+		mmCreateReportJob.inspectFuncCreateReportJob(ctx, job)
 	}
 
-	mm_params := &IRepositoryMockHistoryParams{userID, limit, offset}
+	mm_params := &IRepositoryMockCreateReportJobParams{ctx, job}
 
 	// Record call args
-	mmHistory.HistoryMock.mutex.Lock()
-	mmHistory.HistoryMock.callArgs = append(mmHistory.HistoryMock.callArgs, mm_params)
-	mmHistory.HistoryMock.mutex.Unlock()
+	mmCreateReportJob.CreateReportJobMock.mutex.Lock()
+	mmCreateReportJob.CreateReportJobMock.callArgs = append(mmCreateReportJob.CreateReportJobMock.callArgs, mm_params)
+	mmCreateReportJob.CreateReportJobMock.mutex.Unlock()
 
-	for _, e := range mmHistory.HistoryMock.expectations {
+	for _, e := range mmCreateReportJob.CreateReportJobMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.ha1, e.results.err
+			return e.results.err
 		}
 	}
 
-	if mmHistory.HistoryMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmHistory.HistoryMock.defaultExpectation.Counter, 1)
-		mm_want := mmHistory.HistoryMock.defaultExpectation.params
-		mm_got := IRepositoryMockHistoryParams{userID, limit, offset}
+	if mmCreateReportJob.CreateReportJobMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateReportJob.CreateReportJobMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateReportJob.CreateReportJobMock.defaultExpectation.params
+		mm_got := IRepositoryMockCreateReportJobParams{ctx, job}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmHistory.t.Errorf("IRepositoryMock.History got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmCreateReportJob.t.Errorf("IRepositoryMock.CreateReportJob got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmHistory.HistoryMock.defaultExpectation.results
+		mm_results := mmCreateReportJob.CreateReportJobMock.defaultExpectation.results
 		if mm_results == nil {
-			mmHistory.t.Fatal("No results are set for the IRepositoryMock.History")
+			mmCreateReportJob.t.Fatal("No results are set for the IRepositoryMock.CreateReportJob")
 		}
-		return (*mm_results).ha1, (*mm_results).err
+		return (*mm_results).err
 	}
-	if mmHistory.funcHistory != nil {
-		return mmHistory.funcHistory(userID, limit, offset)
+	if mmCreateReportJob.funcCreateReportJob != nil {
+		return mmCreateReportJob.funcCreateReportJob(ctx, job)
 	}
-	mmHistory.t.Fatalf("Unexpected call to IRepositoryMock.History. %v %v %v", userID, limit, offset)
+	mmCreateReportJob.t.Fatalf("Unexpected call to IRepositoryMock.CreateReportJob. %v %v", ctx, job)
 	return
 }
 
-// HistoryAfterCounter returns a count of finished IRepositoryMock.History invocations
-func (mmHistory *IRepositoryMock) HistoryAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmHistory.afterHistoryCounter)
+// CreateReportJobAfterCounter returns a count of finished IRepositoryMock.CreateReportJob invocations
+func (mmCreateReportJob *IRepositoryMock) CreateReportJobAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateReportJob.afterCreateReportJobCounter)
 }
 
-// HistoryBeforeCounter returns a count of IRepositoryMock.History invocations
-func (mmHistory *IRepositoryMock) HistoryBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmHistory.beforeHistoryCounter)
+// CreateReportJobBeforeCounter returns a count of IRepositoryMock.CreateReportJob invocations
+func (mmCreateReportJob *IRepositoryMock) CreateReportJobBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateReportJob.beforeCreateReportJobCounter)
 }
 
-// Calls returns a list of arguments used in each call to IRepositoryMock.History.
+// Calls returns a list of arguments used in each call to IRepositoryMock.CreateReportJob.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmHistory *mIRepositoryMockHistory) Calls() []*IRepositoryMockHistoryParams {
-	mmHistory.mutex.RLock()
+func (mmCreateReportJob *mIRepositoryMockCreateReportJob) Calls() []*IRepositoryMockCreateReportJobParams {
+	mmCreateReportJob.mutex.RLock()
 
-	argCopy := make([]*IRepositoryMockHistoryParams, len(mmHistory.callArgs))
-	copy(argCopy, mmHistory.callArgs)
+	argCopy := make([]*IRepositoryMockCreateReportJobParams, len(mmCreateReportJob.callArgs))
+	copy(argCopy, mmCreateReportJob.callArgs)
 
-	mmHistory.mutex.RUnlock()
+	mmCreateReportJob.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockHistoryDone returns true if the count of the History invocations corresponds
+// MinimockCreateReportJobDone returns true if the count of the CreateReportJob invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockHistoryDone() bool {
-	for _, e := range m.HistoryMock.expectations {
+func (m *IRepositoryMock) MinimockCreateReportJobDone() bool {
+	for _, e := range m.CreateReportJobMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.HistoryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
+	if m.CreateReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterCreateReportJobCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcHistory != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
+	if m.funcCreateReportJob != nil && mm_atomic.LoadUint64(&m.afterCreateReportJobCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockHistoryInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockHistoryInspect() {
-	for _, e := range m.HistoryMock.expectations {
+// MinimockCreateReportJobInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockCreateReportJobInspect() {
+	for _, e := range m.CreateReportJobMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.History with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.CreateReportJob with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.HistoryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
-		if m.HistoryMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.History")
+	if m.CreateReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterCreateReportJobCounter) < 1 {
+		if m.CreateReportJobMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.CreateReportJob")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.History with params: %#v", *m.HistoryMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.CreateReportJob with params: %#v", *m.CreateReportJobMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcHistory != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.History")
+	if m.funcCreateReportJob != nil && mm_atomic.LoadUint64(&m.afterCreateReportJobCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.CreateReportJob")
 	}
 }
 
-type mIRepositoryMockOrder struct {
+type mIRepositoryMockCreateService struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockOrderExpectation
-	expectations       []*IRepositoryMockOrderExpectation
+	defaultExpectation *IRepositoryMockCreateServiceExpectation
+	expectations       []*IRepositoryMockCreateServiceExpectation
 
-	callArgs []*IRepositoryMockOrderParams
+	callArgs []*IRepositoryMockCreateServiceParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockOrderExpectation specifies expectation struct of the IRepository.Order
-type IRepositoryMockOrderExpectation struct {
+// IRepositoryMockCreateServiceExpectation specifies expectation struct of the IRepository.CreateService
+type IRepositoryMockCreateServiceExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockOrderParams
-	results *IRepositoryMockOrderResults
+	params  *IRepositoryMockCreateServiceParams
+	results *IRepositoryMockCreateServiceResults
 	Counter uint64
 }
 
-// IRepositoryMockOrderParams contains parameters of the IRepository.Order
-type IRepositoryMockOrderParams struct {
-	user  model.User
-	order model.Order
+// IRepositoryMockCreateServiceParams contains parameters of the IRepository.CreateService
+type IRepositoryMockCreateServiceParams struct {
+	ctx context.Context
+	s   model.Service
 }
 
-// IRepositoryMockOrderResults contains results of the IRepository.Order
-type IRepositoryMockOrderResults struct {
+// IRepositoryMockCreateServiceResults contains results of the IRepository.CreateService
+type IRepositoryMockCreateServiceResults struct {
 	err error
 }
 
-// Expect sets up expected params for IRepository.Order
-func (mmOrder *mIRepositoryMockOrder) Expect(user model.User, order model.Order) *mIRepositoryMockOrder {
-	if mmOrder.mock.funcOrder != nil {
-		mmOrder.mock.t.Fatalf("IRepositoryMock.Order mock is already set by Set")
+// Expect sets up expected params for IRepository.CreateService
+func (mmCreateService *mIRepositoryMockCreateService) Expect(ctx context.Context, s model.Service) *mIRepositoryMockCreateService {
+	if mmCreateService.mock.funcCreateService != nil {
+		mmCreateService.mock.t.Fatalf("IRepositoryMock.CreateService mock is already set by Set")
 	}
 
-	if mmOrder.defaultExpectation == nil {
-		mmOrder.defaultExpectation = &IRepositoryMockOrderExpectation{}
+	if mmCreateService.defaultExpectation == nil {
+		mmCreateService.defaultExpectation = &IRepositoryMockCreateServiceExpectation{}
 	}
 
-	mmOrder.defaultExpectation.params = &IRepositoryMockOrderParams{user, order}
-	for _, e := range mmOrder.expectations {
-		if minimock.Equal(e.params, mmOrder.defaultExpectation.params) {
-			mmOrder.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmOrder.defaultExpectation.params)
+	mmCreateService.defaultExpectation.params = &IRepositoryMockCreateServiceParams{ctx, s}
+	for _, e := range mmCreateService.expectations {
+		if minimock.Equal(e.params, mmCreateService.defaultExpectation.params) {
+			mmCreateService.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateService.defaultExpectation.params)
 		}
 	}
 
-	return mmOrder
+	return mmCreateService
 }
 
-// Inspect accepts an inspector function that has same arguments as the IRepository.Order
-func (mmOrder *mIRepositoryMockOrder) Inspect(f func(user model.User, order model.Order)) *mIRepositoryMockOrder {
-	if mmOrder.mock.inspectFuncOrder != nil {
-		mmOrder.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Order")
+// Inspect accepts an inspector function that has same arguments as the IRepository.CreateService
+func (mmCreateService *mIRepositoryMockCreateService) Inspect(f func(ctx context.Context, s model.Service)) *mIRepositoryMockCreateService {
+	if mmCreateService.mock.inspectFuncCreateService != nil {
+		mmCreateService.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.CreateService")
 	}
 
-	mmOrder.mock.inspectFuncOrder = f
+	mmCreateService.mock.inspectFuncCreateService = f
 
-	return mmOrder
+	return mmCreateService
 }
 
-// Return sets up results that will be returned by IRepository.Order
-func (mmOrder *mIRepositoryMockOrder) Return(err error) *IRepositoryMock {
-	if mmOrder.mock.funcOrder != nil {
-		mmOrder.mock.t.Fatalf("IRepositoryMock.Order mock is already set by Set")
+// Return sets up results that will be returned by IRepository.CreateService
+func (mmCreateService *mIRepositoryMockCreateService) Return(err error) *IRepositoryMock {
+	if mmCreateService.mock.funcCreateService != nil {
+		mmCreateService.mock.t.Fatalf("IRepositoryMock.CreateService mock is already set by Set")
 	}
 
-	if mmOrder.defaultExpectation == nil {
-		mmOrder.defaultExpectation = &IRepositoryMockOrderExpectation{mock: mmOrder.mock}
+	if mmCreateService.defaultExpectation == nil {
+		mmCreateService.defaultExpectation = &IRepositoryMockCreateServiceExpectation{mock: mmCreateService.mock}
 	}
-	mmOrder.defaultExpectation.results = &IRepositoryMockOrderResults{err}
-	return mmOrder.mock
+	mmCreateService.defaultExpectation.results = &IRepositoryMockCreateServiceResults{err}
+	return mmCreateService.mock
 }
 
-//Set uses given function f to mock the IRepository.Order method
-func (mmOrder *mIRepositoryMockOrder) Set(f func(user model.User, order model.Order) (err error)) *IRepositoryMock {
-	if mmOrder.defaultExpectation != nil {
-		mmOrder.mock.t.Fatalf("Default expectation is already set for the IRepository.Order method")
+// Set uses given function f to mock the IRepository.CreateService method
+func (mmCreateService *mIRepositoryMockCreateService) Set(f func(ctx context.Context, s model.Service) (err error)) *IRepositoryMock {
+	if mmCreateService.defaultExpectation != nil {
+		mmCreateService.mock.t.Fatalf("Default expectation is already set for the IRepository.CreateService method")
 	}
 
-	if len(mmOrder.expectations) > 0 {
-		mmOrder.mock.t.Fatalf("Some expectations are already set for the IRepository.Order method")
+	if len(mmCreateService.expectations) > 0 {
+		mmCreateService.mock.t.Fatalf("Some expectations are already set for the IRepository.CreateService method")
 	}
 
-	mmOrder.mock.funcOrder = f
-	return mmOrder.mock
+	mmCreateService.mock.funcCreateService = f
+	return mmCreateService.mock
 }
 
-// When sets expectation for the IRepository.Order which will trigger the result defined by the following
+// When sets expectation for the IRepository.CreateService which will trigger the result defined by the following
 // Then helper
-func (mmOrder *mIRepositoryMockOrder) When(user model.User, order model.Order) *IRepositoryMockOrderExpectation {
-	if mmOrder.mock.funcOrder != nil {
-		mmOrder.mock.t.Fatalf("IRepositoryMock.Order mock is already set by Set")
+func (mmCreateService *mIRepositoryMockCreateService) When(ctx context.Context, s model.Service) *IRepositoryMockCreateServiceExpectation {
+	if mmCreateService.mock.funcCreateService != nil {
+		mmCreateService.mock.t.Fatalf("IRepositoryMock.CreateService mock is already set by Set")
 	}
 
-	expectation := &IRepositoryMockOrderExpectation{
-		mock:   mmOrder.mock,
-		params: &IRepositoryMockOrderParams{user, order},
+	expectation := &IRepositoryMockCreateServiceExpectation{
+		mock:   mmCreateService.mock,
+		params: &IRepositoryMockCreateServiceParams{ctx, s},
 	}
-	mmOrder.expectations = append(mmOrder.expectations, expectation)
+	mmCreateService.expectations = append(mmCreateService.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IRepository.Order return parameters for the expectation previously defined by the When method
-func (e *IRepositoryMockOrderExpectation) Then(err error) *IRepositoryMock {
-	e.results = &IRepositoryMockOrderResults{err}
+// Then sets up IRepository.CreateService return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockCreateServiceExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockCreateServiceResults{err}
 	return e.mock
 }
 
-// Order implements IRepository
-func (mmOrder *IRepositoryMock) Order(user model.User, order model.Order) (err error) {
-	mm_atomic.AddUint64(&mmOrder.beforeOrderCounter, 1)
-	defer mm_atomic.AddUint64(&mmOrder.afterOrderCounter, 1)
+// CreateService implements IRepository
+func (mmCreateService *IRepositoryMock) CreateService(ctx context.Context, s model.Service) (err error) {
+	mm_atomic.AddUint64(&mmCreateService.beforeCreateServiceCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateService.afterCreateServiceCounter, 1)
 
-	if mmOrder.inspectFuncOrder != nil {
-		mmOrder.inspectFuncOrder(user, order)
+	if mmCreateService.inspectFuncCreateService != nil {
+		mmCreateService.inspectFuncCreateService(ctx, s)
 	}
 
-	mm_params := &IRepositoryMockOrderParams{user, order}
+	mm_params := &IRepositoryMockCreateServiceParams{ctx, s}
 
 	// Record call args
-	mmOrder.OrderMock.mutex.Lock()
-	mmOrder.OrderMock.callArgs = append(mmOrder.OrderMock.callArgs, mm_params)
-	mmOrder.OrderMock.mutex.Unlock()
+	mmCreateService.CreateServiceMock.mutex.Lock()
+	mmCreateService.CreateServiceMock.callArgs = append(mmCreateService.CreateServiceMock.callArgs, mm_params)
+	mmCreateService.CreateServiceMock.mutex.Unlock()
 
-	for _, e := range mmOrder.OrderMock.expectations {
+	for _, e := range mmCreateService.CreateServiceMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
 			return e.results.err
 		}
 	}
 
-	if mmOrder.OrderMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmOrder.OrderMock.defaultExpectation.Counter, 1)
-		mm_want := mmOrder.OrderMock.defaultExpectation.params
-		mm_got := IRepositoryMockOrderParams{user, order}
+	if mmCreateService.CreateServiceMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateService.CreateServiceMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateService.CreateServiceMock.defaultExpectation.params
+		mm_got := IRepositoryMockCreateServiceParams{ctx, s}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmOrder.t.Errorf("IRepositoryMock.Order got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmCreateService.t.Errorf("IRepositoryMock.CreateService got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmOrder.OrderMock.defaultExpectation.results
+		mm_results := mmCreateService.CreateServiceMock.defaultExpectation.results
 		if mm_results == nil {
-			mmOrder.t.Fatal("No results are set for the IRepositoryMock.Order")
+			mmCreateService.t.Fatal("No results are set for the IRepositoryMock.CreateService")
 		}
 		return (*mm_results).err
 	}
-	if mmOrder.funcOrder != nil {
-		return mmOrder.funcOrder(user, order)
+	if mmCreateService.funcCreateService != nil {
+		return mmCreateService.funcCreateService(ctx, s)
 	}
-	mmOrder.t.Fatalf("Unexpected call to IRepositoryMock.Order. %v %v", user, order)
+	mmCreateService.t.Fatalf("Unexpected call to IRepositoryMock.CreateService. %v %v", ctx, s)
 	return
 }
 
-// OrderAfterCounter returns a count of finished IRepositoryMock.Order invocations
-func (mmOrder *IRepositoryMock) OrderAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmOrder.afterOrderCounter)
+// CreateServiceAfterCounter returns a count of finished IRepositoryMock.CreateService invocations
+func (mmCreateService *IRepositoryMock) CreateServiceAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateService.afterCreateServiceCounter)
 }
 
-// OrderBeforeCounter returns a count of IRepositoryMock.Order invocations
-func (mmOrder *IRepositoryMock) OrderBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmOrder.beforeOrderCounter)
+// CreateServiceBeforeCounter returns a count of IRepositoryMock.CreateService invocations
+func (mmCreateService *IRepositoryMock) CreateServiceBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateService.beforeCreateServiceCounter)
 }
 
-// Calls returns a list of arguments used in each call to IRepositoryMock.Order.
+// Calls returns a list of arguments used in each call to IRepositoryMock.CreateService.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmOrder *mIRepositoryMockOrder) Calls() []*IRepositoryMockOrderParams {
-	mmOrder.mutex.RLock()
+func (mmCreateService *mIRepositoryMockCreateService) Calls() []*IRepositoryMockCreateServiceParams {
+	mmCreateService.mutex.RLock()
 
-	argCopy := make([]*IRepositoryMockOrderParams, len(mmOrder.callArgs))
-	copy(argCopy, mmOrder.callArgs)
+	argCopy := make([]*IRepositoryMockCreateServiceParams, len(mmCreateService.callArgs))
+	copy(argCopy, mmCreateService.callArgs)
 
-	mmOrder.mutex.RUnlock()
+	mmCreateService.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockOrderDone returns true if the count of the Order invocations corresponds
+// MinimockCreateServiceDone returns true if the count of the CreateService invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockOrderDone() bool {
-	for _, e := range m.OrderMock.expectations {
+func (m *IRepositoryMock) MinimockCreateServiceDone() bool {
+	for _, e := range m.CreateServiceMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.OrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
+	if m.CreateServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterCreateServiceCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcOrder != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
+	if m.funcCreateService != nil && mm_atomic.LoadUint64(&m.afterCreateServiceCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockOrderInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockOrderInspect() {
-	for _, e := range m.OrderMock.expectations {
+// MinimockCreateServiceInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockCreateServiceInspect() {
+	for _, e := range m.CreateServiceMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.Order with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.CreateService with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.OrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
-		if m.OrderMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.Order")
+	if m.CreateServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterCreateServiceCounter) < 1 {
+		if m.CreateServiceMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.CreateService")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.Order with params: %#v", *m.OrderMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.CreateService with params: %#v", *m.CreateServiceMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcOrder != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.Order")
+	if m.funcCreateService != nil && mm_atomic.LoadUint64(&m.afterCreateServiceCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.CreateService")
 	}
 }
 
-type mIRepositoryMockOrderFailed struct {
+type mIRepositoryMockDeleteIdempotencyKey struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockOrderFailedExpectation
-	expectations       []*IRepositoryMockOrderFailedExpectation
+	defaultExpectation *IRepositoryMockDeleteIdempotencyKeyExpectation
+	expectations       []*IRepositoryMockDeleteIdempotencyKeyExpectation
 
-	callArgs []*IRepositoryMockOrderFailedParams
+	callArgs []*IRepositoryMockDeleteIdempotencyKeyParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockOrderFailedExpectation specifies expectation struct of the IRepository.OrderFailed
-type IRepositoryMockOrderFailedExpectation struct {
+// IRepositoryMockDeleteIdempotencyKeyExpectation specifies expectation struct of the IRepository.DeleteIdempotencyKey
+type IRepositoryMockDeleteIdempotencyKeyExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockOrderFailedParams
-	results *IRepositoryMockOrderFailedResults
+	params  *IRepositoryMockDeleteIdempotencyKeyParams
+	results *IRepositoryMockDeleteIdempotencyKeyResults
 	Counter uint64
 }
 
-// IRepositoryMockOrderFailedParams contains parameters of the IRepository.OrderFailed
-type IRepositoryMockOrderFailedParams struct {
-	user  model.User
-	order model.Order
+// IRepositoryMockDeleteIdempotencyKeyParams contains parameters of the IRepository.DeleteIdempotencyKey
+type IRepositoryMockDeleteIdempotencyKeyParams struct {
+	ctx context.Context
+	key string
 }
 
-// IRepositoryMockOrderFailedResults contains results of the IRepository.OrderFailed
-type IRepositoryMockOrderFailedResults struct {
+// IRepositoryMockDeleteIdempotencyKeyResults contains results of the IRepository.DeleteIdempotencyKey
+type IRepositoryMockDeleteIdempotencyKeyResults struct {
 	err error
 }
 
-// Expect sets up expected params for IRepository.OrderFailed
-func (mmOrderFailed *mIRepositoryMockOrderFailed) Expect(user model.User, order model.Order) *mIRepositoryMockOrderFailed {
-	if mmOrderFailed.mock.funcOrderFailed != nil {
-		mmOrderFailed.mock.t.Fatalf("IRepositoryMock.OrderFailed mock is already set by Set")
+// Expect sets up expected params for IRepository.DeleteIdempotencyKey
+func (mmDeleteIdempotencyKey *mIRepositoryMockDeleteIdempotencyKey) Expect(ctx context.Context, key string) *mIRepositoryMockDeleteIdempotencyKey {
+	if mmDeleteIdempotencyKey.mock.funcDeleteIdempotencyKey != nil {
+		mmDeleteIdempotencyKey.mock.t.Fatalf("IRepositoryMock.DeleteIdempotencyKey mock is already set by Set")
 	}
 
-	if mmOrderFailed.defaultExpectation == nil {
-		mmOrderFailed.defaultExpectation = &IRepositoryMockOrderFailedExpectation{}
+	if mmDeleteIdempotencyKey.defaultExpectation == nil {
+		mmDeleteIdempotencyKey.defaultExpectation = &IRepositoryMockDeleteIdempotencyKeyExpectation{}
 	}
 
-	mmOrderFailed.defaultExpectation.params = &IRepositoryMockOrderFailedParams{user, order}
-	for _, e := range mmOrderFailed.expectations {
-		if minimock.Equal(e.params, mmOrderFailed.defaultExpectation.params) {
-			mmOrderFailed.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmOrderFailed.defaultExpectation.params)
+	mmDeleteIdempotencyKey.defaultExpectation.params = &IRepositoryMockDeleteIdempotencyKeyParams{ctx, key}
+	for _, e := range mmDeleteIdempotencyKey.expectations {
+		if minimock.Equal(e.params, mmDeleteIdempotencyKey.defaultExpectation.params) {
+			mmDeleteIdempotencyKey.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteIdempotencyKey.defaultExpectation.params)
 		}
 	}
 
-	return mmOrderFailed
+	return mmDeleteIdempotencyKey
 }
 
-// Inspect accepts an inspector function that has same arguments as the IRepository.OrderFailed
-func (mmOrderFailed *mIRepositoryMockOrderFailed) Inspect(f func(user model.User, order model.Order)) *mIRepositoryMockOrderFailed {
-	if mmOrderFailed.mock.inspectFuncOrderFailed != nil {
-		mmOrderFailed.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.OrderFailed")
+// Inspect accepts an inspector function that has same arguments as the IRepository.DeleteIdempotencyKey
+func (mmDeleteIdempotencyKey *mIRepositoryMockDeleteIdempotencyKey) Inspect(f func(ctx context.Context, key string)) *mIRepositoryMockDeleteIdempotencyKey {
+	if mmDeleteIdempotencyKey.mock.inspectFuncDeleteIdempotencyKey != nil {
+		mmDeleteIdempotencyKey.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.DeleteIdempotencyKey")
 	}
 
-	mmOrderFailed.mock.inspectFuncOrderFailed = f
+	mmDeleteIdempotencyKey.mock.inspectFuncDeleteIdempotencyKey = f
 
-	return mmOrderFailed
+	return mmDeleteIdempotencyKey
 }
 
-// Return sets up results that will be returned by IRepository.OrderFailed
-func (mmOrderFailed *mIRepositoryMockOrderFailed) Return(err error) *IRepositoryMock {
-	if mmOrderFailed.mock.funcOrderFailed != nil {
-		mmOrderFailed.mock.t.Fatalf("IRepositoryMock.OrderFailed mock is already set by Set")
+// Return sets up results that will be returned by IRepository.DeleteIdempotencyKey
+func (mmDeleteIdempotencyKey *mIRepositoryMockDeleteIdempotencyKey) Return(err error) *IRepositoryMock {
+	if mmDeleteIdempotencyKey.mock.funcDeleteIdempotencyKey != nil {
+		mmDeleteIdempotencyKey.mock.t.Fatalf("IRepositoryMock.DeleteIdempotencyKey mock is already set by Set")
 	}
 
-	if mmOrderFailed.defaultExpectation == nil {
-		mmOrderFailed.defaultExpectation = &IRepositoryMockOrderFailedExpectation{mock: mmOrderFailed.mock}
+	if mmDeleteIdempotencyKey.defaultExpectation == nil {
+		mmDeleteIdempotencyKey.defaultExpectation = &IRepositoryMockDeleteIdempotencyKeyExpectation{mock: mmDeleteIdempotencyKey.mock}
 	}
-	mmOrderFailed.defaultExpectation.results = &IRepositoryMockOrderFailedResults{err}
-	return mmOrderFailed.mock
+	mmDeleteIdempotencyKey.defaultExpectation.results = &IRepositoryMockDeleteIdempotencyKeyResults{err}
+	return mmDeleteIdempotencyKey.mock
 }
 
-//Set uses given function f to mock the IRepository.OrderFailed method
-func (mmOrderFailed *mIRepositoryMockOrderFailed) Set(f func(user model.User, order model.Order) (err error)) *IRepositoryMock {
-	if mmOrderFailed.defaultExpectation != nil {
-		mmOrderFailed.mock.t.Fatalf("Default expectation is already set for the IRepository.OrderFailed method")
+// Set uses given function f to mock the IRepository.DeleteIdempotencyKey method
+func (mmDeleteIdempotencyKey *mIRepositoryMockDeleteIdempotencyKey) Set(f func(ctx context.Context, key string) (err error)) *IRepositoryMock {
+	if mmDeleteIdempotencyKey.defaultExpectation != nil {
+		mmDeleteIdempotencyKey.mock.t.Fatalf("Default expectation is already set for the IRepository.DeleteIdempotencyKey method")
 	}
 
-	if len(mmOrderFailed.expectations) > 0 {
-		mmOrderFailed.mock.t.Fatalf("Some expectations are already set for the IRepository.OrderFailed method")
+	if len(mmDeleteIdempotencyKey.expectations) > 0 {
+		mmDeleteIdempotencyKey.mock.t.Fatalf("Some expectations are already set for the IRepository.DeleteIdempotencyKey method")
 	}
 
-	mmOrderFailed.mock.funcOrderFailed = f
-	return mmOrderFailed.mock
+	mmDeleteIdempotencyKey.mock.funcDeleteIdempotencyKey = f
+	return mmDeleteIdempotencyKey.mock
 }
 
-// When sets expectation for the IRepository.OrderFailed which will trigger the result defined by the following
+// When sets expectation for the IRepository.DeleteIdempotencyKey which will trigger the result defined by the following
 // Then helper
-func (mmOrderFailed *mIRepositoryMockOrderFailed) When(user model.User, order model.Order) *IRepositoryMockOrderFailedExpectation {
-	if mmOrderFailed.mock.funcOrderFailed != nil {
-		mmOrderFailed.mock.t.Fatalf("IRepositoryMock.OrderFailed mock is already set by Set")
+func (mmDeleteIdempotencyKey *mIRepositoryMockDeleteIdempotencyKey) When(ctx context.Context, key string) *IRepositoryMockDeleteIdempotencyKeyExpectation {
+	if mmDeleteIdempotencyKey.mock.funcDeleteIdempotencyKey != nil {
+		mmDeleteIdempotencyKey.mock.t.Fatalf("IRepositoryMock.DeleteIdempotencyKey mock is already set by Set")
 	}
 
-	expectation := &IRepositoryMockOrderFailedExpectation{
-		mock:   mmOrderFailed.mock,
-		params: &IRepositoryMockOrderFailedParams{user, order},
+	expectation := &IRepositoryMockDeleteIdempotencyKeyExpectation{
+		mock:   mmDeleteIdempotencyKey.mock,
+		params: &IRepositoryMockDeleteIdempotencyKeyParams{ctx, key},
 	}
-	mmOrderFailed.expectations = append(mmOrderFailed.expectations, expectation)
+	mmDeleteIdempotencyKey.expectations = append(mmDeleteIdempotencyKey.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IRepository.OrderFailed return parameters for the expectation previously defined by the When method
-func (e *IRepositoryMockOrderFailedExpectation) Then(err error) *IRepositoryMock {
-	e.results = &IRepositoryMockOrderFailedResults{err}
+// Then sets up IRepository.DeleteIdempotencyKey return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockDeleteIdempotencyKeyExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockDeleteIdempotencyKeyResults{err}
 	return e.mock
 }
 
-// OrderFailed implements IRepository
-func (mmOrderFailed *IRepositoryMock) OrderFailed(user model.User, order model.Order) (err error) {
-	mm_atomic.AddUint64(&mmOrderFailed.beforeOrderFailedCounter, 1)
-	defer mm_atomic.AddUint64(&mmOrderFailed.afterOrderFailedCounter, 1)
+// DeleteIdempotencyKey implements IRepository
+func (mmDeleteIdempotencyKey *IRepositoryMock) DeleteIdempotencyKey(ctx context.Context, key string) (err error) {
+	mm_atomic.AddUint64(&mmDeleteIdempotencyKey.beforeDeleteIdempotencyKeyCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteIdempotencyKey.afterDeleteIdempotencyKeyCounter, 1)
 
-	if mmOrderFailed.inspectFuncOrderFailed != nil {
-		mmOrderFailed.inspectFuncOrderFailed(user, order)
+	if mmDeleteIdempotencyKey.inspectFuncDeleteIdempotencyKey != nil {
+		mmDeleteIdempotencyKey.inspectFuncDeleteIdempotencyKey(ctx, key)
 	}
 
-	mm_params := &IRepositoryMockOrderFailedParams{user, order}
+	mm_params := &IRepositoryMockDeleteIdempotencyKeyParams{ctx, key}
 
 	// Record call args
-	mmOrderFailed.OrderFailedMock.mutex.Lock()
-	mmOrderFailed.OrderFailedMock.callArgs = append(mmOrderFailed.OrderFailedMock.callArgs, mm_params)
-	mmOrderFailed.OrderFailedMock.mutex.Unlock()
+	mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.mutex.Lock()
+	mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.callArgs = append(mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.callArgs, mm_params)
+	mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.mutex.Unlock()
 
-	for _, e := range mmOrderFailed.OrderFailedMock.expectations {
+	for _, e := range mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
 			return e.results.err
 		}
 	}
 
-	if mmOrderFailed.OrderFailedMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmOrderFailed.OrderFailedMock.defaultExpectation.Counter, 1)
-		mm_want := mmOrderFailed.OrderFailedMock.defaultExpectation.params
-		mm_got := IRepositoryMockOrderFailedParams{user, order}
+	if mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.defaultExpectation.params
+		mm_got := IRepositoryMockDeleteIdempotencyKeyParams{ctx, key}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmOrderFailed.t.Errorf("IRepositoryMock.OrderFailed got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmDeleteIdempotencyKey.t.Errorf("IRepositoryMock.DeleteIdempotencyKey got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmOrderFailed.OrderFailedMock.defaultExpectation.results
+		mm_results := mmDeleteIdempotencyKey.DeleteIdempotencyKeyMock.defaultExpectation.results
 		if mm_results == nil {
-			mmOrderFailed.t.Fatal("No results are set for the IRepositoryMock.OrderFailed")
+			mmDeleteIdempotencyKey.t.Fatal("No results are set for the IRepositoryMock.DeleteIdempotencyKey")
 		}
 		return (*mm_results).err
 	}
-	if mmOrderFailed.funcOrderFailed != nil {
-		return mmOrderFailed.funcOrderFailed(user, order)
+	if mmDeleteIdempotencyKey.funcDeleteIdempotencyKey != nil {
+		return mmDeleteIdempotencyKey.funcDeleteIdempotencyKey(ctx, key)
 	}
-	mmOrderFailed.t.Fatalf("Unexpected call to IRepositoryMock.OrderFailed. %v %v", user, order)
+	mmDeleteIdempotencyKey.t.Fatalf("Unexpected call to IRepositoryMock.DeleteIdempotencyKey. %v %v", ctx, key)
 	return
 }
 
-// OrderFailedAfterCounter returns a count of finished IRepositoryMock.OrderFailed invocations
-func (mmOrderFailed *IRepositoryMock) OrderFailedAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmOrderFailed.afterOrderFailedCounter)
+// DeleteIdempotencyKeyAfterCounter returns a count of finished IRepositoryMock.DeleteIdempotencyKey invocations
+func (mmDeleteIdempotencyKey *IRepositoryMock) DeleteIdempotencyKeyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteIdempotencyKey.afterDeleteIdempotencyKeyCounter)
 }
 
-// OrderFailedBeforeCounter returns a count of IRepositoryMock.OrderFailed invocations
-func (mmOrderFailed *IRepositoryMock) OrderFailedBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmOrderFailed.beforeOrderFailedCounter)
+// DeleteIdempotencyKeyBeforeCounter returns a count of IRepositoryMock.DeleteIdempotencyKey invocations
+func (mmDeleteIdempotencyKey *IRepositoryMock) DeleteIdempotencyKeyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteIdempotencyKey.beforeDeleteIdempotencyKeyCounter)
 }
 
-// Calls returns a list of arguments used in each call to IRepositoryMock.OrderFailed.
+// Calls returns a list of arguments used in each call to IRepositoryMock.DeleteIdempotencyKey.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmOrderFailed *mIRepositoryMockOrderFailed) Calls() []*IRepositoryMockOrderFailedParams {
-	mmOrderFailed.mutex.RLock()
+func (mmDeleteIdempotencyKey *mIRepositoryMockDeleteIdempotencyKey) Calls() []*IRepositoryMockDeleteIdempotencyKeyParams {
+	mmDeleteIdempotencyKey.mutex.RLock()
 
-	argCopy := make([]*IRepositoryMockOrderFailedParams, len(mmOrderFailed.callArgs))
-	copy(argCopy, mmOrderFailed.callArgs)
+	argCopy := make([]*IRepositoryMockDeleteIdempotencyKeyParams, len(mmDeleteIdempotencyKey.callArgs))
+	copy(argCopy, mmDeleteIdempotencyKey.callArgs)
 
-	mmOrderFailed.mutex.RUnlock()
+	mmDeleteIdempotencyKey.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockOrderFailedDone returns true if the count of the OrderFailed invocations corresponds
+// MinimockDeleteIdempotencyKeyDone returns true if the count of the DeleteIdempotencyKey invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockOrderFailedDone() bool {
-	for _, e := range m.OrderFailedMock.expectations {
+func (m *IRepositoryMock) MinimockDeleteIdempotencyKeyDone() bool {
+	for _, e := range m.DeleteIdempotencyKeyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.OrderFailedMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderFailedCounter) < 1 {
+	if m.DeleteIdempotencyKeyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeyCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcOrderFailed != nil && mm_atomic.LoadUint64(&m.afterOrderFailedCounter) < 1 {
+	if m.funcDeleteIdempotencyKey != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeyCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockOrderFailedInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockOrderFailedInspect() {
-	for _, e := range m.OrderFailedMock.expectations {
+// MinimockDeleteIdempotencyKeyInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockDeleteIdempotencyKeyInspect() {
+	for _, e := range m.DeleteIdempotencyKeyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.OrderFailed with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteIdempotencyKey with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.OrderFailedMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderFailedCounter) < 1 {
-		if m.OrderFailedMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.OrderFailed")
+	if m.DeleteIdempotencyKeyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeyCounter) < 1 {
+		if m.DeleteIdempotencyKeyMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.DeleteIdempotencyKey")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.OrderFailed with params: %#v", *m.OrderFailedMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteIdempotencyKey with params: %#v", *m.DeleteIdempotencyKeyMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcOrderFailed != nil && mm_atomic.LoadUint64(&m.afterOrderFailedCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.OrderFailed")
+	if m.funcDeleteIdempotencyKey != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeyCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.DeleteIdempotencyKey")
 	}
 }
 
-type mIRepositoryMockOrderSuccess struct {
+type mIRepositoryMockDeleteIdempotencyKeys struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockOrderSuccessExpectation
-	expectations       []*IRepositoryMockOrderSuccessExpectation
+	defaultExpectation *IRepositoryMockDeleteIdempotencyKeysExpectation
+	expectations       []*IRepositoryMockDeleteIdempotencyKeysExpectation
 
-	callArgs []*IRepositoryMockOrderSuccessParams
+	callArgs []*IRepositoryMockDeleteIdempotencyKeysParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockOrderSuccessExpectation specifies expectation struct of the IRepository.OrderSuccess
-type IRepositoryMockOrderSuccessExpectation struct {
+// IRepositoryMockDeleteIdempotencyKeysExpectation specifies expectation struct of the IRepository.DeleteIdempotencyKeys
+type IRepositoryMockDeleteIdempotencyKeysExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockOrderSuccessParams
-	results *IRepositoryMockOrderSuccessResults
+	params  *IRepositoryMockDeleteIdempotencyKeysParams
+	results *IRepositoryMockDeleteIdempotencyKeysResults
 	Counter uint64
 }
 
-// IRepositoryMockOrderSuccessParams contains parameters of the IRepository.OrderSuccess
-type IRepositoryMockOrderSuccessParams struct {
-	order model.Order
+// IRepositoryMockDeleteIdempotencyKeysParams contains parameters of the IRepository.DeleteIdempotencyKeys
+type IRepositoryMockDeleteIdempotencyKeysParams struct {
+	ctx    context.Context
+	before time.Time
 }
 
-// IRepositoryMockOrderSuccessResults contains results of the IRepository.OrderSuccess
-type IRepositoryMockOrderSuccessResults struct {
+// IRepositoryMockDeleteIdempotencyKeysResults contains results of the IRepository.DeleteIdempotencyKeys
+type IRepositoryMockDeleteIdempotencyKeysResults struct {
+	i1  int
 	err error
 }
 
-// Expect sets up expected params for IRepository.OrderSuccess
-func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Expect(order model.Order) *mIRepositoryMockOrderSuccess {
-	if mmOrderSuccess.mock.funcOrderSuccess != nil {
-		mmOrderSuccess.mock.t.Fatalf("IRepositoryMock.OrderSuccess mock is already set by Set")
+// Expect sets up expected params for IRepository.DeleteIdempotencyKeys
+func (mmDeleteIdempotencyKeys *mIRepositoryMockDeleteIdempotencyKeys) Expect(ctx context.Context, before time.Time) *mIRepositoryMockDeleteIdempotencyKeys {
+	if mmDeleteIdempotencyKeys.mock.funcDeleteIdempotencyKeys != nil {
+		mmDeleteIdempotencyKeys.mock.t.Fatalf("IRepositoryMock.DeleteIdempotencyKeys mock is already set by Set")
 	}
 
-	if mmOrderSuccess.defaultExpectation == nil {
-		mmOrderSuccess.defaultExpectation = &IRepositoryMockOrderSuccessExpectation{}
+	if mmDeleteIdempotencyKeys.defaultExpectation == nil {
+		mmDeleteIdempotencyKeys.defaultExpectation = &IRepositoryMockDeleteIdempotencyKeysExpectation{}
 	}
 
-	mmOrderSuccess.defaultExpectation.params = &IRepositoryMockOrderSuccessParams{order}
-	for _, e := range mmOrderSuccess.expectations {
-		if minimock.Equal(e.params, mmOrderSuccess.defaultExpectation.params) {
-			mmOrderSuccess.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmOrderSuccess.defaultExpectation.params)
+	mmDeleteIdempotencyKeys.defaultExpectation.params = &IRepositoryMockDeleteIdempotencyKeysParams{ctx, before}
+	for _, e := range mmDeleteIdempotencyKeys.expectations {
+		if minimock.Equal(e.params, mmDeleteIdempotencyKeys.defaultExpectation.params) {
+			mmDeleteIdempotencyKeys.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteIdempotencyKeys.defaultExpectation.params)
 		}
 	}
 
-	return mmOrderSuccess
+	return mmDeleteIdempotencyKeys
 }
 
-// Inspect accepts an inspector function that has same arguments as the IRepository.OrderSuccess
-func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Inspect(f func(order model.Order)) *mIRepositoryMockOrderSuccess {
-	if mmOrderSuccess.mock.inspectFuncOrderSuccess != nil {
-		mmOrderSuccess.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.OrderSuccess")
+// Inspect accepts an inspector function that has same arguments as the IRepository.DeleteIdempotencyKeys
+func (mmDeleteIdempotencyKeys *mIRepositoryMockDeleteIdempotencyKeys) Inspect(f func(ctx context.Context, before time.Time)) *mIRepositoryMockDeleteIdempotencyKeys {
+	if mmDeleteIdempotencyKeys.mock.inspectFuncDeleteIdempotencyKeys != nil {
+		mmDeleteIdempotencyKeys.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.DeleteIdempotencyKeys")
 	}
 
-	mmOrderSuccess.mock.inspectFuncOrderSuccess = f
+	mmDeleteIdempotencyKeys.mock.inspectFuncDeleteIdempotencyKeys = f
 
-	return mmOrderSuccess
+	return mmDeleteIdempotencyKeys
 }
 
-// Return sets up results that will be returned by IRepository.OrderSuccess
-func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Return(err error) *IRepositoryMock {
-	if mmOrderSuccess.mock.funcOrderSuccess != nil {
-		mmOrderSuccess.mock.t.Fatalf("IRepositoryMock.OrderSuccess mock is already set by Set")
+// Return sets up results that will be returned by IRepository.DeleteIdempotencyKeys
+func (mmDeleteIdempotencyKeys *mIRepositoryMockDeleteIdempotencyKeys) Return(i1 int, err error) *IRepositoryMock {
+	if mmDeleteIdempotencyKeys.mock.funcDeleteIdempotencyKeys != nil {
+		mmDeleteIdempotencyKeys.mock.t.Fatalf("IRepositoryMock.DeleteIdempotencyKeys mock is already set by Set")
 	}
 
-	if mmOrderSuccess.defaultExpectation == nil {
-		mmOrderSuccess.defaultExpectation = &IRepositoryMockOrderSuccessExpectation{mock: mmOrderSuccess.mock}
+	if mmDeleteIdempotencyKeys.defaultExpectation == nil {
+		mmDeleteIdempotencyKeys.defaultExpectation = &IRepositoryMockDeleteIdempotencyKeysExpectation{mock: mmDeleteIdempotencyKeys.mock}
 	}
-	mmOrderSuccess.defaultExpectation.results = &IRepositoryMockOrderSuccessResults{err}
-	return mmOrderSuccess.mock
+	mmDeleteIdempotencyKeys.defaultExpectation.results = &IRepositoryMockDeleteIdempotencyKeysResults{i1, err}
+	return mmDeleteIdempotencyKeys.mock
 }
 
-//Set uses given function f to mock the IRepository.OrderSuccess method
-func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Set(f func(order model.Order) (err error)) *IRepositoryMock {
-	if mmOrderSuccess.defaultExpectation != nil {
-		mmOrderSuccess.mock.t.Fatalf("Default expectation is already set for the IRepository.OrderSuccess method")
+// Set uses given function f to mock the IRepository.DeleteIdempotencyKeys method
+func (mmDeleteIdempotencyKeys *mIRepositoryMockDeleteIdempotencyKeys) Set(f func(ctx context.Context, before time.Time) (i1 int, err error)) *IRepositoryMock {
+	if mmDeleteIdempotencyKeys.defaultExpectation != nil {
+		mmDeleteIdempotencyKeys.mock.t.Fatalf("Default expectation is already set for the IRepository.DeleteIdempotencyKeys method")
 	}
 
-	if len(mmOrderSuccess.expectations) > 0 {
-		mmOrderSuccess.mock.t.Fatalf("Some expectations are already set for the IRepository.OrderSuccess method")
+	if len(mmDeleteIdempotencyKeys.expectations) > 0 {
+		mmDeleteIdempotencyKeys.mock.t.Fatalf("Some expectations are already set for the IRepository.DeleteIdempotencyKeys method")
 	}
 
-	mmOrderSuccess.mock.funcOrderSuccess = f
-	return mmOrderSuccess.mock
+	mmDeleteIdempotencyKeys.mock.funcDeleteIdempotencyKeys = f
+	return mmDeleteIdempotencyKeys.mock
 }
 
-// When sets expectation for the IRepository.OrderSuccess which will trigger the result defined by the following
+// When sets expectation for the IRepository.DeleteIdempotencyKeys which will trigger the result defined by the following
 // Then helper
-func (mmOrderSuccess *mIRepositoryMockOrderSuccess) When(order model.Order) *IRepositoryMockOrderSuccessExpectation {
-	if mmOrderSuccess.mock.funcOrderSuccess != nil {
-		mmOrderSuccess.mock.t.Fatalf("IRepositoryMock.OrderSuccess mock is already set by Set")
+func (mmDeleteIdempotencyKeys *mIRepositoryMockDeleteIdempotencyKeys) When(ctx context.Context, before time.Time) *IRepositoryMockDeleteIdempotencyKeysExpectation {
+	if mmDeleteIdempotencyKeys.mock.funcDeleteIdempotencyKeys != nil {
+		mmDeleteIdempotencyKeys.mock.t.Fatalf("IRepositoryMock.DeleteIdempotencyKeys mock is already set by Set")
 	}
 
-	expectation := &IRepositoryMockOrderSuccessExpectation{
-		mock:   mmOrderSuccess.mock,
-		params: &IRepositoryMockOrderSuccessParams{order},
+	expectation := &IRepositoryMockDeleteIdempotencyKeysExpectation{
+		mock:   mmDeleteIdempotencyKeys.mock,
+		params: &IRepositoryMockDeleteIdempotencyKeysParams{ctx, before},
 	}
-	mmOrderSuccess.expectations = append(mmOrderSuccess.expectations, expectation)
+	mmDeleteIdempotencyKeys.expectations = append(mmDeleteIdempotencyKeys.expectations, expectation)
 	return expectation
 }
 
-// Then sets up IRepository.OrderSuccess return parameters for the expectation previously defined by the When method
-func (e *IRepositoryMockOrderSuccessExpectation) Then(err error) *IRepositoryMock {
-	e.results = &IRepositoryMockOrderSuccessResults{err}
+// Then sets up IRepository.DeleteIdempotencyKeys return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockDeleteIdempotencyKeysExpectation) Then(i1 int, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockDeleteIdempotencyKeysResults{i1, err}
 	return e.mock
 }
 
-// OrderSuccess implements IRepository
-func (mmOrderSuccess *IRepositoryMock) OrderSuccess(order model.Order) (err error) {
-	mm_atomic.AddUint64(&mmOrderSuccess.beforeOrderSuccessCounter, 1)
-	defer mm_atomic.AddUint64(&mmOrderSuccess.afterOrderSuccessCounter, 1)
+// DeleteIdempotencyKeys implements IRepository
+func (mmDeleteIdempotencyKeys *IRepositoryMock) DeleteIdempotencyKeys(ctx context.Context, before time.Time) (i1 int, err error) {
+	mm_atomic.AddUint64(&mmDeleteIdempotencyKeys.beforeDeleteIdempotencyKeysCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteIdempotencyKeys.afterDeleteIdempotencyKeysCounter, 1)
 
-	if mmOrderSuccess.inspectFuncOrderSuccess != nil {
-		mmOrderSuccess.inspectFuncOrderSuccess(order)
+	if mmDeleteIdempotencyKeys.inspectFuncDeleteIdempotencyKeys != nil {
+		mmDeleteIdempotencyKeys.inspectFuncDeleteIdempotencyKeys(ctx, before)
 	}
 
-	mm_params := &IRepositoryMockOrderSuccessParams{order}
+	mm_params := &IRepositoryMockDeleteIdempotencyKeysParams{ctx, before}
 
 	// Record call args
-	mmOrderSuccess.OrderSuccessMock.mutex.Lock()
-	mmOrderSuccess.OrderSuccessMock.callArgs = append(mmOrderSuccess.OrderSuccessMock.callArgs, mm_params)
-	mmOrderSuccess.OrderSuccessMock.mutex.Unlock()
+	mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.mutex.Lock()
+	mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.callArgs = append(mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.callArgs, mm_params)
+	mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.mutex.Unlock()
 
-	for _, e := range mmOrderSuccess.OrderSuccessMock.expectations {
+	for _, e := range mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.expectations {
 		if minimock.Equal(e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.i1, e.results.err
 		}
 	}
 
-	if mmOrderSuccess.OrderSuccessMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmOrderSuccess.OrderSuccessMock.defaultExpectation.Counter, 1)
-		mm_want := mmOrderSuccess.OrderSuccessMock.defaultExpectation.params
-		mm_got := IRepositoryMockOrderSuccessParams{order}
+	if mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.defaultExpectation.params
+		mm_got := IRepositoryMockDeleteIdempotencyKeysParams{ctx, before}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmOrderSuccess.t.Errorf("IRepositoryMock.OrderSuccess got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmDeleteIdempotencyKeys.t.Errorf("IRepositoryMock.DeleteIdempotencyKeys got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmOrderSuccess.OrderSuccessMock.defaultExpectation.results
+		mm_results := mmDeleteIdempotencyKeys.DeleteIdempotencyKeysMock.defaultExpectation.results
 		if mm_results == nil {
-			mmOrderSuccess.t.Fatal("No results are set for the IRepositoryMock.OrderSuccess")
+			mmDeleteIdempotencyKeys.t.Fatal("No results are set for the IRepositoryMock.DeleteIdempotencyKeys")
 		}
-		return (*mm_results).err
+		return (*mm_results).i1, (*mm_results).err
 	}
-	if mmOrderSuccess.funcOrderSuccess != nil {
-		return mmOrderSuccess.funcOrderSuccess(order)
+	if mmDeleteIdempotencyKeys.funcDeleteIdempotencyKeys != nil {
+		return mmDeleteIdempotencyKeys.funcDeleteIdempotencyKeys(ctx, before)
 	}
-	mmOrderSuccess.t.Fatalf("Unexpected call to IRepositoryMock.OrderSuccess. %v", order)
+	mmDeleteIdempotencyKeys.t.Fatalf("Unexpected call to IRepositoryMock.DeleteIdempotencyKeys. %v %v", ctx, before)
 	return
 }
 
-// OrderSuccessAfterCounter returns a count of finished IRepositoryMock.OrderSuccess invocations
-func (mmOrderSuccess *IRepositoryMock) OrderSuccessAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmOrderSuccess.afterOrderSuccessCounter)
+// DeleteIdempotencyKeysAfterCounter returns a count of finished IRepositoryMock.DeleteIdempotencyKeys invocations
+func (mmDeleteIdempotencyKeys *IRepositoryMock) DeleteIdempotencyKeysAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteIdempotencyKeys.afterDeleteIdempotencyKeysCounter)
 }
 
-// OrderSuccessBeforeCounter returns a count of IRepositoryMock.OrderSuccess invocations
-func (mmOrderSuccess *IRepositoryMock) OrderSuccessBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmOrderSuccess.beforeOrderSuccessCounter)
+// DeleteIdempotencyKeysBeforeCounter returns a count of IRepositoryMock.DeleteIdempotencyKeys invocations
+func (mmDeleteIdempotencyKeys *IRepositoryMock) DeleteIdempotencyKeysBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteIdempotencyKeys.beforeDeleteIdempotencyKeysCounter)
 }
 
-// Calls returns a list of arguments used in each call to IRepositoryMock.OrderSuccess.
+// Calls returns a list of arguments used in each call to IRepositoryMock.DeleteIdempotencyKeys.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Calls() []*IRepositoryMockOrderSuccessParams {
-	mmOrderSuccess.mutex.RLock()
+func (mmDeleteIdempotencyKeys *mIRepositoryMockDeleteIdempotencyKeys) Calls() []*IRepositoryMockDeleteIdempotencyKeysParams {
+	mmDeleteIdempotencyKeys.mutex.RLock()
 
-	argCopy := make([]*IRepositoryMockOrderSuccessParams, len(mmOrderSuccess.callArgs))
-	copy(argCopy, mmOrderSuccess.callArgs)
+	argCopy := make([]*IRepositoryMockDeleteIdempotencyKeysParams, len(mmDeleteIdempotencyKeys.callArgs))
+	copy(argCopy, mmDeleteIdempotencyKeys.callArgs)
 
-	mmOrderSuccess.mutex.RUnlock()
+	mmDeleteIdempotencyKeys.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockOrderSuccessDone returns true if the count of the OrderSuccess invocations corresponds
+// MinimockDeleteIdempotencyKeysDone returns true if the count of the DeleteIdempotencyKeys invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockOrderSuccessDone() bool {
-	for _, e := range m.OrderSuccessMock.expectations {
+func (m *IRepositoryMock) MinimockDeleteIdempotencyKeysDone() bool {
+	for _, e := range m.DeleteIdempotencyKeysMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.OrderSuccessMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
+	if m.DeleteIdempotencyKeysMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeysCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcOrderSuccess != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
+	if m.funcDeleteIdempotencyKeys != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeysCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockOrderSuccessInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockOrderSuccessInspect() {
-	for _, e := range m.OrderSuccessMock.expectations {
+// MinimockDeleteIdempotencyKeysInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockDeleteIdempotencyKeysInspect() {
+	for _, e := range m.DeleteIdempotencyKeysMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.OrderSuccess with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteIdempotencyKeys with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.OrderSuccessMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
-		if m.OrderSuccessMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.OrderSuccess")
+	if m.DeleteIdempotencyKeysMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeysCounter) < 1 {
+		if m.DeleteIdempotencyKeysMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.DeleteIdempotencyKeys")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.OrderSuccess with params: %#v", *m.OrderSuccessMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteIdempotencyKeys with params: %#v", *m.DeleteIdempotencyKeysMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcOrderSuccess != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.OrderSuccess")
+	if m.funcDeleteIdempotencyKeys != nil && mm_atomic.LoadUint64(&m.afterDeleteIdempotencyKeysCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.DeleteIdempotencyKeys")
 	}
 }
 
-type mIRepositoryMockReport struct {
+type mIRepositoryMockDeleteReportJobs struct {
 	mock               *IRepositoryMock
-	defaultExpectation *IRepositoryMockReportExpectation
-	expectations       []*IRepositoryMockReportExpectation
+	defaultExpectation *IRepositoryMockDeleteReportJobsExpectation
+	expectations       []*IRepositoryMockDeleteReportJobsExpectation
 
-	callArgs []*IRepositoryMockReportParams
+	callArgs []*IRepositoryMockDeleteReportJobsParams
 	mutex    sync.RWMutex
 }
 
-// IRepositoryMockReportExpectation specifies expectation struct of the IRepository.Report
-type IRepositoryMockReportExpectation struct {
+// IRepositoryMockDeleteReportJobsExpectation specifies expectation struct of the IRepository.DeleteReportJobs
+type IRepositoryMockDeleteReportJobsExpectation struct {
 	mock    *IRepositoryMock
-	params  *IRepositoryMockReportParams
-	results *IRepositoryMockReportResults
+	params  *IRepositoryMockDeleteReportJobsParams
+	results *IRepositoryMockDeleteReportJobsResults
 	Counter uint64
 }
 
-// IRepositoryMockReportParams contains parameters of the IRepository.Report
-type IRepositoryMockReportParams struct {
-	t1 time.Time
+// IRepositoryMockDeleteReportJobsParams contains parameters of the IRepository.DeleteReportJobs
+type IRepositoryMockDeleteReportJobsParams struct {
+	ctx    context.Context
+	before time.Time
 }
 
-// IRepositoryMockReportResults contains results of the IRepository.Report
-type IRepositoryMockReportResults struct {
-	ra1 []model.Report
+// IRepositoryMockDeleteReportJobsResults contains results of the IRepository.DeleteReportJobs
+type IRepositoryMockDeleteReportJobsResults struct {
+	i1  int
 	err error
 }
 
-// Expect sets up expected params for IRepository.Report
-func (mmReport *mIRepositoryMockReport) Expect(t1 time.Time) *mIRepositoryMockReport {
-	if mmReport.mock.funcReport != nil {
+// Expect sets up expected params for IRepository.DeleteReportJobs
+func (mmDeleteReportJobs *mIRepositoryMockDeleteReportJobs) Expect(ctx context.Context, before time.Time) *mIRepositoryMockDeleteReportJobs {
+	if mmDeleteReportJobs.mock.funcDeleteReportJobs != nil {
+		mmDeleteReportJobs.mock.t.Fatalf("IRepositoryMock.DeleteReportJobs mock is already set by Set")
+	}
+
+	if mmDeleteReportJobs.defaultExpectation == nil {
+		mmDeleteReportJobs.defaultExpectation = &IRepositoryMockDeleteReportJobsExpectation{}
+	}
+
+	mmDeleteReportJobs.defaultExpectation.params = &IRepositoryMockDeleteReportJobsParams{ctx, before}
+	for _, e := range mmDeleteReportJobs.expectations {
+		if minimock.Equal(e.params, mmDeleteReportJobs.defaultExpectation.params) {
+			mmDeleteReportJobs.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteReportJobs.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteReportJobs
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.DeleteReportJobs
+func (mmDeleteReportJobs *mIRepositoryMockDeleteReportJobs) Inspect(f func(ctx context.Context, before time.Time)) *mIRepositoryMockDeleteReportJobs {
+	if mmDeleteReportJobs.mock.inspectFuncDeleteReportJobs != nil {
+		mmDeleteReportJobs.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.DeleteReportJobs")
+	}
+
+	mmDeleteReportJobs.mock.inspectFuncDeleteReportJobs = f
+
+	return mmDeleteReportJobs
+}
+
+// Return sets up results that will be returned by IRepository.DeleteReportJobs
+func (mmDeleteReportJobs *mIRepositoryMockDeleteReportJobs) Return(i1 int, err error) *IRepositoryMock {
+	if mmDeleteReportJobs.mock.funcDeleteReportJobs != nil {
+		mmDeleteReportJobs.mock.t.Fatalf("IRepositoryMock.DeleteReportJobs mock is already set by Set")
+	}
+
+	if mmDeleteReportJobs.defaultExpectation == nil {
+		mmDeleteReportJobs.defaultExpectation = &IRepositoryMockDeleteReportJobsExpectation{mock: mmDeleteReportJobs.mock}
+	}
+	mmDeleteReportJobs.defaultExpectation.results = &IRepositoryMockDeleteReportJobsResults{i1, err}
+	return mmDeleteReportJobs.mock
+}
+
+// Set uses given function f to mock the IRepository.DeleteReportJobs method
+func (mmDeleteReportJobs *mIRepositoryMockDeleteReportJobs) Set(f func(ctx context.Context, before time.Time) (i1 int, err error)) *IRepositoryMock {
+	if mmDeleteReportJobs.defaultExpectation != nil {
+		mmDeleteReportJobs.mock.t.Fatalf("Default expectation is already set for the IRepository.DeleteReportJobs method")
+	}
+
+	if len(mmDeleteReportJobs.expectations) > 0 {
+		mmDeleteReportJobs.mock.t.Fatalf("Some expectations are already set for the IRepository.DeleteReportJobs method")
+	}
+
+	mmDeleteReportJobs.mock.funcDeleteReportJobs = f
+	return mmDeleteReportJobs.mock
+}
+
+// When sets expectation for the IRepository.DeleteReportJobs which will trigger the result defined by the following
+// Then helper
+func (mmDeleteReportJobs *mIRepositoryMockDeleteReportJobs) When(ctx context.Context, before time.Time) *IRepositoryMockDeleteReportJobsExpectation {
+	if mmDeleteReportJobs.mock.funcDeleteReportJobs != nil {
+		mmDeleteReportJobs.mock.t.Fatalf("IRepositoryMock.DeleteReportJobs mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockDeleteReportJobsExpectation{
+		mock:   mmDeleteReportJobs.mock,
+		params: &IRepositoryMockDeleteReportJobsParams{ctx, before},
+	}
+	mmDeleteReportJobs.expectations = append(mmDeleteReportJobs.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.DeleteReportJobs return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockDeleteReportJobsExpectation) Then(i1 int, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockDeleteReportJobsResults{i1, err}
+	return e.mock
+}
+
+// DeleteReportJobs implements IRepository
+func (mmDeleteReportJobs *IRepositoryMock) DeleteReportJobs(ctx context.Context, before time.Time) (i1 int, err error) {
+	mm_atomic.AddUint64(&mmDeleteReportJobs.beforeDeleteReportJobsCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteReportJobs.afterDeleteReportJobsCounter, 1)
+
+	if mmDeleteReportJobs.inspectFuncDeleteReportJobs != nil {
+		mmDeleteReportJobs.inspectFuncDeleteReportJobs(ctx, before)
+	}
+
+	mm_params := &IRepositoryMockDeleteReportJobsParams{ctx, before}
+
+	// Record call args
+	mmDeleteReportJobs.DeleteReportJobsMock.mutex.Lock()
+	mmDeleteReportJobs.DeleteReportJobsMock.callArgs = append(mmDeleteReportJobs.DeleteReportJobsMock.callArgs, mm_params)
+	mmDeleteReportJobs.DeleteReportJobsMock.mutex.Unlock()
+
+	for _, e := range mmDeleteReportJobs.DeleteReportJobsMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmDeleteReportJobs.DeleteReportJobsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteReportJobs.DeleteReportJobsMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteReportJobs.DeleteReportJobsMock.defaultExpectation.params
+		mm_got := IRepositoryMockDeleteReportJobsParams{ctx, before}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteReportJobs.t.Errorf("IRepositoryMock.DeleteReportJobs got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteReportJobs.DeleteReportJobsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteReportJobs.t.Fatal("No results are set for the IRepositoryMock.DeleteReportJobs")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmDeleteReportJobs.funcDeleteReportJobs != nil {
+		return mmDeleteReportJobs.funcDeleteReportJobs(ctx, before)
+	}
+	mmDeleteReportJobs.t.Fatalf("Unexpected call to IRepositoryMock.DeleteReportJobs. %v %v", ctx, before)
+	return
+}
+
+// DeleteReportJobsAfterCounter returns a count of finished IRepositoryMock.DeleteReportJobs invocations
+func (mmDeleteReportJobs *IRepositoryMock) DeleteReportJobsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteReportJobs.afterDeleteReportJobsCounter)
+}
+
+// DeleteReportJobsBeforeCounter returns a count of IRepositoryMock.DeleteReportJobs invocations
+func (mmDeleteReportJobs *IRepositoryMock) DeleteReportJobsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteReportJobs.beforeDeleteReportJobsCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.DeleteReportJobs.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteReportJobs *mIRepositoryMockDeleteReportJobs) Calls() []*IRepositoryMockDeleteReportJobsParams {
+	mmDeleteReportJobs.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockDeleteReportJobsParams, len(mmDeleteReportJobs.callArgs))
+	copy(argCopy, mmDeleteReportJobs.callArgs)
+
+	mmDeleteReportJobs.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteReportJobsDone returns true if the count of the DeleteReportJobs invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockDeleteReportJobsDone() bool {
+	for _, e := range m.DeleteReportJobsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteReportJobsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteReportJobsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteReportJobs != nil && mm_atomic.LoadUint64(&m.afterDeleteReportJobsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockDeleteReportJobsInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockDeleteReportJobsInspect() {
+	for _, e := range m.DeleteReportJobsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteReportJobs with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteReportJobsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteReportJobsCounter) < 1 {
+		if m.DeleteReportJobsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.DeleteReportJobs")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteReportJobs with params: %#v", *m.DeleteReportJobsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteReportJobs != nil && mm_atomic.LoadUint64(&m.afterDeleteReportJobsCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.DeleteReportJobs")
+	}
+}
+
+type mIRepositoryMockDeleteService struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockDeleteServiceExpectation
+	expectations       []*IRepositoryMockDeleteServiceExpectation
+
+	callArgs []*IRepositoryMockDeleteServiceParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockDeleteServiceExpectation specifies expectation struct of the IRepository.DeleteService
+type IRepositoryMockDeleteServiceExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockDeleteServiceParams
+	results *IRepositoryMockDeleteServiceResults
+	Counter uint64
+}
+
+// IRepositoryMockDeleteServiceParams contains parameters of the IRepository.DeleteService
+type IRepositoryMockDeleteServiceParams struct {
+	ctx context.Context
+	id  uuid.UUID
+}
+
+// IRepositoryMockDeleteServiceResults contains results of the IRepository.DeleteService
+type IRepositoryMockDeleteServiceResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.DeleteService
+func (mmDeleteService *mIRepositoryMockDeleteService) Expect(ctx context.Context, id uuid.UUID) *mIRepositoryMockDeleteService {
+	if mmDeleteService.mock.funcDeleteService != nil {
+		mmDeleteService.mock.t.Fatalf("IRepositoryMock.DeleteService mock is already set by Set")
+	}
+
+	if mmDeleteService.defaultExpectation == nil {
+		mmDeleteService.defaultExpectation = &IRepositoryMockDeleteServiceExpectation{}
+	}
+
+	mmDeleteService.defaultExpectation.params = &IRepositoryMockDeleteServiceParams{ctx, id}
+	for _, e := range mmDeleteService.expectations {
+		if minimock.Equal(e.params, mmDeleteService.defaultExpectation.params) {
+			mmDeleteService.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteService.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteService
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.DeleteService
+func (mmDeleteService *mIRepositoryMockDeleteService) Inspect(f func(ctx context.Context, id uuid.UUID)) *mIRepositoryMockDeleteService {
+	if mmDeleteService.mock.inspectFuncDeleteService != nil {
+		mmDeleteService.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.DeleteService")
+	}
+
+	mmDeleteService.mock.inspectFuncDeleteService = f
+
+	return mmDeleteService
+}
+
+// Return sets up results that will be returned by IRepository.DeleteService
+func (mmDeleteService *mIRepositoryMockDeleteService) Return(err error) *IRepositoryMock {
+	if mmDeleteService.mock.funcDeleteService != nil {
+		mmDeleteService.mock.t.Fatalf("IRepositoryMock.DeleteService mock is already set by Set")
+	}
+
+	if mmDeleteService.defaultExpectation == nil {
+		mmDeleteService.defaultExpectation = &IRepositoryMockDeleteServiceExpectation{mock: mmDeleteService.mock}
+	}
+	mmDeleteService.defaultExpectation.results = &IRepositoryMockDeleteServiceResults{err}
+	return mmDeleteService.mock
+}
+
+// Set uses given function f to mock the IRepository.DeleteService method
+func (mmDeleteService *mIRepositoryMockDeleteService) Set(f func(ctx context.Context, id uuid.UUID) (err error)) *IRepositoryMock {
+	if mmDeleteService.defaultExpectation != nil {
+		mmDeleteService.mock.t.Fatalf("Default expectation is already set for the IRepository.DeleteService method")
+	}
+
+	if len(mmDeleteService.expectations) > 0 {
+		mmDeleteService.mock.t.Fatalf("Some expectations are already set for the IRepository.DeleteService method")
+	}
+
+	mmDeleteService.mock.funcDeleteService = f
+	return mmDeleteService.mock
+}
+
+// When sets expectation for the IRepository.DeleteService which will trigger the result defined by the following
+// Then helper
+func (mmDeleteService *mIRepositoryMockDeleteService) When(ctx context.Context, id uuid.UUID) *IRepositoryMockDeleteServiceExpectation {
+	if mmDeleteService.mock.funcDeleteService != nil {
+		mmDeleteService.mock.t.Fatalf("IRepositoryMock.DeleteService mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockDeleteServiceExpectation{
+		mock:   mmDeleteService.mock,
+		params: &IRepositoryMockDeleteServiceParams{ctx, id},
+	}
+	mmDeleteService.expectations = append(mmDeleteService.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.DeleteService return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockDeleteServiceExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockDeleteServiceResults{err}
+	return e.mock
+}
+
+// DeleteService implements IRepository
+func (mmDeleteService *IRepositoryMock) DeleteService(ctx context.Context, id uuid.UUID) (err error) {
+	mm_atomic.AddUint64(&mmDeleteService.beforeDeleteServiceCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteService.afterDeleteServiceCounter, 1)
+
+	if mmDeleteService.inspectFuncDeleteService != nil {
+		mmDeleteService.inspectFuncDeleteService(ctx, id)
+	}
+
+	mm_params := &IRepositoryMockDeleteServiceParams{ctx, id}
+
+	// Record call args
+	mmDeleteService.DeleteServiceMock.mutex.Lock()
+	mmDeleteService.DeleteServiceMock.callArgs = append(mmDeleteService.DeleteServiceMock.callArgs, mm_params)
+	mmDeleteService.DeleteServiceMock.mutex.Unlock()
+
+	for _, e := range mmDeleteService.DeleteServiceMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeleteService.DeleteServiceMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteService.DeleteServiceMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteService.DeleteServiceMock.defaultExpectation.params
+		mm_got := IRepositoryMockDeleteServiceParams{ctx, id}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteService.t.Errorf("IRepositoryMock.DeleteService got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteService.DeleteServiceMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteService.t.Fatal("No results are set for the IRepositoryMock.DeleteService")
+		}
+		return (*mm_results).err
+	}
+	if mmDeleteService.funcDeleteService != nil {
+		return mmDeleteService.funcDeleteService(ctx, id)
+	}
+	mmDeleteService.t.Fatalf("Unexpected call to IRepositoryMock.DeleteService. %v %v", ctx, id)
+	return
+}
+
+// DeleteServiceAfterCounter returns a count of finished IRepositoryMock.DeleteService invocations
+func (mmDeleteService *IRepositoryMock) DeleteServiceAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteService.afterDeleteServiceCounter)
+}
+
+// DeleteServiceBeforeCounter returns a count of IRepositoryMock.DeleteService invocations
+func (mmDeleteService *IRepositoryMock) DeleteServiceBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteService.beforeDeleteServiceCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.DeleteService.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteService *mIRepositoryMockDeleteService) Calls() []*IRepositoryMockDeleteServiceParams {
+	mmDeleteService.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockDeleteServiceParams, len(mmDeleteService.callArgs))
+	copy(argCopy, mmDeleteService.callArgs)
+
+	mmDeleteService.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteServiceDone returns true if the count of the DeleteService invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockDeleteServiceDone() bool {
+	for _, e := range m.DeleteServiceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteServiceCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteService != nil && mm_atomic.LoadUint64(&m.afterDeleteServiceCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockDeleteServiceInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockDeleteServiceInspect() {
+	for _, e := range m.DeleteServiceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteService with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDeleteServiceCounter) < 1 {
+		if m.DeleteServiceMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.DeleteService")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.DeleteService with params: %#v", *m.DeleteServiceMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteService != nil && mm_atomic.LoadUint64(&m.afterDeleteServiceCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.DeleteService")
+	}
+}
+
+type mIRepositoryMockDiscrepancies struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockDiscrepanciesExpectation
+	expectations       []*IRepositoryMockDiscrepanciesExpectation
+
+	callArgs []*IRepositoryMockDiscrepanciesParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockDiscrepanciesExpectation specifies expectation struct of the IRepository.Discrepancies
+type IRepositoryMockDiscrepanciesExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockDiscrepanciesParams
+	results *IRepositoryMockDiscrepanciesResults
+	Counter uint64
+}
+
+// IRepositoryMockDiscrepanciesParams contains parameters of the IRepository.Discrepancies
+type IRepositoryMockDiscrepanciesParams struct {
+	ctx context.Context
+}
+
+// IRepositoryMockDiscrepanciesResults contains results of the IRepository.Discrepancies
+type IRepositoryMockDiscrepanciesResults struct {
+	da1 []model.Discrepancy
+	err error
+}
+
+// Expect sets up expected params for IRepository.Discrepancies
+func (mmDiscrepancies *mIRepositoryMockDiscrepancies) Expect(ctx context.Context) *mIRepositoryMockDiscrepancies {
+	if mmDiscrepancies.mock.funcDiscrepancies != nil {
+		mmDiscrepancies.mock.t.Fatalf("IRepositoryMock.Discrepancies mock is already set by Set")
+	}
+
+	if mmDiscrepancies.defaultExpectation == nil {
+		mmDiscrepancies.defaultExpectation = &IRepositoryMockDiscrepanciesExpectation{}
+	}
+
+	mmDiscrepancies.defaultExpectation.params = &IRepositoryMockDiscrepanciesParams{ctx}
+	for _, e := range mmDiscrepancies.expectations {
+		if minimock.Equal(e.params, mmDiscrepancies.defaultExpectation.params) {
+			mmDiscrepancies.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDiscrepancies.defaultExpectation.params)
+		}
+	}
+
+	return mmDiscrepancies
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.Discrepancies
+func (mmDiscrepancies *mIRepositoryMockDiscrepancies) Inspect(f func(ctx context.Context)) *mIRepositoryMockDiscrepancies {
+	if mmDiscrepancies.mock.inspectFuncDiscrepancies != nil {
+		mmDiscrepancies.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Discrepancies")
+	}
+
+	mmDiscrepancies.mock.inspectFuncDiscrepancies = f
+
+	return mmDiscrepancies
+}
+
+// Return sets up results that will be returned by IRepository.Discrepancies
+func (mmDiscrepancies *mIRepositoryMockDiscrepancies) Return(da1 []model.Discrepancy, err error) *IRepositoryMock {
+	if mmDiscrepancies.mock.funcDiscrepancies != nil {
+		mmDiscrepancies.mock.t.Fatalf("IRepositoryMock.Discrepancies mock is already set by Set")
+	}
+
+	if mmDiscrepancies.defaultExpectation == nil {
+		mmDiscrepancies.defaultExpectation = &IRepositoryMockDiscrepanciesExpectation{mock: mmDiscrepancies.mock}
+	}
+	mmDiscrepancies.defaultExpectation.results = &IRepositoryMockDiscrepanciesResults{da1, err}
+	return mmDiscrepancies.mock
+}
+
+// Set uses given function f to mock the IRepository.Discrepancies method
+func (mmDiscrepancies *mIRepositoryMockDiscrepancies) Set(f func(ctx context.Context) (da1 []model.Discrepancy, err error)) *IRepositoryMock {
+	if mmDiscrepancies.defaultExpectation != nil {
+		mmDiscrepancies.mock.t.Fatalf("Default expectation is already set for the IRepository.Discrepancies method")
+	}
+
+	if len(mmDiscrepancies.expectations) > 0 {
+		mmDiscrepancies.mock.t.Fatalf("Some expectations are already set for the IRepository.Discrepancies method")
+	}
+
+	mmDiscrepancies.mock.funcDiscrepancies = f
+	return mmDiscrepancies.mock
+}
+
+// When sets expectation for the IRepository.Discrepancies which will trigger the result defined by the following
+// Then helper
+func (mmDiscrepancies *mIRepositoryMockDiscrepancies) When(ctx context.Context) *IRepositoryMockDiscrepanciesExpectation {
+	if mmDiscrepancies.mock.funcDiscrepancies != nil {
+		mmDiscrepancies.mock.t.Fatalf("IRepositoryMock.Discrepancies mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockDiscrepanciesExpectation{
+		mock:   mmDiscrepancies.mock,
+		params: &IRepositoryMockDiscrepanciesParams{ctx},
+	}
+	mmDiscrepancies.expectations = append(mmDiscrepancies.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.Discrepancies return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockDiscrepanciesExpectation) Then(da1 []model.Discrepancy, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockDiscrepanciesResults{da1, err}
+	return e.mock
+}
+
+// Discrepancies implements IRepository
+func (mmDiscrepancies *IRepositoryMock) Discrepancies(ctx context.Context) (da1 []model.Discrepancy, err error) {
+	mm_atomic.AddUint64(&mmDiscrepancies.beforeDiscrepanciesCounter, 1)
+	defer mm_atomic.AddUint64(&mmDiscrepancies.afterDiscrepanciesCounter, 1)
+
+	if mmDiscrepancies.inspectFuncDiscrepancies != nil {
+		mmDiscrepancies.inspectFuncDiscrepancies(ctx)
+	}
+
+	mm_params := &IRepositoryMockDiscrepanciesParams{ctx}
+
+	// Record call args
+	mmDiscrepancies.DiscrepanciesMock.mutex.Lock()
+	mmDiscrepancies.DiscrepanciesMock.callArgs = append(mmDiscrepancies.DiscrepanciesMock.callArgs, mm_params)
+	mmDiscrepancies.DiscrepanciesMock.mutex.Unlock()
+
+	for _, e := range mmDiscrepancies.DiscrepanciesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.da1, e.results.err
+		}
+	}
+
+	if mmDiscrepancies.DiscrepanciesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDiscrepancies.DiscrepanciesMock.defaultExpectation.Counter, 1)
+		mm_want := mmDiscrepancies.DiscrepanciesMock.defaultExpectation.params
+		mm_got := IRepositoryMockDiscrepanciesParams{ctx}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDiscrepancies.t.Errorf("IRepositoryMock.Discrepancies got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDiscrepancies.DiscrepanciesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDiscrepancies.t.Fatal("No results are set for the IRepositoryMock.Discrepancies")
+		}
+		return (*mm_results).da1, (*mm_results).err
+	}
+	if mmDiscrepancies.funcDiscrepancies != nil {
+		return mmDiscrepancies.funcDiscrepancies(ctx)
+	}
+	mmDiscrepancies.t.Fatalf("Unexpected call to IRepositoryMock.Discrepancies. %v", ctx)
+	return
+}
+
+// DiscrepanciesAfterCounter returns a count of finished IRepositoryMock.Discrepancies invocations
+func (mmDiscrepancies *IRepositoryMock) DiscrepanciesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDiscrepancies.afterDiscrepanciesCounter)
+}
+
+// DiscrepanciesBeforeCounter returns a count of IRepositoryMock.Discrepancies invocations
+func (mmDiscrepancies *IRepositoryMock) DiscrepanciesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDiscrepancies.beforeDiscrepanciesCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.Discrepancies.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDiscrepancies *mIRepositoryMockDiscrepancies) Calls() []*IRepositoryMockDiscrepanciesParams {
+	mmDiscrepancies.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockDiscrepanciesParams, len(mmDiscrepancies.callArgs))
+	copy(argCopy, mmDiscrepancies.callArgs)
+
+	mmDiscrepancies.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDiscrepanciesDone returns true if the count of the Discrepancies invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockDiscrepanciesDone() bool {
+	for _, e := range m.DiscrepanciesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DiscrepanciesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDiscrepanciesCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDiscrepancies != nil && mm_atomic.LoadUint64(&m.afterDiscrepanciesCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockDiscrepanciesInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockDiscrepanciesInspect() {
+	for _, e := range m.DiscrepanciesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.Discrepancies with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DiscrepanciesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDiscrepanciesCounter) < 1 {
+		if m.DiscrepanciesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Discrepancies")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.Discrepancies with params: %#v", *m.DiscrepanciesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDiscrepancies != nil && mm_atomic.LoadUint64(&m.afterDiscrepanciesCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Discrepancies")
+	}
+}
+
+type mIRepositoryMockEnrollment struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockEnrollmentExpectation
+	expectations       []*IRepositoryMockEnrollmentExpectation
+
+	callArgs []*IRepositoryMockEnrollmentParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockEnrollmentExpectation specifies expectation struct of the IRepository.Enrollment
+type IRepositoryMockEnrollmentExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockEnrollmentParams
+	results *IRepositoryMockEnrollmentResults
+	Counter uint64
+}
+
+// IRepositoryMockEnrollmentParams contains parameters of the IRepository.Enrollment
+type IRepositoryMockEnrollmentParams struct {
+	ctx    context.Context
+	userID uuid.UUID
+	funds  model.Money
+	date   time.Time
+}
+
+// IRepositoryMockEnrollmentResults contains results of the IRepository.Enrollment
+type IRepositoryMockEnrollmentResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.Enrollment
+func (mmEnrollment *mIRepositoryMockEnrollment) Expect(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) *mIRepositoryMockEnrollment {
+	if mmEnrollment.mock.funcEnrollment != nil {
+		mmEnrollment.mock.t.Fatalf("IRepositoryMock.Enrollment mock is already set by Set")
+	}
+
+	if mmEnrollment.defaultExpectation == nil {
+		mmEnrollment.defaultExpectation = &IRepositoryMockEnrollmentExpectation{}
+	}
+
+	mmEnrollment.defaultExpectation.params = &IRepositoryMockEnrollmentParams{ctx, userID, funds, date}
+	for _, e := range mmEnrollment.expectations {
+		if minimock.Equal(e.params, mmEnrollment.defaultExpectation.params) {
+			mmEnrollment.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmEnrollment.defaultExpectation.params)
+		}
+	}
+
+	return mmEnrollment
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.Enrollment
+func (mmEnrollment *mIRepositoryMockEnrollment) Inspect(f func(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time)) *mIRepositoryMockEnrollment {
+	if mmEnrollment.mock.inspectFuncEnrollment != nil {
+		mmEnrollment.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Enrollment")
+	}
+
+	mmEnrollment.mock.inspectFuncEnrollment = f
+
+	return mmEnrollment
+}
+
+// Return sets up results that will be returned by IRepository.Enrollment
+func (mmEnrollment *mIRepositoryMockEnrollment) Return(err error) *IRepositoryMock {
+	if mmEnrollment.mock.funcEnrollment != nil {
+		mmEnrollment.mock.t.Fatalf("IRepositoryMock.Enrollment mock is already set by Set")
+	}
+
+	if mmEnrollment.defaultExpectation == nil {
+		mmEnrollment.defaultExpectation = &IRepositoryMockEnrollmentExpectation{mock: mmEnrollment.mock}
+	}
+	mmEnrollment.defaultExpectation.results = &IRepositoryMockEnrollmentResults{err}
+	return mmEnrollment.mock
+}
+
+// Set uses given function f to mock the IRepository.Enrollment method
+func (mmEnrollment *mIRepositoryMockEnrollment) Set(f func(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) (err error)) *IRepositoryMock {
+	if mmEnrollment.defaultExpectation != nil {
+		mmEnrollment.mock.t.Fatalf("Default expectation is already set for the IRepository.Enrollment method")
+	}
+
+	if len(mmEnrollment.expectations) > 0 {
+		mmEnrollment.mock.t.Fatalf("Some expectations are already set for the IRepository.Enrollment method")
+	}
+
+	mmEnrollment.mock.funcEnrollment = f
+	return mmEnrollment.mock
+}
+
+// When sets expectation for the IRepository.Enrollment which will trigger the result defined by the following
+// Then helper
+func (mmEnrollment *mIRepositoryMockEnrollment) When(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) *IRepositoryMockEnrollmentExpectation {
+	if mmEnrollment.mock.funcEnrollment != nil {
+		mmEnrollment.mock.t.Fatalf("IRepositoryMock.Enrollment mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockEnrollmentExpectation{
+		mock:   mmEnrollment.mock,
+		params: &IRepositoryMockEnrollmentParams{ctx, userID, funds, date},
+	}
+	mmEnrollment.expectations = append(mmEnrollment.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.Enrollment return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockEnrollmentExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockEnrollmentResults{err}
+	return e.mock
+}
+
+// Enrollment implements IRepository
+func (mmEnrollment *IRepositoryMock) Enrollment(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) (err error) {
+	mm_atomic.AddUint64(&mmEnrollment.beforeEnrollmentCounter, 1)
+	defer mm_atomic.AddUint64(&mmEnrollment.afterEnrollmentCounter, 1)
+
+	if mmEnrollment.inspectFuncEnrollment != nil {
+		mmEnrollment.inspectFuncEnrollment(ctx, userID, funds, date)
+	}
+
+	mm_params := &IRepositoryMockEnrollmentParams{ctx, userID, funds, date}
+
+	// Record call args
+	mmEnrollment.EnrollmentMock.mutex.Lock()
+	mmEnrollment.EnrollmentMock.callArgs = append(mmEnrollment.EnrollmentMock.callArgs, mm_params)
+	mmEnrollment.EnrollmentMock.mutex.Unlock()
+
+	for _, e := range mmEnrollment.EnrollmentMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmEnrollment.EnrollmentMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmEnrollment.EnrollmentMock.defaultExpectation.Counter, 1)
+		mm_want := mmEnrollment.EnrollmentMock.defaultExpectation.params
+		mm_got := IRepositoryMockEnrollmentParams{ctx, userID, funds, date}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmEnrollment.t.Errorf("IRepositoryMock.Enrollment got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmEnrollment.EnrollmentMock.defaultExpectation.results
+		if mm_results == nil {
+			mmEnrollment.t.Fatal("No results are set for the IRepositoryMock.Enrollment")
+		}
+		return (*mm_results).err
+	}
+	if mmEnrollment.funcEnrollment != nil {
+		return mmEnrollment.funcEnrollment(ctx, userID, funds, date)
+	}
+	mmEnrollment.t.Fatalf("Unexpected call to IRepositoryMock.Enrollment. %v %v %v %v", ctx, userID, funds, date)
+	return
+}
+
+// EnrollmentAfterCounter returns a count of finished IRepositoryMock.Enrollment invocations
+func (mmEnrollment *IRepositoryMock) EnrollmentAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEnrollment.afterEnrollmentCounter)
+}
+
+// EnrollmentBeforeCounter returns a count of IRepositoryMock.Enrollment invocations
+func (mmEnrollment *IRepositoryMock) EnrollmentBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEnrollment.beforeEnrollmentCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.Enrollment.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmEnrollment *mIRepositoryMockEnrollment) Calls() []*IRepositoryMockEnrollmentParams {
+	mmEnrollment.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockEnrollmentParams, len(mmEnrollment.callArgs))
+	copy(argCopy, mmEnrollment.callArgs)
+
+	mmEnrollment.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockEnrollmentDone returns true if the count of the Enrollment invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockEnrollmentDone() bool {
+	for _, e := range m.EnrollmentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.EnrollmentMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcEnrollment != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockEnrollmentInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockEnrollmentInspect() {
+	for _, e := range m.EnrollmentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.Enrollment with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.EnrollmentMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
+		if m.EnrollmentMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Enrollment")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.Enrollment with params: %#v", *m.EnrollmentMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcEnrollment != nil && mm_atomic.LoadUint64(&m.afterEnrollmentCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Enrollment")
+	}
+}
+
+type mIRepositoryMockExpiredOrders struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockExpiredOrdersExpectation
+	expectations       []*IRepositoryMockExpiredOrdersExpectation
+
+	callArgs []*IRepositoryMockExpiredOrdersParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockExpiredOrdersExpectation specifies expectation struct of the IRepository.ExpiredOrders
+type IRepositoryMockExpiredOrdersExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockExpiredOrdersParams
+	results *IRepositoryMockExpiredOrdersResults
+	Counter uint64
+}
+
+// IRepositoryMockExpiredOrdersParams contains parameters of the IRepository.ExpiredOrders
+type IRepositoryMockExpiredOrdersParams struct {
+	ctx   context.Context
+	date  time.Time
+	limit int
+}
+
+// IRepositoryMockExpiredOrdersResults contains results of the IRepository.ExpiredOrders
+type IRepositoryMockExpiredOrdersResults struct {
+	oa1 []model.Order
+	err error
+}
+
+// Expect sets up expected params for IRepository.ExpiredOrders
+func (mmExpiredOrders *mIRepositoryMockExpiredOrders) Expect(ctx context.Context, date time.Time, limit int) *mIRepositoryMockExpiredOrders {
+	if mmExpiredOrders.mock.funcExpiredOrders != nil {
+		mmExpiredOrders.mock.t.Fatalf("IRepositoryMock.ExpiredOrders mock is already set by Set")
+	}
+
+	if mmExpiredOrders.defaultExpectation == nil {
+		mmExpiredOrders.defaultExpectation = &IRepositoryMockExpiredOrdersExpectation{}
+	}
+
+	mmExpiredOrders.defaultExpectation.params = &IRepositoryMockExpiredOrdersParams{ctx, date, limit}
+	for _, e := range mmExpiredOrders.expectations {
+		if minimock.Equal(e.params, mmExpiredOrders.defaultExpectation.params) {
+			mmExpiredOrders.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmExpiredOrders.defaultExpectation.params)
+		}
+	}
+
+	return mmExpiredOrders
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.ExpiredOrders
+func (mmExpiredOrders *mIRepositoryMockExpiredOrders) Inspect(f func(ctx context.Context, date time.Time, limit int)) *mIRepositoryMockExpiredOrders {
+	if mmExpiredOrders.mock.inspectFuncExpiredOrders != nil {
+		mmExpiredOrders.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.ExpiredOrders")
+	}
+
+	mmExpiredOrders.mock.inspectFuncExpiredOrders = f
+
+	return mmExpiredOrders
+}
+
+// Return sets up results that will be returned by IRepository.ExpiredOrders
+func (mmExpiredOrders *mIRepositoryMockExpiredOrders) Return(oa1 []model.Order, err error) *IRepositoryMock {
+	if mmExpiredOrders.mock.funcExpiredOrders != nil {
+		mmExpiredOrders.mock.t.Fatalf("IRepositoryMock.ExpiredOrders mock is already set by Set")
+	}
+
+	if mmExpiredOrders.defaultExpectation == nil {
+		mmExpiredOrders.defaultExpectation = &IRepositoryMockExpiredOrdersExpectation{mock: mmExpiredOrders.mock}
+	}
+	mmExpiredOrders.defaultExpectation.results = &IRepositoryMockExpiredOrdersResults{oa1, err}
+	return mmExpiredOrders.mock
+}
+
+// Set uses given function f to mock the IRepository.ExpiredOrders method
+func (mmExpiredOrders *mIRepositoryMockExpiredOrders) Set(f func(ctx context.Context, date time.Time, limit int) (oa1 []model.Order, err error)) *IRepositoryMock {
+	if mmExpiredOrders.defaultExpectation != nil {
+		mmExpiredOrders.mock.t.Fatalf("Default expectation is already set for the IRepository.ExpiredOrders method")
+	}
+
+	if len(mmExpiredOrders.expectations) > 0 {
+		mmExpiredOrders.mock.t.Fatalf("Some expectations are already set for the IRepository.ExpiredOrders method")
+	}
+
+	mmExpiredOrders.mock.funcExpiredOrders = f
+	return mmExpiredOrders.mock
+}
+
+// When sets expectation for the IRepository.ExpiredOrders which will trigger the result defined by the following
+// Then helper
+func (mmExpiredOrders *mIRepositoryMockExpiredOrders) When(ctx context.Context, date time.Time, limit int) *IRepositoryMockExpiredOrdersExpectation {
+	if mmExpiredOrders.mock.funcExpiredOrders != nil {
+		mmExpiredOrders.mock.t.Fatalf("IRepositoryMock.ExpiredOrders mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockExpiredOrdersExpectation{
+		mock:   mmExpiredOrders.mock,
+		params: &IRepositoryMockExpiredOrdersParams{ctx, date, limit},
+	}
+	mmExpiredOrders.expectations = append(mmExpiredOrders.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.ExpiredOrders return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockExpiredOrdersExpectation) Then(oa1 []model.Order, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockExpiredOrdersResults{oa1, err}
+	return e.mock
+}
+
+// ExpiredOrders implements IRepository
+func (mmExpiredOrders *IRepositoryMock) ExpiredOrders(ctx context.Context, date time.Time, limit int) (oa1 []model.Order, err error) {
+	mm_atomic.AddUint64(&mmExpiredOrders.beforeExpiredOrdersCounter, 1)
+	defer mm_atomic.AddUint64(&mmExpiredOrders.afterExpiredOrdersCounter, 1)
+
+	if mmExpiredOrders.inspectFuncExpiredOrders != nil {
+		mmExpiredOrders.inspectFuncExpiredOrders(ctx, date, limit)
+	}
+
+	mm_params := &IRepositoryMockExpiredOrdersParams{ctx, date, limit}
+
+	// Record call args
+	mmExpiredOrders.ExpiredOrdersMock.mutex.Lock()
+	mmExpiredOrders.ExpiredOrdersMock.callArgs = append(mmExpiredOrders.ExpiredOrdersMock.callArgs, mm_params)
+	mmExpiredOrders.ExpiredOrdersMock.mutex.Unlock()
+
+	for _, e := range mmExpiredOrders.ExpiredOrdersMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.oa1, e.results.err
+		}
+	}
+
+	if mmExpiredOrders.ExpiredOrdersMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmExpiredOrders.ExpiredOrdersMock.defaultExpectation.Counter, 1)
+		mm_want := mmExpiredOrders.ExpiredOrdersMock.defaultExpectation.params
+		mm_got := IRepositoryMockExpiredOrdersParams{ctx, date, limit}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmExpiredOrders.t.Errorf("IRepositoryMock.ExpiredOrders got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmExpiredOrders.ExpiredOrdersMock.defaultExpectation.results
+		if mm_results == nil {
+			mmExpiredOrders.t.Fatal("No results are set for the IRepositoryMock.ExpiredOrders")
+		}
+		return (*mm_results).oa1, (*mm_results).err
+	}
+	if mmExpiredOrders.funcExpiredOrders != nil {
+		return mmExpiredOrders.funcExpiredOrders(ctx, date, limit)
+	}
+	mmExpiredOrders.t.Fatalf("Unexpected call to IRepositoryMock.ExpiredOrders. %v %v %v", ctx, date, limit)
+	return
+}
+
+// ExpiredOrdersAfterCounter returns a count of finished IRepositoryMock.ExpiredOrders invocations
+func (mmExpiredOrders *IRepositoryMock) ExpiredOrdersAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmExpiredOrders.afterExpiredOrdersCounter)
+}
+
+// ExpiredOrdersBeforeCounter returns a count of IRepositoryMock.ExpiredOrders invocations
+func (mmExpiredOrders *IRepositoryMock) ExpiredOrdersBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmExpiredOrders.beforeExpiredOrdersCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.ExpiredOrders.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmExpiredOrders *mIRepositoryMockExpiredOrders) Calls() []*IRepositoryMockExpiredOrdersParams {
+	mmExpiredOrders.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockExpiredOrdersParams, len(mmExpiredOrders.callArgs))
+	copy(argCopy, mmExpiredOrders.callArgs)
+
+	mmExpiredOrders.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockExpiredOrdersDone returns true if the count of the ExpiredOrders invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockExpiredOrdersDone() bool {
+	for _, e := range m.ExpiredOrdersMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ExpiredOrdersMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterExpiredOrdersCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcExpiredOrders != nil && mm_atomic.LoadUint64(&m.afterExpiredOrdersCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockExpiredOrdersInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockExpiredOrdersInspect() {
+	for _, e := range m.ExpiredOrdersMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.ExpiredOrders with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ExpiredOrdersMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterExpiredOrdersCounter) < 1 {
+		if m.ExpiredOrdersMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.ExpiredOrders")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.ExpiredOrders with params: %#v", *m.ExpiredOrdersMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcExpiredOrders != nil && mm_atomic.LoadUint64(&m.afterExpiredOrdersCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.ExpiredOrders")
+	}
+}
+
+type mIRepositoryMockFinishReportJob struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockFinishReportJobExpectation
+	expectations       []*IRepositoryMockFinishReportJobExpectation
+
+	callArgs []*IRepositoryMockFinishReportJobParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockFinishReportJobExpectation specifies expectation struct of the IRepository.FinishReportJob
+type IRepositoryMockFinishReportJobExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockFinishReportJobParams
+	results *IRepositoryMockFinishReportJobResults
+	Counter uint64
+}
+
+// IRepositoryMockFinishReportJobParams contains parameters of the IRepository.FinishReportJob
+type IRepositoryMockFinishReportJobParams struct {
+	ctx  context.Context
+	job  model.ReportJob
+	date time.Time
+}
+
+// IRepositoryMockFinishReportJobResults contains results of the IRepository.FinishReportJob
+type IRepositoryMockFinishReportJobResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.FinishReportJob
+func (mmFinishReportJob *mIRepositoryMockFinishReportJob) Expect(ctx context.Context, job model.ReportJob, date time.Time) *mIRepositoryMockFinishReportJob {
+	if mmFinishReportJob.mock.funcFinishReportJob != nil {
+		mmFinishReportJob.mock.t.Fatalf("IRepositoryMock.FinishReportJob mock is already set by Set")
+	}
+
+	if mmFinishReportJob.defaultExpectation == nil {
+		mmFinishReportJob.defaultExpectation = &IRepositoryMockFinishReportJobExpectation{}
+	}
+
+	mmFinishReportJob.defaultExpectation.params = &IRepositoryMockFinishReportJobParams{ctx, job, date}
+	for _, e := range mmFinishReportJob.expectations {
+		if minimock.Equal(e.params, mmFinishReportJob.defaultExpectation.params) {
+			mmFinishReportJob.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmFinishReportJob.defaultExpectation.params)
+		}
+	}
+
+	return mmFinishReportJob
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.FinishReportJob
+func (mmFinishReportJob *mIRepositoryMockFinishReportJob) Inspect(f func(ctx context.Context, job model.ReportJob, date time.Time)) *mIRepositoryMockFinishReportJob {
+	if mmFinishReportJob.mock.inspectFuncFinishReportJob != nil {
+		mmFinishReportJob.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.FinishReportJob")
+	}
+
+	mmFinishReportJob.mock.inspectFuncFinishReportJob = f
+
+	return mmFinishReportJob
+}
+
+// Return sets up results that will be returned by IRepository.FinishReportJob
+func (mmFinishReportJob *mIRepositoryMockFinishReportJob) Return(err error) *IRepositoryMock {
+	if mmFinishReportJob.mock.funcFinishReportJob != nil {
+		mmFinishReportJob.mock.t.Fatalf("IRepositoryMock.FinishReportJob mock is already set by Set")
+	}
+
+	if mmFinishReportJob.defaultExpectation == nil {
+		mmFinishReportJob.defaultExpectation = &IRepositoryMockFinishReportJobExpectation{mock: mmFinishReportJob.mock}
+	}
+	mmFinishReportJob.defaultExpectation.results = &IRepositoryMockFinishReportJobResults{err}
+	return mmFinishReportJob.mock
+}
+
+// Set uses given function f to mock the IRepository.FinishReportJob method
+func (mmFinishReportJob *mIRepositoryMockFinishReportJob) Set(f func(ctx context.Context, job model.ReportJob, date time.Time) (err error)) *IRepositoryMock {
+	if mmFinishReportJob.defaultExpectation != nil {
+		mmFinishReportJob.mock.t.Fatalf("Default expectation is already set for the IRepository.FinishReportJob method")
+	}
+
+	if len(mmFinishReportJob.expectations) > 0 {
+		mmFinishReportJob.mock.t.Fatalf("Some expectations are already set for the IRepository.FinishReportJob method")
+	}
+
+	mmFinishReportJob.mock.funcFinishReportJob = f
+	return mmFinishReportJob.mock
+}
+
+// When sets expectation for the IRepository.FinishReportJob which will trigger the result defined by the following
+// Then helper
+func (mmFinishReportJob *mIRepositoryMockFinishReportJob) When(ctx context.Context, job model.ReportJob, date time.Time) *IRepositoryMockFinishReportJobExpectation {
+	if mmFinishReportJob.mock.funcFinishReportJob != nil {
+		mmFinishReportJob.mock.t.Fatalf("IRepositoryMock.FinishReportJob mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockFinishReportJobExpectation{
+		mock:   mmFinishReportJob.mock,
+		params: &IRepositoryMockFinishReportJobParams{ctx, job, date},
+	}
+	mmFinishReportJob.expectations = append(mmFinishReportJob.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.FinishReportJob return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockFinishReportJobExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockFinishReportJobResults{err}
+	return e.mock
+}
+
+// FinishReportJob implements IRepository
+func (mmFinishReportJob *IRepositoryMock) FinishReportJob(ctx context.Context, job model.ReportJob, date time.Time) (err error) {
+	mm_atomic.AddUint64(&mmFinishReportJob.beforeFinishReportJobCounter, 1)
+	defer mm_atomic.AddUint64(&mmFinishReportJob.afterFinishReportJobCounter, 1)
+
+	if mmFinishReportJob.inspectFuncFinishReportJob != nil {
+		mmFinishReportJob.inspectFuncFinishReportJob(ctx, job, date)
+	}
+
+	mm_params := &IRepositoryMockFinishReportJobParams{ctx, job, date}
+
+	// Record call args
+	mmFinishReportJob.FinishReportJobMock.mutex.Lock()
+	mmFinishReportJob.FinishReportJobMock.callArgs = append(mmFinishReportJob.FinishReportJobMock.callArgs, mm_params)
+	mmFinishReportJob.FinishReportJobMock.mutex.Unlock()
+
+	for _, e := range mmFinishReportJob.FinishReportJobMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmFinishReportJob.FinishReportJobMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmFinishReportJob.FinishReportJobMock.defaultExpectation.Counter, 1)
+		mm_want := mmFinishReportJob.FinishReportJobMock.defaultExpectation.params
+		mm_got := IRepositoryMockFinishReportJobParams{ctx, job, date}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmFinishReportJob.t.Errorf("IRepositoryMock.FinishReportJob got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmFinishReportJob.FinishReportJobMock.defaultExpectation.results
+		if mm_results == nil {
+			mmFinishReportJob.t.Fatal("No results are set for the IRepositoryMock.FinishReportJob")
+		}
+		return (*mm_results).err
+	}
+	if mmFinishReportJob.funcFinishReportJob != nil {
+		return mmFinishReportJob.funcFinishReportJob(ctx, job, date)
+	}
+	mmFinishReportJob.t.Fatalf("Unexpected call to IRepositoryMock.FinishReportJob. %v %v %v", ctx, job, date)
+	return
+}
+
+// FinishReportJobAfterCounter returns a count of finished IRepositoryMock.FinishReportJob invocations
+func (mmFinishReportJob *IRepositoryMock) FinishReportJobAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmFinishReportJob.afterFinishReportJobCounter)
+}
+
+// FinishReportJobBeforeCounter returns a count of IRepositoryMock.FinishReportJob invocations
+func (mmFinishReportJob *IRepositoryMock) FinishReportJobBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmFinishReportJob.beforeFinishReportJobCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.FinishReportJob.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmFinishReportJob *mIRepositoryMockFinishReportJob) Calls() []*IRepositoryMockFinishReportJobParams {
+	mmFinishReportJob.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockFinishReportJobParams, len(mmFinishReportJob.callArgs))
+	copy(argCopy, mmFinishReportJob.callArgs)
+
+	mmFinishReportJob.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockFinishReportJobDone returns true if the count of the FinishReportJob invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockFinishReportJobDone() bool {
+	for _, e := range m.FinishReportJobMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.FinishReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterFinishReportJobCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcFinishReportJob != nil && mm_atomic.LoadUint64(&m.afterFinishReportJobCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockFinishReportJobInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockFinishReportJobInspect() {
+	for _, e := range m.FinishReportJobMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.FinishReportJob with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.FinishReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterFinishReportJobCounter) < 1 {
+		if m.FinishReportJobMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.FinishReportJob")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.FinishReportJob with params: %#v", *m.FinishReportJobMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcFinishReportJob != nil && mm_atomic.LoadUint64(&m.afterFinishReportJobCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.FinishReportJob")
+	}
+}
+
+type mIRepositoryMockGetIdempotencyKey struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockGetIdempotencyKeyExpectation
+	expectations       []*IRepositoryMockGetIdempotencyKeyExpectation
+
+	callArgs []*IRepositoryMockGetIdempotencyKeyParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockGetIdempotencyKeyExpectation specifies expectation struct of the IRepository.GetIdempotencyKey
+type IRepositoryMockGetIdempotencyKeyExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockGetIdempotencyKeyParams
+	results *IRepositoryMockGetIdempotencyKeyResults
+	Counter uint64
+}
+
+// IRepositoryMockGetIdempotencyKeyParams contains parameters of the IRepository.GetIdempotencyKey
+type IRepositoryMockGetIdempotencyKeyParams struct {
+	ctx context.Context
+	key string
+}
+
+// IRepositoryMockGetIdempotencyKeyResults contains results of the IRepository.GetIdempotencyKey
+type IRepositoryMockGetIdempotencyKeyResults struct {
+	ip1 *model.Idempotency
+	err error
+}
+
+// Expect sets up expected params for IRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIRepositoryMockGetIdempotencyKey) Expect(ctx context.Context, key string) *mIRepositoryMockGetIdempotencyKey {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IRepositoryMock.GetIdempotencyKey mock is already set by Set")
+	}
+
+	if mmGetIdempotencyKey.defaultExpectation == nil {
+		mmGetIdempotencyKey.defaultExpectation = &IRepositoryMockGetIdempotencyKeyExpectation{}
+	}
+
+	mmGetIdempotencyKey.defaultExpectation.params = &IRepositoryMockGetIdempotencyKeyParams{ctx, key}
+	for _, e := range mmGetIdempotencyKey.expectations {
+		if minimock.Equal(e.params, mmGetIdempotencyKey.defaultExpectation.params) {
+			mmGetIdempotencyKey.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetIdempotencyKey.defaultExpectation.params)
+		}
+	}
+
+	return mmGetIdempotencyKey
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIRepositoryMockGetIdempotencyKey) Inspect(f func(ctx context.Context, key string)) *mIRepositoryMockGetIdempotencyKey {
+	if mmGetIdempotencyKey.mock.inspectFuncGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.GetIdempotencyKey")
+	}
+
+	mmGetIdempotencyKey.mock.inspectFuncGetIdempotencyKey = f
+
+	return mmGetIdempotencyKey
+}
+
+// Return sets up results that will be returned by IRepository.GetIdempotencyKey
+func (mmGetIdempotencyKey *mIRepositoryMockGetIdempotencyKey) Return(ip1 *model.Idempotency, err error) *IRepositoryMock {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IRepositoryMock.GetIdempotencyKey mock is already set by Set")
+	}
+
+	if mmGetIdempotencyKey.defaultExpectation == nil {
+		mmGetIdempotencyKey.defaultExpectation = &IRepositoryMockGetIdempotencyKeyExpectation{mock: mmGetIdempotencyKey.mock}
+	}
+	mmGetIdempotencyKey.defaultExpectation.results = &IRepositoryMockGetIdempotencyKeyResults{ip1, err}
+	return mmGetIdempotencyKey.mock
+}
+
+// Set uses given function f to mock the IRepository.GetIdempotencyKey method
+func (mmGetIdempotencyKey *mIRepositoryMockGetIdempotencyKey) Set(f func(ctx context.Context, key string) (ip1 *model.Idempotency, err error)) *IRepositoryMock {
+	if mmGetIdempotencyKey.defaultExpectation != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("Default expectation is already set for the IRepository.GetIdempotencyKey method")
+	}
+
+	if len(mmGetIdempotencyKey.expectations) > 0 {
+		mmGetIdempotencyKey.mock.t.Fatalf("Some expectations are already set for the IRepository.GetIdempotencyKey method")
+	}
+
+	mmGetIdempotencyKey.mock.funcGetIdempotencyKey = f
+	return mmGetIdempotencyKey.mock
+}
+
+// When sets expectation for the IRepository.GetIdempotencyKey which will trigger the result defined by the following
+// Then helper
+func (mmGetIdempotencyKey *mIRepositoryMockGetIdempotencyKey) When(ctx context.Context, key string) *IRepositoryMockGetIdempotencyKeyExpectation {
+	if mmGetIdempotencyKey.mock.funcGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.mock.t.Fatalf("IRepositoryMock.GetIdempotencyKey mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockGetIdempotencyKeyExpectation{
+		mock:   mmGetIdempotencyKey.mock,
+		params: &IRepositoryMockGetIdempotencyKeyParams{ctx, key},
+	}
+	mmGetIdempotencyKey.expectations = append(mmGetIdempotencyKey.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.GetIdempotencyKey return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockGetIdempotencyKeyExpectation) Then(ip1 *model.Idempotency, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockGetIdempotencyKeyResults{ip1, err}
+	return e.mock
+}
+
+// GetIdempotencyKey implements IRepository
+func (mmGetIdempotencyKey *IRepositoryMock) GetIdempotencyKey(ctx context.Context, key string) (ip1 *model.Idempotency, err error) {
+	mm_atomic.AddUint64(&mmGetIdempotencyKey.beforeGetIdempotencyKeyCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetIdempotencyKey.afterGetIdempotencyKeyCounter, 1)
+
+	if mmGetIdempotencyKey.inspectFuncGetIdempotencyKey != nil {
+		mmGetIdempotencyKey.inspectFuncGetIdempotencyKey(ctx, key)
+	}
+
+	mm_params := &IRepositoryMockGetIdempotencyKeyParams{ctx, key}
+
+	// Record call args
+	mmGetIdempotencyKey.GetIdempotencyKeyMock.mutex.Lock()
+	mmGetIdempotencyKey.GetIdempotencyKeyMock.callArgs = append(mmGetIdempotencyKey.GetIdempotencyKeyMock.callArgs, mm_params)
+	mmGetIdempotencyKey.GetIdempotencyKeyMock.mutex.Unlock()
+
+	for _, e := range mmGetIdempotencyKey.GetIdempotencyKeyMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ip1, e.results.err
+		}
+	}
+
+	if mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.params
+		mm_got := IRepositoryMockGetIdempotencyKeyParams{ctx, key}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetIdempotencyKey.t.Errorf("IRepositoryMock.GetIdempotencyKey got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetIdempotencyKey.GetIdempotencyKeyMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetIdempotencyKey.t.Fatal("No results are set for the IRepositoryMock.GetIdempotencyKey")
+		}
+		return (*mm_results).ip1, (*mm_results).err
+	}
+	if mmGetIdempotencyKey.funcGetIdempotencyKey != nil {
+		return mmGetIdempotencyKey.funcGetIdempotencyKey(ctx, key)
+	}
+	mmGetIdempotencyKey.t.Fatalf("Unexpected call to IRepositoryMock.GetIdempotencyKey. %v %v", ctx, key)
+	return
+}
+
+// GetIdempotencyKeyAfterCounter returns a count of finished IRepositoryMock.GetIdempotencyKey invocations
+func (mmGetIdempotencyKey *IRepositoryMock) GetIdempotencyKeyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetIdempotencyKey.afterGetIdempotencyKeyCounter)
+}
+
+// GetIdempotencyKeyBeforeCounter returns a count of IRepositoryMock.GetIdempotencyKey invocations
+func (mmGetIdempotencyKey *IRepositoryMock) GetIdempotencyKeyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetIdempotencyKey.beforeGetIdempotencyKeyCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.GetIdempotencyKey.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetIdempotencyKey *mIRepositoryMockGetIdempotencyKey) Calls() []*IRepositoryMockGetIdempotencyKeyParams {
+	mmGetIdempotencyKey.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockGetIdempotencyKeyParams, len(mmGetIdempotencyKey.callArgs))
+	copy(argCopy, mmGetIdempotencyKey.callArgs)
+
+	mmGetIdempotencyKey.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetIdempotencyKeyDone returns true if the count of the GetIdempotencyKey invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockGetIdempotencyKeyDone() bool {
+	for _, e := range m.GetIdempotencyKeyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetIdempotencyKeyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetIdempotencyKeyCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetIdempotencyKey != nil && mm_atomic.LoadUint64(&m.afterGetIdempotencyKeyCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetIdempotencyKeyInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockGetIdempotencyKeyInspect() {
+	for _, e := range m.GetIdempotencyKeyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.GetIdempotencyKey with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetIdempotencyKeyMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetIdempotencyKeyCounter) < 1 {
+		if m.GetIdempotencyKeyMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.GetIdempotencyKey")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.GetIdempotencyKey with params: %#v", *m.GetIdempotencyKeyMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetIdempotencyKey != nil && mm_atomic.LoadUint64(&m.afterGetIdempotencyKeyCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.GetIdempotencyKey")
+	}
+}
+
+type mIRepositoryMockGetOrder struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockGetOrderExpectation
+	expectations       []*IRepositoryMockGetOrderExpectation
+
+	callArgs []*IRepositoryMockGetOrderParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockGetOrderExpectation specifies expectation struct of the IRepository.GetOrder
+type IRepositoryMockGetOrderExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockGetOrderParams
+	results *IRepositoryMockGetOrderResults
+	Counter uint64
+}
+
+// IRepositoryMockGetOrderParams contains parameters of the IRepository.GetOrder
+type IRepositoryMockGetOrderParams struct {
+	ctx     context.Context
+	orderID uuid.UUID
+}
+
+// IRepositoryMockGetOrderResults contains results of the IRepository.GetOrder
+type IRepositoryMockGetOrderResults struct {
+	op1 *model.Order
+	err error
+}
+
+// Expect sets up expected params for IRepository.GetOrder
+func (mmGetOrder *mIRepositoryMockGetOrder) Expect(ctx context.Context, orderID uuid.UUID) *mIRepositoryMockGetOrder {
+	if mmGetOrder.mock.funcGetOrder != nil {
+		mmGetOrder.mock.t.Fatalf("IRepositoryMock.GetOrder mock is already set by Set")
+	}
+
+	if mmGetOrder.defaultExpectation == nil {
+		mmGetOrder.defaultExpectation = &IRepositoryMockGetOrderExpectation{}
+	}
+
+	mmGetOrder.defaultExpectation.params = &IRepositoryMockGetOrderParams{ctx, orderID}
+	for _, e := range mmGetOrder.expectations {
+		if minimock.Equal(e.params, mmGetOrder.defaultExpectation.params) {
+			mmGetOrder.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetOrder.defaultExpectation.params)
+		}
+	}
+
+	return mmGetOrder
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.GetOrder
+func (mmGetOrder *mIRepositoryMockGetOrder) Inspect(f func(ctx context.Context, orderID uuid.UUID)) *mIRepositoryMockGetOrder {
+	if mmGetOrder.mock.inspectFuncGetOrder != nil {
+		mmGetOrder.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.GetOrder")
+	}
+
+	mmGetOrder.mock.inspectFuncGetOrder = f
+
+	return mmGetOrder
+}
+
+// Return sets up results that will be returned by IRepository.GetOrder
+func (mmGetOrder *mIRepositoryMockGetOrder) Return(op1 *model.Order, err error) *IRepositoryMock {
+	if mmGetOrder.mock.funcGetOrder != nil {
+		mmGetOrder.mock.t.Fatalf("IRepositoryMock.GetOrder mock is already set by Set")
+	}
+
+	if mmGetOrder.defaultExpectation == nil {
+		mmGetOrder.defaultExpectation = &IRepositoryMockGetOrderExpectation{mock: mmGetOrder.mock}
+	}
+	mmGetOrder.defaultExpectation.results = &IRepositoryMockGetOrderResults{op1, err}
+	return mmGetOrder.mock
+}
+
+// Set uses given function f to mock the IRepository.GetOrder method
+func (mmGetOrder *mIRepositoryMockGetOrder) Set(f func(ctx context.Context, orderID uuid.UUID) (op1 *model.Order, err error)) *IRepositoryMock {
+	if mmGetOrder.defaultExpectation != nil {
+		mmGetOrder.mock.t.Fatalf("Default expectation is already set for the IRepository.GetOrder method")
+	}
+
+	if len(mmGetOrder.expectations) > 0 {
+		mmGetOrder.mock.t.Fatalf("Some expectations are already set for the IRepository.GetOrder method")
+	}
+
+	mmGetOrder.mock.funcGetOrder = f
+	return mmGetOrder.mock
+}
+
+// When sets expectation for the IRepository.GetOrder which will trigger the result defined by the following
+// Then helper
+func (mmGetOrder *mIRepositoryMockGetOrder) When(ctx context.Context, orderID uuid.UUID) *IRepositoryMockGetOrderExpectation {
+	if mmGetOrder.mock.funcGetOrder != nil {
+		mmGetOrder.mock.t.Fatalf("IRepositoryMock.GetOrder mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockGetOrderExpectation{
+		mock:   mmGetOrder.mock,
+		params: &IRepositoryMockGetOrderParams{ctx, orderID},
+	}
+	mmGetOrder.expectations = append(mmGetOrder.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.GetOrder return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockGetOrderExpectation) Then(op1 *model.Order, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockGetOrderResults{op1, err}
+	return e.mock
+}
+
+// GetOrder implements IRepository
+func (mmGetOrder *IRepositoryMock) GetOrder(ctx context.Context, orderID uuid.UUID) (op1 *model.Order, err error) {
+	mm_atomic.AddUint64(&mmGetOrder.beforeGetOrderCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetOrder.afterGetOrderCounter, 1)
+
+	if mmGetOrder.inspectFuncGetOrder != nil {
+		mmGetOrder.inspectFuncGetOrder(ctx, orderID)
+	}
+
+	mm_params := &IRepositoryMockGetOrderParams{ctx, orderID}
+
+	// Record call args
+	mmGetOrder.GetOrderMock.mutex.Lock()
+	mmGetOrder.GetOrderMock.callArgs = append(mmGetOrder.GetOrderMock.callArgs, mm_params)
+	mmGetOrder.GetOrderMock.mutex.Unlock()
+
+	for _, e := range mmGetOrder.GetOrderMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.op1, e.results.err
+		}
+	}
+
+	if mmGetOrder.GetOrderMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetOrder.GetOrderMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetOrder.GetOrderMock.defaultExpectation.params
+		mm_got := IRepositoryMockGetOrderParams{ctx, orderID}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetOrder.t.Errorf("IRepositoryMock.GetOrder got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetOrder.GetOrderMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetOrder.t.Fatal("No results are set for the IRepositoryMock.GetOrder")
+		}
+		return (*mm_results).op1, (*mm_results).err
+	}
+	if mmGetOrder.funcGetOrder != nil {
+		return mmGetOrder.funcGetOrder(ctx, orderID)
+	}
+	mmGetOrder.t.Fatalf("Unexpected call to IRepositoryMock.GetOrder. %v %v", ctx, orderID)
+	return
+}
+
+// GetOrderAfterCounter returns a count of finished IRepositoryMock.GetOrder invocations
+func (mmGetOrder *IRepositoryMock) GetOrderAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetOrder.afterGetOrderCounter)
+}
+
+// GetOrderBeforeCounter returns a count of IRepositoryMock.GetOrder invocations
+func (mmGetOrder *IRepositoryMock) GetOrderBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetOrder.beforeGetOrderCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.GetOrder.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetOrder *mIRepositoryMockGetOrder) Calls() []*IRepositoryMockGetOrderParams {
+	mmGetOrder.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockGetOrderParams, len(mmGetOrder.callArgs))
+	copy(argCopy, mmGetOrder.callArgs)
+
+	mmGetOrder.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetOrderDone returns true if the count of the GetOrder invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockGetOrderDone() bool {
+	for _, e := range m.GetOrderMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetOrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetOrder != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetOrderInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockGetOrderInspect() {
+	for _, e := range m.GetOrderMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.GetOrder with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetOrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
+		if m.GetOrderMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.GetOrder")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.GetOrder with params: %#v", *m.GetOrderMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetOrder != nil && mm_atomic.LoadUint64(&m.afterGetOrderCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.GetOrder")
+	}
+}
+
+type mIRepositoryMockGetReportJob struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockGetReportJobExpectation
+	expectations       []*IRepositoryMockGetReportJobExpectation
+
+	callArgs []*IRepositoryMockGetReportJobParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockGetReportJobExpectation specifies expectation struct of the IRepository.GetReportJob
+type IRepositoryMockGetReportJobExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockGetReportJobParams
+	results *IRepositoryMockGetReportJobResults
+	Counter uint64
+}
+
+// IRepositoryMockGetReportJobParams contains parameters of the IRepository.GetReportJob
+type IRepositoryMockGetReportJobParams struct {
+	ctx context.Context
+	id  uuid.UUID
+}
+
+// IRepositoryMockGetReportJobResults contains results of the IRepository.GetReportJob
+type IRepositoryMockGetReportJobResults struct {
+	rp1 *model.ReportJob
+	err error
+}
+
+// Expect sets up expected params for IRepository.GetReportJob
+func (mmGetReportJob *mIRepositoryMockGetReportJob) Expect(ctx context.Context, id uuid.UUID) *mIRepositoryMockGetReportJob {
+	if mmGetReportJob.mock.funcGetReportJob != nil {
+		mmGetReportJob.mock.t.Fatalf("IRepositoryMock.GetReportJob mock is already set by Set")
+	}
+
+	if mmGetReportJob.defaultExpectation == nil {
+		mmGetReportJob.defaultExpectation = &IRepositoryMockGetReportJobExpectation{}
+	}
+
+	mmGetReportJob.defaultExpectation.params = &IRepositoryMockGetReportJobParams{ctx, id}
+	for _, e := range mmGetReportJob.expectations {
+		if minimock.Equal(e.params, mmGetReportJob.defaultExpectation.params) {
+			mmGetReportJob.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetReportJob.defaultExpectation.params)
+		}
+	}
+
+	return mmGetReportJob
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.GetReportJob
+func (mmGetReportJob *mIRepositoryMockGetReportJob) Inspect(f func(ctx context.Context, id uuid.UUID)) *mIRepositoryMockGetReportJob {
+	if mmGetReportJob.mock.inspectFuncGetReportJob != nil {
+		mmGetReportJob.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.GetReportJob")
+	}
+
+	mmGetReportJob.mock.inspectFuncGetReportJob = f
+
+	return mmGetReportJob
+}
+
+// Return sets up results that will be returned by IRepository.GetReportJob
+func (mmGetReportJob *mIRepositoryMockGetReportJob) Return(rp1 *model.ReportJob, err error) *IRepositoryMock {
+	if mmGetReportJob.mock.funcGetReportJob != nil {
+		mmGetReportJob.mock.t.Fatalf("IRepositoryMock.GetReportJob mock is already set by Set")
+	}
+
+	if mmGetReportJob.defaultExpectation == nil {
+		mmGetReportJob.defaultExpectation = &IRepositoryMockGetReportJobExpectation{mock: mmGetReportJob.mock}
+	}
+	mmGetReportJob.defaultExpectation.results = &IRepositoryMockGetReportJobResults{rp1, err}
+	return mmGetReportJob.mock
+}
+
+// Set uses given function f to mock the IRepository.GetReportJob method
+func (mmGetReportJob *mIRepositoryMockGetReportJob) Set(f func(ctx context.Context, id uuid.UUID) (rp1 *model.ReportJob, err error)) *IRepositoryMock {
+	if mmGetReportJob.defaultExpectation != nil {
+		mmGetReportJob.mock.t.Fatalf("Default expectation is already set for the IRepository.GetReportJob method")
+	}
+
+	if len(mmGetReportJob.expectations) > 0 {
+		mmGetReportJob.mock.t.Fatalf("Some expectations are already set for the IRepository.GetReportJob method")
+	}
+
+	mmGetReportJob.mock.funcGetReportJob = f
+	return mmGetReportJob.mock
+}
+
+// When sets expectation for the IRepository.GetReportJob which will trigger the result defined by the following
+// Then helper
+func (mmGetReportJob *mIRepositoryMockGetReportJob) When(ctx context.Context, id uuid.UUID) *IRepositoryMockGetReportJobExpectation {
+	if mmGetReportJob.mock.funcGetReportJob != nil {
+		mmGetReportJob.mock.t.Fatalf("IRepositoryMock.GetReportJob mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockGetReportJobExpectation{
+		mock:   mmGetReportJob.mock,
+		params: &IRepositoryMockGetReportJobParams{ctx, id},
+	}
+	mmGetReportJob.expectations = append(mmGetReportJob.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.GetReportJob return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockGetReportJobExpectation) Then(rp1 *model.ReportJob, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockGetReportJobResults{rp1, err}
+	return e.mock
+}
+
+// GetReportJob implements IRepository
+func (mmGetReportJob *IRepositoryMock) GetReportJob(ctx context.Context, id uuid.UUID) (rp1 *model.ReportJob, err error) {
+	mm_atomic.AddUint64(&mmGetReportJob.beforeGetReportJobCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetReportJob.afterGetReportJobCounter, 1)
+
+	if mmGetReportJob.inspectFuncGetReportJob != nil {
+		mmGetReportJob.inspectFuncGetReportJob(ctx, id)
+	}
+
+	mm_params := &IRepositoryMockGetReportJobParams{ctx, id}
+
+	// Record call args
+	mmGetReportJob.GetReportJobMock.mutex.Lock()
+	mmGetReportJob.GetReportJobMock.callArgs = append(mmGetReportJob.GetReportJobMock.callArgs, mm_params)
+	mmGetReportJob.GetReportJobMock.mutex.Unlock()
+
+	for _, e := range mmGetReportJob.GetReportJobMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.rp1, e.results.err
+		}
+	}
+
+	if mmGetReportJob.GetReportJobMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetReportJob.GetReportJobMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetReportJob.GetReportJobMock.defaultExpectation.params
+		mm_got := IRepositoryMockGetReportJobParams{ctx, id}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetReportJob.t.Errorf("IRepositoryMock.GetReportJob got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetReportJob.GetReportJobMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetReportJob.t.Fatal("No results are set for the IRepositoryMock.GetReportJob")
+		}
+		return (*mm_results).rp1, (*mm_results).err
+	}
+	if mmGetReportJob.funcGetReportJob != nil {
+		return mmGetReportJob.funcGetReportJob(ctx, id)
+	}
+	mmGetReportJob.t.Fatalf("Unexpected call to IRepositoryMock.GetReportJob. %v %v", ctx, id)
+	return
+}
+
+// GetReportJobAfterCounter returns a count of finished IRepositoryMock.GetReportJob invocations
+func (mmGetReportJob *IRepositoryMock) GetReportJobAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReportJob.afterGetReportJobCounter)
+}
+
+// GetReportJobBeforeCounter returns a count of IRepositoryMock.GetReportJob invocations
+func (mmGetReportJob *IRepositoryMock) GetReportJobBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReportJob.beforeGetReportJobCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.GetReportJob.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetReportJob *mIRepositoryMockGetReportJob) Calls() []*IRepositoryMockGetReportJobParams {
+	mmGetReportJob.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockGetReportJobParams, len(mmGetReportJob.callArgs))
+	copy(argCopy, mmGetReportJob.callArgs)
+
+	mmGetReportJob.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetReportJobDone returns true if the count of the GetReportJob invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockGetReportJobDone() bool {
+	for _, e := range m.GetReportJobMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetReportJobCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetReportJob != nil && mm_atomic.LoadUint64(&m.afterGetReportJobCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetReportJobInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockGetReportJobInspect() {
+	for _, e := range m.GetReportJobMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.GetReportJob with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetReportJobMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetReportJobCounter) < 1 {
+		if m.GetReportJobMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.GetReportJob")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.GetReportJob with params: %#v", *m.GetReportJobMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetReportJob != nil && mm_atomic.LoadUint64(&m.afterGetReportJobCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.GetReportJob")
+	}
+}
+
+type mIRepositoryMockGetService struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockGetServiceExpectation
+	expectations       []*IRepositoryMockGetServiceExpectation
+
+	callArgs []*IRepositoryMockGetServiceParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockGetServiceExpectation specifies expectation struct of the IRepository.GetService
+type IRepositoryMockGetServiceExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockGetServiceParams
+	results *IRepositoryMockGetServiceResults
+	Counter uint64
+}
+
+// IRepositoryMockGetServiceParams contains parameters of the IRepository.GetService
+type IRepositoryMockGetServiceParams struct {
+	ctx context.Context
+	id  uuid.UUID
+}
+
+// IRepositoryMockGetServiceResults contains results of the IRepository.GetService
+type IRepositoryMockGetServiceResults struct {
+	sp1 *model.Service
+	err error
+}
+
+// Expect sets up expected params for IRepository.GetService
+func (mmGetService *mIRepositoryMockGetService) Expect(ctx context.Context, id uuid.UUID) *mIRepositoryMockGetService {
+	if mmGetService.mock.funcGetService != nil {
+		mmGetService.mock.t.Fatalf("IRepositoryMock.GetService mock is already set by Set")
+	}
+
+	if mmGetService.defaultExpectation == nil {
+		mmGetService.defaultExpectation = &IRepositoryMockGetServiceExpectation{}
+	}
+
+	mmGetService.defaultExpectation.params = &IRepositoryMockGetServiceParams{ctx, id}
+	for _, e := range mmGetService.expectations {
+		if minimock.Equal(e.params, mmGetService.defaultExpectation.params) {
+			mmGetService.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetService.defaultExpectation.params)
+		}
+	}
+
+	return mmGetService
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.GetService
+func (mmGetService *mIRepositoryMockGetService) Inspect(f func(ctx context.Context, id uuid.UUID)) *mIRepositoryMockGetService {
+	if mmGetService.mock.inspectFuncGetService != nil {
+		mmGetService.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.GetService")
+	}
+
+	mmGetService.mock.inspectFuncGetService = f
+
+	return mmGetService
+}
+
+// Return sets up results that will be returned by IRepository.GetService
+func (mmGetService *mIRepositoryMockGetService) Return(sp1 *model.Service, err error) *IRepositoryMock {
+	if mmGetService.mock.funcGetService != nil {
+		mmGetService.mock.t.Fatalf("IRepositoryMock.GetService mock is already set by Set")
+	}
+
+	if mmGetService.defaultExpectation == nil {
+		mmGetService.defaultExpectation = &IRepositoryMockGetServiceExpectation{mock: mmGetService.mock}
+	}
+	mmGetService.defaultExpectation.results = &IRepositoryMockGetServiceResults{sp1, err}
+	return mmGetService.mock
+}
+
+// Set uses given function f to mock the IRepository.GetService method
+func (mmGetService *mIRepositoryMockGetService) Set(f func(ctx context.Context, id uuid.UUID) (sp1 *model.Service, err error)) *IRepositoryMock {
+	if mmGetService.defaultExpectation != nil {
+		mmGetService.mock.t.Fatalf("Default expectation is already set for the IRepository.GetService method")
+	}
+
+	if len(mmGetService.expectations) > 0 {
+		mmGetService.mock.t.Fatalf("Some expectations are already set for the IRepository.GetService method")
+	}
+
+	mmGetService.mock.funcGetService = f
+	return mmGetService.mock
+}
+
+// When sets expectation for the IRepository.GetService which will trigger the result defined by the following
+// Then helper
+func (mmGetService *mIRepositoryMockGetService) When(ctx context.Context, id uuid.UUID) *IRepositoryMockGetServiceExpectation {
+	if mmGetService.mock.funcGetService != nil {
+		mmGetService.mock.t.Fatalf("IRepositoryMock.GetService mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockGetServiceExpectation{
+		mock:   mmGetService.mock,
+		params: &IRepositoryMockGetServiceParams{ctx, id},
+	}
+	mmGetService.expectations = append(mmGetService.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.GetService return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockGetServiceExpectation) Then(sp1 *model.Service, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockGetServiceResults{sp1, err}
+	return e.mock
+}
+
+// GetService implements IRepository
+func (mmGetService *IRepositoryMock) GetService(ctx context.Context, id uuid.UUID) (sp1 *model.Service, err error) {
+	mm_atomic.AddUint64(&mmGetService.beforeGetServiceCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetService.afterGetServiceCounter, 1)
+
+	if mmGetService.inspectFuncGetService != nil {
+		mmGetService.inspectFuncGetService(ctx, id)
+	}
+
+	mm_params := &IRepositoryMockGetServiceParams{ctx, id}
+
+	// Record call args
+	mmGetService.GetServiceMock.mutex.Lock()
+	mmGetService.GetServiceMock.callArgs = append(mmGetService.GetServiceMock.callArgs, mm_params)
+	mmGetService.GetServiceMock.mutex.Unlock()
+
+	for _, e := range mmGetService.GetServiceMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sp1, e.results.err
+		}
+	}
+
+	if mmGetService.GetServiceMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetService.GetServiceMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetService.GetServiceMock.defaultExpectation.params
+		mm_got := IRepositoryMockGetServiceParams{ctx, id}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetService.t.Errorf("IRepositoryMock.GetService got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetService.GetServiceMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetService.t.Fatal("No results are set for the IRepositoryMock.GetService")
+		}
+		return (*mm_results).sp1, (*mm_results).err
+	}
+	if mmGetService.funcGetService != nil {
+		return mmGetService.funcGetService(ctx, id)
+	}
+	mmGetService.t.Fatalf("Unexpected call to IRepositoryMock.GetService. %v %v", ctx, id)
+	return
+}
+
+// GetServiceAfterCounter returns a count of finished IRepositoryMock.GetService invocations
+func (mmGetService *IRepositoryMock) GetServiceAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetService.afterGetServiceCounter)
+}
+
+// GetServiceBeforeCounter returns a count of IRepositoryMock.GetService invocations
+func (mmGetService *IRepositoryMock) GetServiceBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetService.beforeGetServiceCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.GetService.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetService *mIRepositoryMockGetService) Calls() []*IRepositoryMockGetServiceParams {
+	mmGetService.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockGetServiceParams, len(mmGetService.callArgs))
+	copy(argCopy, mmGetService.callArgs)
+
+	mmGetService.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetServiceDone returns true if the count of the GetService invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockGetServiceDone() bool {
+	for _, e := range m.GetServiceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetServiceCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetService != nil && mm_atomic.LoadUint64(&m.afterGetServiceCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetServiceInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockGetServiceInspect() {
+	for _, e := range m.GetServiceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.GetService with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetServiceCounter) < 1 {
+		if m.GetServiceMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.GetService")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.GetService with params: %#v", *m.GetServiceMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetService != nil && mm_atomic.LoadUint64(&m.afterGetServiceCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.GetService")
+	}
+}
+
+type mIRepositoryMockHistory struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockHistoryExpectation
+	expectations       []*IRepositoryMockHistoryExpectation
+
+	callArgs []*IRepositoryMockHistoryParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockHistoryExpectation specifies expectation struct of the IRepository.History
+type IRepositoryMockHistoryExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockHistoryParams
+	results *IRepositoryMockHistoryResults
+	Counter uint64
+}
+
+// IRepositoryMockHistoryParams contains parameters of the IRepository.History
+type IRepositoryMockHistoryParams struct {
+	ctx context.Context
+	q   model.HistoryQuery
+}
+
+// IRepositoryMockHistoryResults contains results of the IRepository.History
+type IRepositoryMockHistoryResults struct {
+	hp1 *model.HistoryPage
+	err error
+}
+
+// Expect sets up expected params for IRepository.History
+func (mmHistory *mIRepositoryMockHistory) Expect(ctx context.Context, q model.HistoryQuery) *mIRepositoryMockHistory {
+	if mmHistory.mock.funcHistory != nil {
+		mmHistory.mock.t.Fatalf("IRepositoryMock.History mock is already set by Set")
+	}
+
+	if mmHistory.defaultExpectation == nil {
+		mmHistory.defaultExpectation = &IRepositoryMockHistoryExpectation{}
+	}
+
+	mmHistory.defaultExpectation.params = &IRepositoryMockHistoryParams{ctx, q}
+	for _, e := range mmHistory.expectations {
+		if minimock.Equal(e.params, mmHistory.defaultExpectation.params) {
+			mmHistory.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHistory.defaultExpectation.params)
+		}
+	}
+
+	return mmHistory
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.History
+func (mmHistory *mIRepositoryMockHistory) Inspect(f func(ctx context.Context, q model.HistoryQuery)) *mIRepositoryMockHistory {
+	if mmHistory.mock.inspectFuncHistory != nil {
+		mmHistory.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.History")
+	}
+
+	mmHistory.mock.inspectFuncHistory = f
+
+	return mmHistory
+}
+
+// Return sets up results that will be returned by IRepository.History
+func (mmHistory *mIRepositoryMockHistory) Return(hp1 *model.HistoryPage, err error) *IRepositoryMock {
+	if mmHistory.mock.funcHistory != nil {
+		mmHistory.mock.t.Fatalf("IRepositoryMock.History mock is already set by Set")
+	}
+
+	if mmHistory.defaultExpectation == nil {
+		mmHistory.defaultExpectation = &IRepositoryMockHistoryExpectation{mock: mmHistory.mock}
+	}
+	mmHistory.defaultExpectation.results = &IRepositoryMockHistoryResults{hp1, err}
+	return mmHistory.mock
+}
+
+// Set uses given function f to mock the IRepository.History method
+func (mmHistory *mIRepositoryMockHistory) Set(f func(ctx context.Context, q model.HistoryQuery) (hp1 *model.HistoryPage, err error)) *IRepositoryMock {
+	if mmHistory.defaultExpectation != nil {
+		mmHistory.mock.t.Fatalf("Default expectation is already set for the IRepository.History method")
+	}
+
+	if len(mmHistory.expectations) > 0 {
+		mmHistory.mock.t.Fatalf("Some expectations are already set for the IRepository.History method")
+	}
+
+	mmHistory.mock.funcHistory = f
+	return mmHistory.mock
+}
+
+// When sets expectation for the IRepository.History which will trigger the result defined by the following
+// Then helper
+func (mmHistory *mIRepositoryMockHistory) When(ctx context.Context, q model.HistoryQuery) *IRepositoryMockHistoryExpectation {
+	if mmHistory.mock.funcHistory != nil {
+		mmHistory.mock.t.Fatalf("IRepositoryMock.History mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockHistoryExpectation{
+		mock:   mmHistory.mock,
+		params: &IRepositoryMockHistoryParams{ctx, q},
+	}
+	mmHistory.expectations = append(mmHistory.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.History return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockHistoryExpectation) Then(hp1 *model.HistoryPage, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockHistoryResults{hp1, err}
+	return e.mock
+}
+
+// History implements IRepository
+func (mmHistory *IRepositoryMock) History(ctx context.Context, q model.HistoryQuery) (hp1 *model.HistoryPage, err error) {
+	mm_atomic.AddUint64(&mmHistory.beforeHistoryCounter, 1)
+	defer mm_atomic.AddUint64(&mmHistory.afterHistoryCounter, 1)
+
+	if mmHistory.inspectFuncHistory != nil {
+		mmHistory.inspectFuncHistory(ctx, q)
+	}
+
+	mm_params := &IRepositoryMockHistoryParams{ctx, q}
+
+	// Record call args
+	mmHistory.HistoryMock.mutex.Lock()
+	mmHistory.HistoryMock.callArgs = append(mmHistory.HistoryMock.callArgs, mm_params)
+	mmHistory.HistoryMock.mutex.Unlock()
+
+	for _, e := range mmHistory.HistoryMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.hp1, e.results.err
+		}
+	}
+
+	if mmHistory.HistoryMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHistory.HistoryMock.defaultExpectation.Counter, 1)
+		mm_want := mmHistory.HistoryMock.defaultExpectation.params
+		mm_got := IRepositoryMockHistoryParams{ctx, q}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHistory.t.Errorf("IRepositoryMock.History got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHistory.HistoryMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHistory.t.Fatal("No results are set for the IRepositoryMock.History")
+		}
+		return (*mm_results).hp1, (*mm_results).err
+	}
+	if mmHistory.funcHistory != nil {
+		return mmHistory.funcHistory(ctx, q)
+	}
+	mmHistory.t.Fatalf("Unexpected call to IRepositoryMock.History. %v %v", ctx, q)
+	return
+}
+
+// HistoryAfterCounter returns a count of finished IRepositoryMock.History invocations
+func (mmHistory *IRepositoryMock) HistoryAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHistory.afterHistoryCounter)
+}
+
+// HistoryBeforeCounter returns a count of IRepositoryMock.History invocations
+func (mmHistory *IRepositoryMock) HistoryBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHistory.beforeHistoryCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.History.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHistory *mIRepositoryMockHistory) Calls() []*IRepositoryMockHistoryParams {
+	mmHistory.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockHistoryParams, len(mmHistory.callArgs))
+	copy(argCopy, mmHistory.callArgs)
+
+	mmHistory.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHistoryDone returns true if the count of the History invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockHistoryDone() bool {
+	for _, e := range m.HistoryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HistoryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHistory != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockHistoryInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockHistoryInspect() {
+	for _, e := range m.HistoryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.History with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HistoryMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
+		if m.HistoryMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.History")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.History with params: %#v", *m.HistoryMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHistory != nil && mm_atomic.LoadUint64(&m.afterHistoryCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.History")
+	}
+}
+
+type mIRepositoryMockOrder struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockOrderExpectation
+	expectations       []*IRepositoryMockOrderExpectation
+
+	callArgs []*IRepositoryMockOrderParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockOrderExpectation specifies expectation struct of the IRepository.Order
+type IRepositoryMockOrderExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockOrderParams
+	results *IRepositoryMockOrderResults
+	Counter uint64
+}
+
+// IRepositoryMockOrderParams contains parameters of the IRepository.Order
+type IRepositoryMockOrderParams struct {
+	ctx   context.Context
+	order model.Order
+}
+
+// IRepositoryMockOrderResults contains results of the IRepository.Order
+type IRepositoryMockOrderResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.Order
+func (mmOrder *mIRepositoryMockOrder) Expect(ctx context.Context, order model.Order) *mIRepositoryMockOrder {
+	if mmOrder.mock.funcOrder != nil {
+		mmOrder.mock.t.Fatalf("IRepositoryMock.Order mock is already set by Set")
+	}
+
+	if mmOrder.defaultExpectation == nil {
+		mmOrder.defaultExpectation = &IRepositoryMockOrderExpectation{}
+	}
+
+	mmOrder.defaultExpectation.params = &IRepositoryMockOrderParams{ctx, order}
+	for _, e := range mmOrder.expectations {
+		if minimock.Equal(e.params, mmOrder.defaultExpectation.params) {
+			mmOrder.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmOrder.defaultExpectation.params)
+		}
+	}
+
+	return mmOrder
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.Order
+func (mmOrder *mIRepositoryMockOrder) Inspect(f func(ctx context.Context, order model.Order)) *mIRepositoryMockOrder {
+	if mmOrder.mock.inspectFuncOrder != nil {
+		mmOrder.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Order")
+	}
+
+	mmOrder.mock.inspectFuncOrder = f
+
+	return mmOrder
+}
+
+// Return sets up results that will be returned by IRepository.Order
+func (mmOrder *mIRepositoryMockOrder) Return(err error) *IRepositoryMock {
+	if mmOrder.mock.funcOrder != nil {
+		mmOrder.mock.t.Fatalf("IRepositoryMock.Order mock is already set by Set")
+	}
+
+	if mmOrder.defaultExpectation == nil {
+		mmOrder.defaultExpectation = &IRepositoryMockOrderExpectation{mock: mmOrder.mock}
+	}
+	mmOrder.defaultExpectation.results = &IRepositoryMockOrderResults{err}
+	return mmOrder.mock
+}
+
+// Set uses given function f to mock the IRepository.Order method
+func (mmOrder *mIRepositoryMockOrder) Set(f func(ctx context.Context, order model.Order) (err error)) *IRepositoryMock {
+	if mmOrder.defaultExpectation != nil {
+		mmOrder.mock.t.Fatalf("Default expectation is already set for the IRepository.Order method")
+	}
+
+	if len(mmOrder.expectations) > 0 {
+		mmOrder.mock.t.Fatalf("Some expectations are already set for the IRepository.Order method")
+	}
+
+	mmOrder.mock.funcOrder = f
+	return mmOrder.mock
+}
+
+// When sets expectation for the IRepository.Order which will trigger the result defined by the following
+// Then helper
+func (mmOrder *mIRepositoryMockOrder) When(ctx context.Context, order model.Order) *IRepositoryMockOrderExpectation {
+	if mmOrder.mock.funcOrder != nil {
+		mmOrder.mock.t.Fatalf("IRepositoryMock.Order mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockOrderExpectation{
+		mock:   mmOrder.mock,
+		params: &IRepositoryMockOrderParams{ctx, order},
+	}
+	mmOrder.expectations = append(mmOrder.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.Order return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockOrderExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockOrderResults{err}
+	return e.mock
+}
+
+// Order implements IRepository
+func (mmOrder *IRepositoryMock) Order(ctx context.Context, order model.Order) (err error) {
+	mm_atomic.AddUint64(&mmOrder.beforeOrderCounter, 1)
+	defer mm_atomic.AddUint64(&mmOrder.afterOrderCounter, 1)
+
+	if mmOrder.inspectFuncOrder != nil {
+		mmOrder.inspectFuncOrder(ctx, order)
+	}
+
+	mm_params := &IRepositoryMockOrderParams{ctx, order}
+
+	// Record call args
+	mmOrder.OrderMock.mutex.Lock()
+	mmOrder.OrderMock.callArgs = append(mmOrder.OrderMock.callArgs, mm_params)
+	mmOrder.OrderMock.mutex.Unlock()
+
+	for _, e := range mmOrder.OrderMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmOrder.OrderMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmOrder.OrderMock.defaultExpectation.Counter, 1)
+		mm_want := mmOrder.OrderMock.defaultExpectation.params
+		mm_got := IRepositoryMockOrderParams{ctx, order}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmOrder.t.Errorf("IRepositoryMock.Order got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmOrder.OrderMock.defaultExpectation.results
+		if mm_results == nil {
+			mmOrder.t.Fatal("No results are set for the IRepositoryMock.Order")
+		}
+		return (*mm_results).err
+	}
+	if mmOrder.funcOrder != nil {
+		return mmOrder.funcOrder(ctx, order)
+	}
+	mmOrder.t.Fatalf("Unexpected call to IRepositoryMock.Order. %v %v", ctx, order)
+	return
+}
+
+// OrderAfterCounter returns a count of finished IRepositoryMock.Order invocations
+func (mmOrder *IRepositoryMock) OrderAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmOrder.afterOrderCounter)
+}
+
+// OrderBeforeCounter returns a count of IRepositoryMock.Order invocations
+func (mmOrder *IRepositoryMock) OrderBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmOrder.beforeOrderCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.Order.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmOrder *mIRepositoryMockOrder) Calls() []*IRepositoryMockOrderParams {
+	mmOrder.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockOrderParams, len(mmOrder.callArgs))
+	copy(argCopy, mmOrder.callArgs)
+
+	mmOrder.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockOrderDone returns true if the count of the Order invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockOrderDone() bool {
+	for _, e := range m.OrderMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.OrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcOrder != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockOrderInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockOrderInspect() {
+	for _, e := range m.OrderMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.Order with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.OrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
+		if m.OrderMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Order")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.Order with params: %#v", *m.OrderMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcOrder != nil && mm_atomic.LoadUint64(&m.afterOrderCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Order")
+	}
+}
+
+type mIRepositoryMockOrderSuccess struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockOrderSuccessExpectation
+	expectations       []*IRepositoryMockOrderSuccessExpectation
+
+	callArgs []*IRepositoryMockOrderSuccessParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockOrderSuccessExpectation specifies expectation struct of the IRepository.OrderSuccess
+type IRepositoryMockOrderSuccessExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockOrderSuccessParams
+	results *IRepositoryMockOrderSuccessResults
+	Counter uint64
+}
+
+// IRepositoryMockOrderSuccessParams contains parameters of the IRepository.OrderSuccess
+type IRepositoryMockOrderSuccessParams struct {
+	ctx      context.Context
+	order    model.Order
+	captured model.Money
+	fee      model.Money
+	date     time.Time
+}
+
+// IRepositoryMockOrderSuccessResults contains results of the IRepository.OrderSuccess
+type IRepositoryMockOrderSuccessResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.OrderSuccess
+func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Expect(ctx context.Context, order model.Order, captured model.Money, fee model.Money, date time.Time) *mIRepositoryMockOrderSuccess {
+	if mmOrderSuccess.mock.funcOrderSuccess != nil {
+		mmOrderSuccess.mock.t.Fatalf("IRepositoryMock.OrderSuccess mock is already set by Set")
+	}
+
+	if mmOrderSuccess.defaultExpectation == nil {
+		mmOrderSuccess.defaultExpectation = &IRepositoryMockOrderSuccessExpectation{}
+	}
+
+	mmOrderSuccess.defaultExpectation.params = &IRepositoryMockOrderSuccessParams{ctx, order, captured, fee, date}
+	for _, e := range mmOrderSuccess.expectations {
+		if minimock.Equal(e.params, mmOrderSuccess.defaultExpectation.params) {
+			mmOrderSuccess.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmOrderSuccess.defaultExpectation.params)
+		}
+	}
+
+	return mmOrderSuccess
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.OrderSuccess
+func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Inspect(f func(ctx context.Context, order model.Order, captured model.Money, fee model.Money, date time.Time)) *mIRepositoryMockOrderSuccess {
+	if mmOrderSuccess.mock.inspectFuncOrderSuccess != nil {
+		mmOrderSuccess.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.OrderSuccess")
+	}
+
+	mmOrderSuccess.mock.inspectFuncOrderSuccess = f
+
+	return mmOrderSuccess
+}
+
+// Return sets up results that will be returned by IRepository.OrderSuccess
+func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Return(err error) *IRepositoryMock {
+	if mmOrderSuccess.mock.funcOrderSuccess != nil {
+		mmOrderSuccess.mock.t.Fatalf("IRepositoryMock.OrderSuccess mock is already set by Set")
+	}
+
+	if mmOrderSuccess.defaultExpectation == nil {
+		mmOrderSuccess.defaultExpectation = &IRepositoryMockOrderSuccessExpectation{mock: mmOrderSuccess.mock}
+	}
+	mmOrderSuccess.defaultExpectation.results = &IRepositoryMockOrderSuccessResults{err}
+	return mmOrderSuccess.mock
+}
+
+// Set uses given function f to mock the IRepository.OrderSuccess method
+func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Set(f func(ctx context.Context, order model.Order, captured model.Money, fee model.Money, date time.Time) (err error)) *IRepositoryMock {
+	if mmOrderSuccess.defaultExpectation != nil {
+		mmOrderSuccess.mock.t.Fatalf("Default expectation is already set for the IRepository.OrderSuccess method")
+	}
+
+	if len(mmOrderSuccess.expectations) > 0 {
+		mmOrderSuccess.mock.t.Fatalf("Some expectations are already set for the IRepository.OrderSuccess method")
+	}
+
+	mmOrderSuccess.mock.funcOrderSuccess = f
+	return mmOrderSuccess.mock
+}
+
+// When sets expectation for the IRepository.OrderSuccess which will trigger the result defined by the following
+// Then helper
+func (mmOrderSuccess *mIRepositoryMockOrderSuccess) When(ctx context.Context, order model.Order, captured model.Money, fee model.Money, date time.Time) *IRepositoryMockOrderSuccessExpectation {
+	if mmOrderSuccess.mock.funcOrderSuccess != nil {
+		mmOrderSuccess.mock.t.Fatalf("IRepositoryMock.OrderSuccess mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockOrderSuccessExpectation{
+		mock:   mmOrderSuccess.mock,
+		params: &IRepositoryMockOrderSuccessParams{ctx, order, captured, fee, date},
+	}
+	mmOrderSuccess.expectations = append(mmOrderSuccess.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.OrderSuccess return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockOrderSuccessExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockOrderSuccessResults{err}
+	return e.mock
+}
+
+// OrderSuccess implements IRepository
+func (mmOrderSuccess *IRepositoryMock) OrderSuccess(ctx context.Context, order model.Order, captured model.Money, fee model.Money, date time.Time) (err error) {
+	mm_atomic.AddUint64(&mmOrderSuccess.beforeOrderSuccessCounter, 1)
+	defer mm_atomic.AddUint64(&mmOrderSuccess.afterOrderSuccessCounter, 1)
+
+	if mmOrderSuccess.inspectFuncOrderSuccess != nil {
+		mmOrderSuccess.inspectFuncOrderSuccess(ctx, order, captured, fee, date)
+	}
+
+	mm_params := &IRepositoryMockOrderSuccessParams{ctx, order, captured, fee, date}
+
+	// Record call args
+	mmOrderSuccess.OrderSuccessMock.mutex.Lock()
+	mmOrderSuccess.OrderSuccessMock.callArgs = append(mmOrderSuccess.OrderSuccessMock.callArgs, mm_params)
+	mmOrderSuccess.OrderSuccessMock.mutex.Unlock()
+
+	for _, e := range mmOrderSuccess.OrderSuccessMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmOrderSuccess.OrderSuccessMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmOrderSuccess.OrderSuccessMock.defaultExpectation.Counter, 1)
+		mm_want := mmOrderSuccess.OrderSuccessMock.defaultExpectation.params
+		mm_got := IRepositoryMockOrderSuccessParams{ctx, order, captured, fee, date}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmOrderSuccess.t.Errorf("IRepositoryMock.OrderSuccess got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmOrderSuccess.OrderSuccessMock.defaultExpectation.results
+		if mm_results == nil {
+			mmOrderSuccess.t.Fatal("No results are set for the IRepositoryMock.OrderSuccess")
+		}
+		return (*mm_results).err
+	}
+	if mmOrderSuccess.funcOrderSuccess != nil {
+		return mmOrderSuccess.funcOrderSuccess(ctx, order, captured, fee, date)
+	}
+	mmOrderSuccess.t.Fatalf("Unexpected call to IRepositoryMock.OrderSuccess. %v %v %v %v %v", ctx, order, captured, fee, date)
+	return
+}
+
+// OrderSuccessAfterCounter returns a count of finished IRepositoryMock.OrderSuccess invocations
+func (mmOrderSuccess *IRepositoryMock) OrderSuccessAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmOrderSuccess.afterOrderSuccessCounter)
+}
+
+// OrderSuccessBeforeCounter returns a count of IRepositoryMock.OrderSuccess invocations
+func (mmOrderSuccess *IRepositoryMock) OrderSuccessBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmOrderSuccess.beforeOrderSuccessCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.OrderSuccess.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmOrderSuccess *mIRepositoryMockOrderSuccess) Calls() []*IRepositoryMockOrderSuccessParams {
+	mmOrderSuccess.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockOrderSuccessParams, len(mmOrderSuccess.callArgs))
+	copy(argCopy, mmOrderSuccess.callArgs)
+
+	mmOrderSuccess.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockOrderSuccessDone returns true if the count of the OrderSuccess invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockOrderSuccessDone() bool {
+	for _, e := range m.OrderSuccessMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.OrderSuccessMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcOrderSuccess != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockOrderSuccessInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockOrderSuccessInspect() {
+	for _, e := range m.OrderSuccessMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.OrderSuccess with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.OrderSuccessMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
+		if m.OrderSuccessMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.OrderSuccess")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.OrderSuccess with params: %#v", *m.OrderSuccessMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcOrderSuccess != nil && mm_atomic.LoadUint64(&m.afterOrderSuccessCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.OrderSuccess")
+	}
+}
+
+type mIRepositoryMockPing struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockPingExpectation
+	expectations       []*IRepositoryMockPingExpectation
+
+	callArgs []*IRepositoryMockPingParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockPingExpectation specifies expectation struct of the IRepository.Ping
+type IRepositoryMockPingExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockPingParams
+	results *IRepositoryMockPingResults
+	Counter uint64
+}
+
+// IRepositoryMockPingParams contains parameters of the IRepository.Ping
+type IRepositoryMockPingParams struct {
+	ctx context.Context
+}
+
+// IRepositoryMockPingResults contains results of the IRepository.Ping
+type IRepositoryMockPingResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.Ping
+func (mmPing *mIRepositoryMockPing) Expect(ctx context.Context) *mIRepositoryMockPing {
+	if mmPing.mock.funcPing != nil {
+		mmPing.mock.t.Fatalf("IRepositoryMock.Ping mock is already set by Set")
+	}
+
+	if mmPing.defaultExpectation == nil {
+		mmPing.defaultExpectation = &IRepositoryMockPingExpectation{}
+	}
+
+	mmPing.defaultExpectation.params = &IRepositoryMockPingParams{ctx}
+	for _, e := range mmPing.expectations {
+		if minimock.Equal(e.params, mmPing.defaultExpectation.params) {
+			mmPing.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmPing.defaultExpectation.params)
+		}
+	}
+
+	return mmPing
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.Ping
+func (mmPing *mIRepositoryMockPing) Inspect(f func(ctx context.Context)) *mIRepositoryMockPing {
+	if mmPing.mock.inspectFuncPing != nil {
+		mmPing.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Ping")
+	}
+
+	mmPing.mock.inspectFuncPing = f
+
+	return mmPing
+}
+
+// Return sets up results that will be returned by IRepository.Ping
+func (mmPing *mIRepositoryMockPing) Return(err error) *IRepositoryMock {
+	if mmPing.mock.funcPing != nil {
+		mmPing.mock.t.Fatalf("IRepositoryMock.Ping mock is already set by Set")
+	}
+
+	if mmPing.defaultExpectation == nil {
+		mmPing.defaultExpectation = &IRepositoryMockPingExpectation{mock: mmPing.mock}
+	}
+	mmPing.defaultExpectation.results = &IRepositoryMockPingResults{err}
+	return mmPing.mock
+}
+
+// Set uses given function f to mock the IRepository.Ping method
+func (mmPing *mIRepositoryMockPing) Set(f func(ctx context.Context) (err error)) *IRepositoryMock {
+	if mmPing.defaultExpectation != nil {
+		mmPing.mock.t.Fatalf("Default expectation is already set for the IRepository.Ping method")
+	}
+
+	if len(mmPing.expectations) > 0 {
+		mmPing.mock.t.Fatalf("Some expectations are already set for the IRepository.Ping method")
+	}
+
+	mmPing.mock.funcPing = f
+	return mmPing.mock
+}
+
+// When sets expectation for the IRepository.Ping which will trigger the result defined by the following
+// Then helper
+func (mmPing *mIRepositoryMockPing) When(ctx context.Context) *IRepositoryMockPingExpectation {
+	if mmPing.mock.funcPing != nil {
+		mmPing.mock.t.Fatalf("IRepositoryMock.Ping mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockPingExpectation{
+		mock:   mmPing.mock,
+		params: &IRepositoryMockPingParams{ctx},
+	}
+	mmPing.expectations = append(mmPing.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.Ping return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockPingExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockPingResults{err}
+	return e.mock
+}
+
+// Ping implements IRepository
+func (mmPing *IRepositoryMock) Ping(ctx context.Context) (err error) {
+	mm_atomic.AddUint64(&mmPing.beforePingCounter, 1)
+	defer mm_atomic.AddUint64(&mmPing.afterPingCounter, 1)
+
+	if mmPing.inspectFuncPing != nil {
+		mmPing.inspectFuncPing(ctx)
+	}
+
+	mm_params := &IRepositoryMockPingParams{ctx}
+
+	// Record call args
+	mmPing.PingMock.mutex.Lock()
+	mmPing.PingMock.callArgs = append(mmPing.PingMock.callArgs, mm_params)
+	mmPing.PingMock.mutex.Unlock()
+
+	for _, e := range mmPing.PingMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmPing.PingMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmPing.PingMock.defaultExpectation.Counter, 1)
+		mm_want := mmPing.PingMock.defaultExpectation.params
+		mm_got := IRepositoryMockPingParams{ctx}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmPing.t.Errorf("IRepositoryMock.Ping got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmPing.PingMock.defaultExpectation.results
+		if mm_results == nil {
+			mmPing.t.Fatal("No results are set for the IRepositoryMock.Ping")
+		}
+		return (*mm_results).err
+	}
+	if mmPing.funcPing != nil {
+		return mmPing.funcPing(ctx)
+	}
+	mmPing.t.Fatalf("Unexpected call to IRepositoryMock.Ping. %v", ctx)
+	return
+}
+
+// PingAfterCounter returns a count of finished IRepositoryMock.Ping invocations
+func (mmPing *IRepositoryMock) PingAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPing.afterPingCounter)
+}
+
+// PingBeforeCounter returns a count of IRepositoryMock.Ping invocations
+func (mmPing *IRepositoryMock) PingBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPing.beforePingCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.Ping.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmPing *mIRepositoryMockPing) Calls() []*IRepositoryMockPingParams {
+	mmPing.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockPingParams, len(mmPing.callArgs))
+	copy(argCopy, mmPing.callArgs)
+
+	mmPing.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockPingDone returns true if the count of the Ping invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockPingDone() bool {
+	for _, e := range m.PingMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.PingMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterPingCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcPing != nil && mm_atomic.LoadUint64(&m.afterPingCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockPingInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockPingInspect() {
+	for _, e := range m.PingMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.Ping with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.PingMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterPingCounter) < 1 {
+		if m.PingMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Ping")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.Ping with params: %#v", *m.PingMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcPing != nil && mm_atomic.LoadUint64(&m.afterPingCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Ping")
+	}
+}
+
+type mIRepositoryMockRefund struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockRefundExpectation
+	expectations       []*IRepositoryMockRefundExpectation
+
+	callArgs []*IRepositoryMockRefundParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockRefundExpectation specifies expectation struct of the IRepository.Refund
+type IRepositoryMockRefundExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockRefundParams
+	results *IRepositoryMockRefundResults
+	Counter uint64
+}
+
+// IRepositoryMockRefundParams contains parameters of the IRepository.Refund
+type IRepositoryMockRefundParams struct {
+	ctx     context.Context
+	orderID uuid.UUID
+	amount  model.Money
+	date    time.Time
+}
+
+// IRepositoryMockRefundResults contains results of the IRepository.Refund
+type IRepositoryMockRefundResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.Refund
+func (mmRefund *mIRepositoryMockRefund) Expect(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) *mIRepositoryMockRefund {
+	if mmRefund.mock.funcRefund != nil {
+		mmRefund.mock.t.Fatalf("IRepositoryMock.Refund mock is already set by Set")
+	}
+
+	if mmRefund.defaultExpectation == nil {
+		mmRefund.defaultExpectation = &IRepositoryMockRefundExpectation{}
+	}
+
+	mmRefund.defaultExpectation.params = &IRepositoryMockRefundParams{ctx, orderID, amount, date}
+	for _, e := range mmRefund.expectations {
+		if minimock.Equal(e.params, mmRefund.defaultExpectation.params) {
+			mmRefund.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmRefund.defaultExpectation.params)
+		}
+	}
+
+	return mmRefund
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.Refund
+func (mmRefund *mIRepositoryMockRefund) Inspect(f func(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time)) *mIRepositoryMockRefund {
+	if mmRefund.mock.inspectFuncRefund != nil {
+		mmRefund.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Refund")
+	}
+
+	mmRefund.mock.inspectFuncRefund = f
+
+	return mmRefund
+}
+
+// Return sets up results that will be returned by IRepository.Refund
+func (mmRefund *mIRepositoryMockRefund) Return(err error) *IRepositoryMock {
+	if mmRefund.mock.funcRefund != nil {
+		mmRefund.mock.t.Fatalf("IRepositoryMock.Refund mock is already set by Set")
+	}
+
+	if mmRefund.defaultExpectation == nil {
+		mmRefund.defaultExpectation = &IRepositoryMockRefundExpectation{mock: mmRefund.mock}
+	}
+	mmRefund.defaultExpectation.results = &IRepositoryMockRefundResults{err}
+	return mmRefund.mock
+}
+
+// Set uses given function f to mock the IRepository.Refund method
+func (mmRefund *mIRepositoryMockRefund) Set(f func(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) (err error)) *IRepositoryMock {
+	if mmRefund.defaultExpectation != nil {
+		mmRefund.mock.t.Fatalf("Default expectation is already set for the IRepository.Refund method")
+	}
+
+	if len(mmRefund.expectations) > 0 {
+		mmRefund.mock.t.Fatalf("Some expectations are already set for the IRepository.Refund method")
+	}
+
+	mmRefund.mock.funcRefund = f
+	return mmRefund.mock
+}
+
+// When sets expectation for the IRepository.Refund which will trigger the result defined by the following
+// Then helper
+func (mmRefund *mIRepositoryMockRefund) When(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) *IRepositoryMockRefundExpectation {
+	if mmRefund.mock.funcRefund != nil {
+		mmRefund.mock.t.Fatalf("IRepositoryMock.Refund mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockRefundExpectation{
+		mock:   mmRefund.mock,
+		params: &IRepositoryMockRefundParams{ctx, orderID, amount, date},
+	}
+	mmRefund.expectations = append(mmRefund.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.Refund return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockRefundExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockRefundResults{err}
+	return e.mock
+}
+
+// Refund implements IRepository
+func (mmRefund *IRepositoryMock) Refund(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) (err error) {
+	mm_atomic.AddUint64(&mmRefund.beforeRefundCounter, 1)
+	defer mm_atomic.AddUint64(&mmRefund.afterRefundCounter, 1)
+
+	if mmRefund.inspectFuncRefund != nil {
+		mmRefund.inspectFuncRefund(ctx, orderID, amount, date)
+	}
+
+	mm_params := &IRepositoryMockRefundParams{ctx, orderID, amount, date}
+
+	// Record call args
+	mmRefund.RefundMock.mutex.Lock()
+	mmRefund.RefundMock.callArgs = append(mmRefund.RefundMock.callArgs, mm_params)
+	mmRefund.RefundMock.mutex.Unlock()
+
+	for _, e := range mmRefund.RefundMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmRefund.RefundMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmRefund.RefundMock.defaultExpectation.Counter, 1)
+		mm_want := mmRefund.RefundMock.defaultExpectation.params
+		mm_got := IRepositoryMockRefundParams{ctx, orderID, amount, date}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmRefund.t.Errorf("IRepositoryMock.Refund got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmRefund.RefundMock.defaultExpectation.results
+		if mm_results == nil {
+			mmRefund.t.Fatal("No results are set for the IRepositoryMock.Refund")
+		}
+		return (*mm_results).err
+	}
+	if mmRefund.funcRefund != nil {
+		return mmRefund.funcRefund(ctx, orderID, amount, date)
+	}
+	mmRefund.t.Fatalf("Unexpected call to IRepositoryMock.Refund. %v %v %v %v", ctx, orderID, amount, date)
+	return
+}
+
+// RefundAfterCounter returns a count of finished IRepositoryMock.Refund invocations
+func (mmRefund *IRepositoryMock) RefundAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRefund.afterRefundCounter)
+}
+
+// RefundBeforeCounter returns a count of IRepositoryMock.Refund invocations
+func (mmRefund *IRepositoryMock) RefundBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRefund.beforeRefundCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.Refund.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmRefund *mIRepositoryMockRefund) Calls() []*IRepositoryMockRefundParams {
+	mmRefund.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockRefundParams, len(mmRefund.callArgs))
+	copy(argCopy, mmRefund.callArgs)
+
+	mmRefund.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockRefundDone returns true if the count of the Refund invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockRefundDone() bool {
+	for _, e := range m.RefundMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.RefundMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterRefundCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcRefund != nil && mm_atomic.LoadUint64(&m.afterRefundCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockRefundInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockRefundInspect() {
+	for _, e := range m.RefundMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.Refund with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.RefundMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterRefundCounter) < 1 {
+		if m.RefundMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Refund")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.Refund with params: %#v", *m.RefundMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcRefund != nil && mm_atomic.LoadUint64(&m.afterRefundCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Refund")
+	}
+}
+
+type mIRepositoryMockReleaseOrder struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockReleaseOrderExpectation
+	expectations       []*IRepositoryMockReleaseOrderExpectation
+
+	callArgs []*IRepositoryMockReleaseOrderParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockReleaseOrderExpectation specifies expectation struct of the IRepository.ReleaseOrder
+type IRepositoryMockReleaseOrderExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockReleaseOrderParams
+	results *IRepositoryMockReleaseOrderResults
+	Counter uint64
+}
+
+// IRepositoryMockReleaseOrderParams contains parameters of the IRepository.ReleaseOrder
+type IRepositoryMockReleaseOrderParams struct {
+	ctx    context.Context
+	order  model.Order
+	status model.OrderStatus
+	date   time.Time
+}
+
+// IRepositoryMockReleaseOrderResults contains results of the IRepository.ReleaseOrder
+type IRepositoryMockReleaseOrderResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.ReleaseOrder
+func (mmReleaseOrder *mIRepositoryMockReleaseOrder) Expect(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) *mIRepositoryMockReleaseOrder {
+	if mmReleaseOrder.mock.funcReleaseOrder != nil {
+		mmReleaseOrder.mock.t.Fatalf("IRepositoryMock.ReleaseOrder mock is already set by Set")
+	}
+
+	if mmReleaseOrder.defaultExpectation == nil {
+		mmReleaseOrder.defaultExpectation = &IRepositoryMockReleaseOrderExpectation{}
+	}
+
+	mmReleaseOrder.defaultExpectation.params = &IRepositoryMockReleaseOrderParams{ctx, order, status, date}
+	for _, e := range mmReleaseOrder.expectations {
+		if minimock.Equal(e.params, mmReleaseOrder.defaultExpectation.params) {
+			mmReleaseOrder.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReleaseOrder.defaultExpectation.params)
+		}
+	}
+
+	return mmReleaseOrder
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.ReleaseOrder
+func (mmReleaseOrder *mIRepositoryMockReleaseOrder) Inspect(f func(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time)) *mIRepositoryMockReleaseOrder {
+	if mmReleaseOrder.mock.inspectFuncReleaseOrder != nil {
+		mmReleaseOrder.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.ReleaseOrder")
+	}
+
+	mmReleaseOrder.mock.inspectFuncReleaseOrder = f
+
+	return mmReleaseOrder
+}
+
+// Return sets up results that will be returned by IRepository.ReleaseOrder
+func (mmReleaseOrder *mIRepositoryMockReleaseOrder) Return(err error) *IRepositoryMock {
+	if mmReleaseOrder.mock.funcReleaseOrder != nil {
+		mmReleaseOrder.mock.t.Fatalf("IRepositoryMock.ReleaseOrder mock is already set by Set")
+	}
+
+	if mmReleaseOrder.defaultExpectation == nil {
+		mmReleaseOrder.defaultExpectation = &IRepositoryMockReleaseOrderExpectation{mock: mmReleaseOrder.mock}
+	}
+	mmReleaseOrder.defaultExpectation.results = &IRepositoryMockReleaseOrderResults{err}
+	return mmReleaseOrder.mock
+}
+
+// Set uses given function f to mock the IRepository.ReleaseOrder method
+func (mmReleaseOrder *mIRepositoryMockReleaseOrder) Set(f func(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) (err error)) *IRepositoryMock {
+	if mmReleaseOrder.defaultExpectation != nil {
+		mmReleaseOrder.mock.t.Fatalf("Default expectation is already set for the IRepository.ReleaseOrder method")
+	}
+
+	if len(mmReleaseOrder.expectations) > 0 {
+		mmReleaseOrder.mock.t.Fatalf("Some expectations are already set for the IRepository.ReleaseOrder method")
+	}
+
+	mmReleaseOrder.mock.funcReleaseOrder = f
+	return mmReleaseOrder.mock
+}
+
+// When sets expectation for the IRepository.ReleaseOrder which will trigger the result defined by the following
+// Then helper
+func (mmReleaseOrder *mIRepositoryMockReleaseOrder) When(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) *IRepositoryMockReleaseOrderExpectation {
+	if mmReleaseOrder.mock.funcReleaseOrder != nil {
+		mmReleaseOrder.mock.t.Fatalf("IRepositoryMock.ReleaseOrder mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockReleaseOrderExpectation{
+		mock:   mmReleaseOrder.mock,
+		params: &IRepositoryMockReleaseOrderParams{ctx, order, status, date},
+	}
+	mmReleaseOrder.expectations = append(mmReleaseOrder.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.ReleaseOrder return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockReleaseOrderExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockReleaseOrderResults{err}
+	return e.mock
+}
+
+// ReleaseOrder implements IRepository
+func (mmReleaseOrder *IRepositoryMock) ReleaseOrder(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) (err error) {
+	mm_atomic.AddUint64(&mmReleaseOrder.beforeReleaseOrderCounter, 1)
+	defer mm_atomic.AddUint64(&mmReleaseOrder.afterReleaseOrderCounter, 1)
+
+	if mmReleaseOrder.inspectFuncReleaseOrder != nil {
+		mmReleaseOrder.inspectFuncReleaseOrder(ctx, order, status, date)
+	}
+
+	mm_params := &IRepositoryMockReleaseOrderParams{ctx, order, status, date}
+
+	// Record call args
+	mmReleaseOrder.ReleaseOrderMock.mutex.Lock()
+	mmReleaseOrder.ReleaseOrderMock.callArgs = append(mmReleaseOrder.ReleaseOrderMock.callArgs, mm_params)
+	mmReleaseOrder.ReleaseOrderMock.mutex.Unlock()
+
+	for _, e := range mmReleaseOrder.ReleaseOrderMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmReleaseOrder.ReleaseOrderMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReleaseOrder.ReleaseOrderMock.defaultExpectation.Counter, 1)
+		mm_want := mmReleaseOrder.ReleaseOrderMock.defaultExpectation.params
+		mm_got := IRepositoryMockReleaseOrderParams{ctx, order, status, date}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmReleaseOrder.t.Errorf("IRepositoryMock.ReleaseOrder got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmReleaseOrder.ReleaseOrderMock.defaultExpectation.results
+		if mm_results == nil {
+			mmReleaseOrder.t.Fatal("No results are set for the IRepositoryMock.ReleaseOrder")
+		}
+		return (*mm_results).err
+	}
+	if mmReleaseOrder.funcReleaseOrder != nil {
+		return mmReleaseOrder.funcReleaseOrder(ctx, order, status, date)
+	}
+	mmReleaseOrder.t.Fatalf("Unexpected call to IRepositoryMock.ReleaseOrder. %v %v %v %v", ctx, order, status, date)
+	return
+}
+
+// ReleaseOrderAfterCounter returns a count of finished IRepositoryMock.ReleaseOrder invocations
+func (mmReleaseOrder *IRepositoryMock) ReleaseOrderAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReleaseOrder.afterReleaseOrderCounter)
+}
+
+// ReleaseOrderBeforeCounter returns a count of IRepositoryMock.ReleaseOrder invocations
+func (mmReleaseOrder *IRepositoryMock) ReleaseOrderBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReleaseOrder.beforeReleaseOrderCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.ReleaseOrder.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmReleaseOrder *mIRepositoryMockReleaseOrder) Calls() []*IRepositoryMockReleaseOrderParams {
+	mmReleaseOrder.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockReleaseOrderParams, len(mmReleaseOrder.callArgs))
+	copy(argCopy, mmReleaseOrder.callArgs)
+
+	mmReleaseOrder.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockReleaseOrderDone returns true if the count of the ReleaseOrder invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockReleaseOrderDone() bool {
+	for _, e := range m.ReleaseOrderMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReleaseOrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterReleaseOrderCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReleaseOrder != nil && mm_atomic.LoadUint64(&m.afterReleaseOrderCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockReleaseOrderInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockReleaseOrderInspect() {
+	for _, e := range m.ReleaseOrderMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.ReleaseOrder with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReleaseOrderMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterReleaseOrderCounter) < 1 {
+		if m.ReleaseOrderMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.ReleaseOrder")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.ReleaseOrder with params: %#v", *m.ReleaseOrderMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReleaseOrder != nil && mm_atomic.LoadUint64(&m.afterReleaseOrderCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.ReleaseOrder")
+	}
+}
+
+type mIRepositoryMockReport struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockReportExpectation
+	expectations       []*IRepositoryMockReportExpectation
+
+	callArgs []*IRepositoryMockReportParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockReportExpectation specifies expectation struct of the IRepository.Report
+type IRepositoryMockReportExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockReportParams
+	results *IRepositoryMockReportResults
+	Counter uint64
+}
+
+// IRepositoryMockReportParams contains parameters of the IRepository.Report
+type IRepositoryMockReportParams struct {
+	ctx context.Context
+	q   model.ReportQuery
+}
+
+// IRepositoryMockReportResults contains results of the IRepository.Report
+type IRepositoryMockReportResults struct {
+	ra1 []model.Report
+	err error
+}
+
+// Expect sets up expected params for IRepository.Report
+func (mmReport *mIRepositoryMockReport) Expect(ctx context.Context, q model.ReportQuery) *mIRepositoryMockReport {
+	if mmReport.mock.funcReport != nil {
 		mmReport.mock.t.Fatalf("IRepositoryMock.Report mock is already set by Set")
 	}
 
@@ -1886,7 +5549,7 @@ func (mmReport *mIRepositoryMockReport) Expect(t1 time.Time) *mIRepositoryMockRe
 		mmReport.defaultExpectation = &IRepositoryMockReportExpectation{}
 	}
 
-	mmReport.defaultExpectation.params = &IRepositoryMockReportParams{t1}
+	mmReport.defaultExpectation.params = &IRepositoryMockReportParams{ctx, q}
 	for _, e := range mmReport.expectations {
 		if minimock.Equal(e.params, mmReport.defaultExpectation.params) {
 			mmReport.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReport.defaultExpectation.params)
@@ -1897,7 +5560,7 @@ func (mmReport *mIRepositoryMockReport) Expect(t1 time.Time) *mIRepositoryMockRe
 }
 
 // Inspect accepts an inspector function that has same arguments as the IRepository.Report
-func (mmReport *mIRepositoryMockReport) Inspect(f func(t1 time.Time)) *mIRepositoryMockReport {
+func (mmReport *mIRepositoryMockReport) Inspect(f func(ctx context.Context, q model.ReportQuery)) *mIRepositoryMockReport {
 	if mmReport.mock.inspectFuncReport != nil {
 		mmReport.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Report")
 	}
@@ -1920,8 +5583,8 @@ func (mmReport *mIRepositoryMockReport) Return(ra1 []model.Report, err error) *I
 	return mmReport.mock
 }
 
-//Set uses given function f to mock the IRepository.Report method
-func (mmReport *mIRepositoryMockReport) Set(f func(t1 time.Time) (ra1 []model.Report, err error)) *IRepositoryMock {
+// Set uses given function f to mock the IRepository.Report method
+func (mmReport *mIRepositoryMockReport) Set(f func(ctx context.Context, q model.ReportQuery) (ra1 []model.Report, err error)) *IRepositoryMock {
 	if mmReport.defaultExpectation != nil {
 		mmReport.mock.t.Fatalf("Default expectation is already set for the IRepository.Report method")
 	}
@@ -1936,14 +5599,14 @@ func (mmReport *mIRepositoryMockReport) Set(f func(t1 time.Time) (ra1 []model.Re
 
 // When sets expectation for the IRepository.Report which will trigger the result defined by the following
 // Then helper
-func (mmReport *mIRepositoryMockReport) When(t1 time.Time) *IRepositoryMockReportExpectation {
+func (mmReport *mIRepositoryMockReport) When(ctx context.Context, q model.ReportQuery) *IRepositoryMockReportExpectation {
 	if mmReport.mock.funcReport != nil {
 		mmReport.mock.t.Fatalf("IRepositoryMock.Report mock is already set by Set")
 	}
 
 	expectation := &IRepositoryMockReportExpectation{
 		mock:   mmReport.mock,
-		params: &IRepositoryMockReportParams{t1},
+		params: &IRepositoryMockReportParams{ctx, q},
 	}
 	mmReport.expectations = append(mmReport.expectations, expectation)
 	return expectation
@@ -1956,15 +5619,15 @@ func (e *IRepositoryMockReportExpectation) Then(ra1 []model.Report, err error) *
 }
 
 // Report implements IRepository
-func (mmReport *IRepositoryMock) Report(t1 time.Time) (ra1 []model.Report, err error) {
+func (mmReport *IRepositoryMock) Report(ctx context.Context, q model.ReportQuery) (ra1 []model.Report, err error) {
 	mm_atomic.AddUint64(&mmReport.beforeReportCounter, 1)
 	defer mm_atomic.AddUint64(&mmReport.afterReportCounter, 1)
 
 	if mmReport.inspectFuncReport != nil {
-		mmReport.inspectFuncReport(t1)
+		mmReport.inspectFuncReport(ctx, q)
 	}
 
-	mm_params := &IRepositoryMockReportParams{t1}
+	mm_params := &IRepositoryMockReportParams{ctx, q}
 
 	// Record call args
 	mmReport.ReportMock.mutex.Lock()
@@ -1981,7 +5644,7 @@ func (mmReport *IRepositoryMock) Report(t1 time.Time) (ra1 []model.Report, err e
 	if mmReport.ReportMock.defaultExpectation != nil {
 		mm_atomic.AddUint64(&mmReport.ReportMock.defaultExpectation.Counter, 1)
 		mm_want := mmReport.ReportMock.defaultExpectation.params
-		mm_got := IRepositoryMockReportParams{t1}
+		mm_got := IRepositoryMockReportParams{ctx, q}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmReport.t.Errorf("IRepositoryMock.Report got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -1993,9 +5656,9 @@ func (mmReport *IRepositoryMock) Report(t1 time.Time) (ra1 []model.Report, err e
 		return (*mm_results).ra1, (*mm_results).err
 	}
 	if mmReport.funcReport != nil {
-		return mmReport.funcReport(t1)
+		return mmReport.funcReport(ctx, q)
 	}
-	mmReport.t.Fatalf("Unexpected call to IRepositoryMock.Report. %v", t1)
+	mmReport.t.Fatalf("Unexpected call to IRepositoryMock.Report. %v %v", ctx, q)
 	return
 }
 
@@ -2024,43 +5687,477 @@ func (mmReport *mIRepositoryMockReport) Calls() []*IRepositoryMockReportParams {
 
 // MinimockReportDone returns true if the count of the Report invocations corresponds
 // the number of defined expectations
-func (m *IRepositoryMock) MinimockReportDone() bool {
-	for _, e := range m.ReportMock.expectations {
+func (m *IRepositoryMock) MinimockReportDone() bool {
+	for _, e := range m.ReportMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReportMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReport != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockReportInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockReportInspect() {
+	for _, e := range m.ReportMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.Report with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReportMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
+		if m.ReportMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Report")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.Report with params: %#v", *m.ReportMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReport != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Report")
+	}
+}
+
+type mIRepositoryMockSaveIdempotencyResponse struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockSaveIdempotencyResponseExpectation
+	expectations       []*IRepositoryMockSaveIdempotencyResponseExpectation
+
+	callArgs []*IRepositoryMockSaveIdempotencyResponseParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockSaveIdempotencyResponseExpectation specifies expectation struct of the IRepository.SaveIdempotencyResponse
+type IRepositoryMockSaveIdempotencyResponseExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockSaveIdempotencyResponseParams
+	results *IRepositoryMockSaveIdempotencyResponseResults
+	Counter uint64
+}
+
+// IRepositoryMockSaveIdempotencyResponseParams contains parameters of the IRepository.SaveIdempotencyResponse
+type IRepositoryMockSaveIdempotencyResponseParams struct {
+	ctx    context.Context
+	key    string
+	status int
+	body   []byte
+}
+
+// IRepositoryMockSaveIdempotencyResponseResults contains results of the IRepository.SaveIdempotencyResponse
+type IRepositoryMockSaveIdempotencyResponseResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.SaveIdempotencyResponse
+func (mmSaveIdempotencyResponse *mIRepositoryMockSaveIdempotencyResponse) Expect(ctx context.Context, key string, status int, body []byte) *mIRepositoryMockSaveIdempotencyResponse {
+	if mmSaveIdempotencyResponse.mock.funcSaveIdempotencyResponse != nil {
+		mmSaveIdempotencyResponse.mock.t.Fatalf("IRepositoryMock.SaveIdempotencyResponse mock is already set by Set")
+	}
+
+	if mmSaveIdempotencyResponse.defaultExpectation == nil {
+		mmSaveIdempotencyResponse.defaultExpectation = &IRepositoryMockSaveIdempotencyResponseExpectation{}
+	}
+
+	mmSaveIdempotencyResponse.defaultExpectation.params = &IRepositoryMockSaveIdempotencyResponseParams{ctx, key, status, body}
+	for _, e := range mmSaveIdempotencyResponse.expectations {
+		if minimock.Equal(e.params, mmSaveIdempotencyResponse.defaultExpectation.params) {
+			mmSaveIdempotencyResponse.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSaveIdempotencyResponse.defaultExpectation.params)
+		}
+	}
+
+	return mmSaveIdempotencyResponse
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.SaveIdempotencyResponse
+func (mmSaveIdempotencyResponse *mIRepositoryMockSaveIdempotencyResponse) Inspect(f func(ctx context.Context, key string, status int, body []byte)) *mIRepositoryMockSaveIdempotencyResponse {
+	if mmSaveIdempotencyResponse.mock.inspectFuncSaveIdempotencyResponse != nil {
+		mmSaveIdempotencyResponse.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.SaveIdempotencyResponse")
+	}
+
+	mmSaveIdempotencyResponse.mock.inspectFuncSaveIdempotencyResponse = f
+
+	return mmSaveIdempotencyResponse
+}
+
+// Return sets up results that will be returned by IRepository.SaveIdempotencyResponse
+func (mmSaveIdempotencyResponse *mIRepositoryMockSaveIdempotencyResponse) Return(err error) *IRepositoryMock {
+	if mmSaveIdempotencyResponse.mock.funcSaveIdempotencyResponse != nil {
+		mmSaveIdempotencyResponse.mock.t.Fatalf("IRepositoryMock.SaveIdempotencyResponse mock is already set by Set")
+	}
+
+	if mmSaveIdempotencyResponse.defaultExpectation == nil {
+		mmSaveIdempotencyResponse.defaultExpectation = &IRepositoryMockSaveIdempotencyResponseExpectation{mock: mmSaveIdempotencyResponse.mock}
+	}
+	mmSaveIdempotencyResponse.defaultExpectation.results = &IRepositoryMockSaveIdempotencyResponseResults{err}
+	return mmSaveIdempotencyResponse.mock
+}
+
+// Set uses given function f to mock the IRepository.SaveIdempotencyResponse method
+func (mmSaveIdempotencyResponse *mIRepositoryMockSaveIdempotencyResponse) Set(f func(ctx context.Context, key string, status int, body []byte) (err error)) *IRepositoryMock {
+	if mmSaveIdempotencyResponse.defaultExpectation != nil {
+		mmSaveIdempotencyResponse.mock.t.Fatalf("Default expectation is already set for the IRepository.SaveIdempotencyResponse method")
+	}
+
+	if len(mmSaveIdempotencyResponse.expectations) > 0 {
+		mmSaveIdempotencyResponse.mock.t.Fatalf("Some expectations are already set for the IRepository.SaveIdempotencyResponse method")
+	}
+
+	mmSaveIdempotencyResponse.mock.funcSaveIdempotencyResponse = f
+	return mmSaveIdempotencyResponse.mock
+}
+
+// When sets expectation for the IRepository.SaveIdempotencyResponse which will trigger the result defined by the following
+// Then helper
+func (mmSaveIdempotencyResponse *mIRepositoryMockSaveIdempotencyResponse) When(ctx context.Context, key string, status int, body []byte) *IRepositoryMockSaveIdempotencyResponseExpectation {
+	if mmSaveIdempotencyResponse.mock.funcSaveIdempotencyResponse != nil {
+		mmSaveIdempotencyResponse.mock.t.Fatalf("IRepositoryMock.SaveIdempotencyResponse mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockSaveIdempotencyResponseExpectation{
+		mock:   mmSaveIdempotencyResponse.mock,
+		params: &IRepositoryMockSaveIdempotencyResponseParams{ctx, key, status, body},
+	}
+	mmSaveIdempotencyResponse.expectations = append(mmSaveIdempotencyResponse.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.SaveIdempotencyResponse return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockSaveIdempotencyResponseExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockSaveIdempotencyResponseResults{err}
+	return e.mock
+}
+
+// SaveIdempotencyResponse implements IRepository
+func (mmSaveIdempotencyResponse *IRepositoryMock) SaveIdempotencyResponse(ctx context.Context, key string, status int, body []byte) (err error) {
+	mm_atomic.AddUint64(&mmSaveIdempotencyResponse.beforeSaveIdempotencyResponseCounter, 1)
+	defer mm_atomic.AddUint64(&mmSaveIdempotencyResponse.afterSaveIdempotencyResponseCounter, 1)
+
+	if mmSaveIdempotencyResponse.inspectFuncSaveIdempotencyResponse != nil {
+		mmSaveIdempotencyResponse.inspectFuncSaveIdempotencyResponse(ctx, key, status, body)
+	}
+
+	mm_params := &IRepositoryMockSaveIdempotencyResponseParams{ctx, key, status, body}
+
+	// Record call args
+	mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.mutex.Lock()
+	mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.callArgs = append(mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.callArgs, mm_params)
+	mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.mutex.Unlock()
+
+	for _, e := range mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.defaultExpectation.Counter, 1)
+		mm_want := mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.defaultExpectation.params
+		mm_got := IRepositoryMockSaveIdempotencyResponseParams{ctx, key, status, body}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSaveIdempotencyResponse.t.Errorf("IRepositoryMock.SaveIdempotencyResponse got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSaveIdempotencyResponse.SaveIdempotencyResponseMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSaveIdempotencyResponse.t.Fatal("No results are set for the IRepositoryMock.SaveIdempotencyResponse")
+		}
+		return (*mm_results).err
+	}
+	if mmSaveIdempotencyResponse.funcSaveIdempotencyResponse != nil {
+		return mmSaveIdempotencyResponse.funcSaveIdempotencyResponse(ctx, key, status, body)
+	}
+	mmSaveIdempotencyResponse.t.Fatalf("Unexpected call to IRepositoryMock.SaveIdempotencyResponse. %v %v %v %v", ctx, key, status, body)
+	return
+}
+
+// SaveIdempotencyResponseAfterCounter returns a count of finished IRepositoryMock.SaveIdempotencyResponse invocations
+func (mmSaveIdempotencyResponse *IRepositoryMock) SaveIdempotencyResponseAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSaveIdempotencyResponse.afterSaveIdempotencyResponseCounter)
+}
+
+// SaveIdempotencyResponseBeforeCounter returns a count of IRepositoryMock.SaveIdempotencyResponse invocations
+func (mmSaveIdempotencyResponse *IRepositoryMock) SaveIdempotencyResponseBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSaveIdempotencyResponse.beforeSaveIdempotencyResponseCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.SaveIdempotencyResponse.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSaveIdempotencyResponse *mIRepositoryMockSaveIdempotencyResponse) Calls() []*IRepositoryMockSaveIdempotencyResponseParams {
+	mmSaveIdempotencyResponse.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockSaveIdempotencyResponseParams, len(mmSaveIdempotencyResponse.callArgs))
+	copy(argCopy, mmSaveIdempotencyResponse.callArgs)
+
+	mmSaveIdempotencyResponse.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSaveIdempotencyResponseDone returns true if the count of the SaveIdempotencyResponse invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockSaveIdempotencyResponseDone() bool {
+	for _, e := range m.SaveIdempotencyResponseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SaveIdempotencyResponseMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSaveIdempotencyResponseCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSaveIdempotencyResponse != nil && mm_atomic.LoadUint64(&m.afterSaveIdempotencyResponseCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockSaveIdempotencyResponseInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockSaveIdempotencyResponseInspect() {
+	for _, e := range m.SaveIdempotencyResponseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.SaveIdempotencyResponse with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SaveIdempotencyResponseMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterSaveIdempotencyResponseCounter) < 1 {
+		if m.SaveIdempotencyResponseMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.SaveIdempotencyResponse")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.SaveIdempotencyResponse with params: %#v", *m.SaveIdempotencyResponseMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSaveIdempotencyResponse != nil && mm_atomic.LoadUint64(&m.afterSaveIdempotencyResponseCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.SaveIdempotencyResponse")
+	}
+}
+
+type mIRepositoryMockServices struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockServicesExpectation
+	expectations       []*IRepositoryMockServicesExpectation
+
+	callArgs []*IRepositoryMockServicesParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockServicesExpectation specifies expectation struct of the IRepository.Services
+type IRepositoryMockServicesExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockServicesParams
+	results *IRepositoryMockServicesResults
+	Counter uint64
+}
+
+// IRepositoryMockServicesParams contains parameters of the IRepository.Services
+type IRepositoryMockServicesParams struct {
+	ctx context.Context
+}
+
+// IRepositoryMockServicesResults contains results of the IRepository.Services
+type IRepositoryMockServicesResults struct {
+	sa1 []model.Service
+	err error
+}
+
+// Expect sets up expected params for IRepository.Services
+func (mmServices *mIRepositoryMockServices) Expect(ctx context.Context) *mIRepositoryMockServices {
+	if mmServices.mock.funcServices != nil {
+		mmServices.mock.t.Fatalf("IRepositoryMock.Services mock is already set by Set")
+	}
+
+	if mmServices.defaultExpectation == nil {
+		mmServices.defaultExpectation = &IRepositoryMockServicesExpectation{}
+	}
+
+	mmServices.defaultExpectation.params = &IRepositoryMockServicesParams{ctx}
+	for _, e := range mmServices.expectations {
+		if minimock.Equal(e.params, mmServices.defaultExpectation.params) {
+			mmServices.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmServices.defaultExpectation.params)
+		}
+	}
+
+	return mmServices
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.Services
+func (mmServices *mIRepositoryMockServices) Inspect(f func(ctx context.Context)) *mIRepositoryMockServices {
+	if mmServices.mock.inspectFuncServices != nil {
+		mmServices.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Services")
+	}
+
+	mmServices.mock.inspectFuncServices = f
+
+	return mmServices
+}
+
+// Return sets up results that will be returned by IRepository.Services
+func (mmServices *mIRepositoryMockServices) Return(sa1 []model.Service, err error) *IRepositoryMock {
+	if mmServices.mock.funcServices != nil {
+		mmServices.mock.t.Fatalf("IRepositoryMock.Services mock is already set by Set")
+	}
+
+	if mmServices.defaultExpectation == nil {
+		mmServices.defaultExpectation = &IRepositoryMockServicesExpectation{mock: mmServices.mock}
+	}
+	mmServices.defaultExpectation.results = &IRepositoryMockServicesResults{sa1, err}
+	return mmServices.mock
+}
+
+// Set uses given function f to mock the IRepository.Services method
+func (mmServices *mIRepositoryMockServices) Set(f func(ctx context.Context) (sa1 []model.Service, err error)) *IRepositoryMock {
+	if mmServices.defaultExpectation != nil {
+		mmServices.mock.t.Fatalf("Default expectation is already set for the IRepository.Services method")
+	}
+
+	if len(mmServices.expectations) > 0 {
+		mmServices.mock.t.Fatalf("Some expectations are already set for the IRepository.Services method")
+	}
+
+	mmServices.mock.funcServices = f
+	return mmServices.mock
+}
+
+// When sets expectation for the IRepository.Services which will trigger the result defined by the following
+// Then helper
+func (mmServices *mIRepositoryMockServices) When(ctx context.Context) *IRepositoryMockServicesExpectation {
+	if mmServices.mock.funcServices != nil {
+		mmServices.mock.t.Fatalf("IRepositoryMock.Services mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockServicesExpectation{
+		mock:   mmServices.mock,
+		params: &IRepositoryMockServicesParams{ctx},
+	}
+	mmServices.expectations = append(mmServices.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.Services return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockServicesExpectation) Then(sa1 []model.Service, err error) *IRepositoryMock {
+	e.results = &IRepositoryMockServicesResults{sa1, err}
+	return e.mock
+}
+
+// Services implements IRepository
+func (mmServices *IRepositoryMock) Services(ctx context.Context) (sa1 []model.Service, err error) {
+	mm_atomic.AddUint64(&mmServices.beforeServicesCounter, 1)
+	defer mm_atomic.AddUint64(&mmServices.afterServicesCounter, 1)
+
+	if mmServices.inspectFuncServices != nil {
+		mmServices.inspectFuncServices(ctx)
+	}
+
+	mm_params := &IRepositoryMockServicesParams{ctx}
+
+	// Record call args
+	mmServices.ServicesMock.mutex.Lock()
+	mmServices.ServicesMock.callArgs = append(mmServices.ServicesMock.callArgs, mm_params)
+	mmServices.ServicesMock.mutex.Unlock()
+
+	for _, e := range mmServices.ServicesMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sa1, e.results.err
+		}
+	}
+
+	if mmServices.ServicesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmServices.ServicesMock.defaultExpectation.Counter, 1)
+		mm_want := mmServices.ServicesMock.defaultExpectation.params
+		mm_got := IRepositoryMockServicesParams{ctx}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmServices.t.Errorf("IRepositoryMock.Services got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmServices.ServicesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmServices.t.Fatal("No results are set for the IRepositoryMock.Services")
+		}
+		return (*mm_results).sa1, (*mm_results).err
+	}
+	if mmServices.funcServices != nil {
+		return mmServices.funcServices(ctx)
+	}
+	mmServices.t.Fatalf("Unexpected call to IRepositoryMock.Services. %v", ctx)
+	return
+}
+
+// ServicesAfterCounter returns a count of finished IRepositoryMock.Services invocations
+func (mmServices *IRepositoryMock) ServicesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmServices.afterServicesCounter)
+}
+
+// ServicesBeforeCounter returns a count of IRepositoryMock.Services invocations
+func (mmServices *IRepositoryMock) ServicesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmServices.beforeServicesCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.Services.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmServices *mIRepositoryMockServices) Calls() []*IRepositoryMockServicesParams {
+	mmServices.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockServicesParams, len(mmServices.callArgs))
+	copy(argCopy, mmServices.callArgs)
+
+	mmServices.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockServicesDone returns true if the count of the Services invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockServicesDone() bool {
+	for _, e := range m.ServicesMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ReportMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
+	if m.ServicesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterServicesCounter) < 1 {
 		return false
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcReport != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
+	if m.funcServices != nil && mm_atomic.LoadUint64(&m.afterServicesCounter) < 1 {
 		return false
 	}
 	return true
 }
 
-// MinimockReportInspect logs each unmet expectation
-func (m *IRepositoryMock) MinimockReportInspect() {
-	for _, e := range m.ReportMock.expectations {
+// MinimockServicesInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockServicesInspect() {
+	for _, e := range m.ServicesMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to IRepositoryMock.Report with params: %#v", *e.params)
+			m.t.Errorf("Expected call to IRepositoryMock.Services with params: %#v", *e.params)
 		}
 	}
 
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ReportMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
-		if m.ReportMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to IRepositoryMock.Report")
+	if m.ServicesMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterServicesCounter) < 1 {
+		if m.ServicesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Services")
 		} else {
-			m.t.Errorf("Expected call to IRepositoryMock.Report with params: %#v", *m.ReportMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to IRepositoryMock.Services with params: %#v", *m.ServicesMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcReport != nil && mm_atomic.LoadUint64(&m.afterReportCounter) < 1 {
-		m.t.Error("Expected call to IRepositoryMock.Report")
+	if m.funcServices != nil && mm_atomic.LoadUint64(&m.afterServicesCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Services")
 	}
 }
 
@@ -2083,9 +6180,12 @@ type IRepositoryMockTransferExpectation struct {
 
 // IRepositoryMockTransferParams contains parameters of the IRepository.Transfer
 type IRepositoryMockTransferParams struct {
-	sender    model.User
-	recipient model.User
-	funds     float64
+	ctx         context.Context
+	senderID    uuid.UUID
+	recipientID uuid.UUID
+	funds       model.Money
+	fee         model.Money
+	date        time.Time
 }
 
 // IRepositoryMockTransferResults contains results of the IRepository.Transfer
@@ -2094,7 +6194,7 @@ type IRepositoryMockTransferResults struct {
 }
 
 // Expect sets up expected params for IRepository.Transfer
-func (mmTransfer *mIRepositoryMockTransfer) Expect(sender model.User, recipient model.User, funds float64) *mIRepositoryMockTransfer {
+func (mmTransfer *mIRepositoryMockTransfer) Expect(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID, funds model.Money, fee model.Money, date time.Time) *mIRepositoryMockTransfer {
 	if mmTransfer.mock.funcTransfer != nil {
 		mmTransfer.mock.t.Fatalf("IRepositoryMock.Transfer mock is already set by Set")
 	}
@@ -2103,7 +6203,7 @@ func (mmTransfer *mIRepositoryMockTransfer) Expect(sender model.User, recipient
 		mmTransfer.defaultExpectation = &IRepositoryMockTransferExpectation{}
 	}
 
-	mmTransfer.defaultExpectation.params = &IRepositoryMockTransferParams{sender, recipient, funds}
+	mmTransfer.defaultExpectation.params = &IRepositoryMockTransferParams{ctx, senderID, recipientID, funds, fee, date}
 	for _, e := range mmTransfer.expectations {
 		if minimock.Equal(e.params, mmTransfer.defaultExpectation.params) {
 			mmTransfer.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmTransfer.defaultExpectation.params)
@@ -2114,7 +6214,7 @@ func (mmTransfer *mIRepositoryMockTransfer) Expect(sender model.User, recipient
 }
 
 // Inspect accepts an inspector function that has same arguments as the IRepository.Transfer
-func (mmTransfer *mIRepositoryMockTransfer) Inspect(f func(sender model.User, recipient model.User, funds float64)) *mIRepositoryMockTransfer {
+func (mmTransfer *mIRepositoryMockTransfer) Inspect(f func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID, funds model.Money, fee model.Money, date time.Time)) *mIRepositoryMockTransfer {
 	if mmTransfer.mock.inspectFuncTransfer != nil {
 		mmTransfer.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Transfer")
 	}
@@ -2137,8 +6237,8 @@ func (mmTransfer *mIRepositoryMockTransfer) Return(err error) *IRepositoryMock {
 	return mmTransfer.mock
 }
 
-//Set uses given function f to mock the IRepository.Transfer method
-func (mmTransfer *mIRepositoryMockTransfer) Set(f func(sender model.User, recipient model.User, funds float64) (err error)) *IRepositoryMock {
+// Set uses given function f to mock the IRepository.Transfer method
+func (mmTransfer *mIRepositoryMockTransfer) Set(f func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID, funds model.Money, fee model.Money, date time.Time) (err error)) *IRepositoryMock {
 	if mmTransfer.defaultExpectation != nil {
 		mmTransfer.mock.t.Fatalf("Default expectation is already set for the IRepository.Transfer method")
 	}
@@ -2153,14 +6253,14 @@ func (mmTransfer *mIRepositoryMockTransfer) Set(f func(sender model.User, recipi
 
 // When sets expectation for the IRepository.Transfer which will trigger the result defined by the following
 // Then helper
-func (mmTransfer *mIRepositoryMockTransfer) When(sender model.User, recipient model.User, funds float64) *IRepositoryMockTransferExpectation {
+func (mmTransfer *mIRepositoryMockTransfer) When(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID, funds model.Money, fee model.Money, date time.Time) *IRepositoryMockTransferExpectation {
 	if mmTransfer.mock.funcTransfer != nil {
 		mmTransfer.mock.t.Fatalf("IRepositoryMock.Transfer mock is already set by Set")
 	}
 
 	expectation := &IRepositoryMockTransferExpectation{
 		mock:   mmTransfer.mock,
-		params: &IRepositoryMockTransferParams{sender, recipient, funds},
+		params: &IRepositoryMockTransferParams{ctx, senderID, recipientID, funds, fee, date},
 	}
 	mmTransfer.expectations = append(mmTransfer.expectations, expectation)
 	return expectation
@@ -2173,15 +6273,15 @@ func (e *IRepositoryMockTransferExpectation) Then(err error) *IRepositoryMock {
 }
 
 // Transfer implements IRepository
-func (mmTransfer *IRepositoryMock) Transfer(sender model.User, recipient model.User, funds float64) (err error) {
+func (mmTransfer *IRepositoryMock) Transfer(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID, funds model.Money, fee model.Money, date time.Time) (err error) {
 	mm_atomic.AddUint64(&mmTransfer.beforeTransferCounter, 1)
 	defer mm_atomic.AddUint64(&mmTransfer.afterTransferCounter, 1)
 
 	if mmTransfer.inspectFuncTransfer != nil {
-		mmTransfer.inspectFuncTransfer(sender, recipient, funds)
+		mmTransfer.inspectFuncTransfer(ctx, senderID, recipientID, funds, fee, date)
 	}
 
-	mm_params := &IRepositoryMockTransferParams{sender, recipient, funds}
+	mm_params := &IRepositoryMockTransferParams{ctx, senderID, recipientID, funds, fee, date}
 
 	// Record call args
 	mmTransfer.TransferMock.mutex.Lock()
@@ -2198,7 +6298,7 @@ func (mmTransfer *IRepositoryMock) Transfer(sender model.User, recipient model.U
 	if mmTransfer.TransferMock.defaultExpectation != nil {
 		mm_atomic.AddUint64(&mmTransfer.TransferMock.defaultExpectation.Counter, 1)
 		mm_want := mmTransfer.TransferMock.defaultExpectation.params
-		mm_got := IRepositoryMockTransferParams{sender, recipient, funds}
+		mm_got := IRepositoryMockTransferParams{ctx, senderID, recipientID, funds, fee, date}
 		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmTransfer.t.Errorf("IRepositoryMock.Transfer got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -2210,9 +6310,9 @@ func (mmTransfer *IRepositoryMock) Transfer(sender model.User, recipient model.U
 		return (*mm_results).err
 	}
 	if mmTransfer.funcTransfer != nil {
-		return mmTransfer.funcTransfer(sender, recipient, funds)
+		return mmTransfer.funcTransfer(ctx, senderID, recipientID, funds, fee, date)
 	}
-	mmTransfer.t.Fatalf("Unexpected call to IRepositoryMock.Transfer. %v %v %v", sender, recipient, funds)
+	mmTransfer.t.Fatalf("Unexpected call to IRepositoryMock.Transfer. %v %v %v %v %v %v", ctx, senderID, recipientID, funds, fee, date)
 	return
 }
 
@@ -2281,28 +6381,503 @@ func (m *IRepositoryMock) MinimockTransferInspect() {
 	}
 }
 
+type mIRepositoryMockUpdateService struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockUpdateServiceExpectation
+	expectations       []*IRepositoryMockUpdateServiceExpectation
+
+	callArgs []*IRepositoryMockUpdateServiceParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockUpdateServiceExpectation specifies expectation struct of the IRepository.UpdateService
+type IRepositoryMockUpdateServiceExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockUpdateServiceParams
+	results *IRepositoryMockUpdateServiceResults
+	Counter uint64
+}
+
+// IRepositoryMockUpdateServiceParams contains parameters of the IRepository.UpdateService
+type IRepositoryMockUpdateServiceParams struct {
+	ctx context.Context
+	s   model.Service
+}
+
+// IRepositoryMockUpdateServiceResults contains results of the IRepository.UpdateService
+type IRepositoryMockUpdateServiceResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.UpdateService
+func (mmUpdateService *mIRepositoryMockUpdateService) Expect(ctx context.Context, s model.Service) *mIRepositoryMockUpdateService {
+	if mmUpdateService.mock.funcUpdateService != nil {
+		mmUpdateService.mock.t.Fatalf("IRepositoryMock.UpdateService mock is already set by Set")
+	}
+
+	if mmUpdateService.defaultExpectation == nil {
+		mmUpdateService.defaultExpectation = &IRepositoryMockUpdateServiceExpectation{}
+	}
+
+	mmUpdateService.defaultExpectation.params = &IRepositoryMockUpdateServiceParams{ctx, s}
+	for _, e := range mmUpdateService.expectations {
+		if minimock.Equal(e.params, mmUpdateService.defaultExpectation.params) {
+			mmUpdateService.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdateService.defaultExpectation.params)
+		}
+	}
+
+	return mmUpdateService
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.UpdateService
+func (mmUpdateService *mIRepositoryMockUpdateService) Inspect(f func(ctx context.Context, s model.Service)) *mIRepositoryMockUpdateService {
+	if mmUpdateService.mock.inspectFuncUpdateService != nil {
+		mmUpdateService.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.UpdateService")
+	}
+
+	mmUpdateService.mock.inspectFuncUpdateService = f
+
+	return mmUpdateService
+}
+
+// Return sets up results that will be returned by IRepository.UpdateService
+func (mmUpdateService *mIRepositoryMockUpdateService) Return(err error) *IRepositoryMock {
+	if mmUpdateService.mock.funcUpdateService != nil {
+		mmUpdateService.mock.t.Fatalf("IRepositoryMock.UpdateService mock is already set by Set")
+	}
+
+	if mmUpdateService.defaultExpectation == nil {
+		mmUpdateService.defaultExpectation = &IRepositoryMockUpdateServiceExpectation{mock: mmUpdateService.mock}
+	}
+	mmUpdateService.defaultExpectation.results = &IRepositoryMockUpdateServiceResults{err}
+	return mmUpdateService.mock
+}
+
+// Set uses given function f to mock the IRepository.UpdateService method
+func (mmUpdateService *mIRepositoryMockUpdateService) Set(f func(ctx context.Context, s model.Service) (err error)) *IRepositoryMock {
+	if mmUpdateService.defaultExpectation != nil {
+		mmUpdateService.mock.t.Fatalf("Default expectation is already set for the IRepository.UpdateService method")
+	}
+
+	if len(mmUpdateService.expectations) > 0 {
+		mmUpdateService.mock.t.Fatalf("Some expectations are already set for the IRepository.UpdateService method")
+	}
+
+	mmUpdateService.mock.funcUpdateService = f
+	return mmUpdateService.mock
+}
+
+// When sets expectation for the IRepository.UpdateService which will trigger the result defined by the following
+// Then helper
+func (mmUpdateService *mIRepositoryMockUpdateService) When(ctx context.Context, s model.Service) *IRepositoryMockUpdateServiceExpectation {
+	if mmUpdateService.mock.funcUpdateService != nil {
+		mmUpdateService.mock.t.Fatalf("IRepositoryMock.UpdateService mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockUpdateServiceExpectation{
+		mock:   mmUpdateService.mock,
+		params: &IRepositoryMockUpdateServiceParams{ctx, s},
+	}
+	mmUpdateService.expectations = append(mmUpdateService.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.UpdateService return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockUpdateServiceExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockUpdateServiceResults{err}
+	return e.mock
+}
+
+// UpdateService implements IRepository
+func (mmUpdateService *IRepositoryMock) UpdateService(ctx context.Context, s model.Service) (err error) {
+	mm_atomic.AddUint64(&mmUpdateService.beforeUpdateServiceCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpdateService.afterUpdateServiceCounter, 1)
+
+	if mmUpdateService.inspectFuncUpdateService != nil {
+		mmUpdateService.inspectFuncUpdateService(ctx, s)
+	}
+
+	mm_params := &IRepositoryMockUpdateServiceParams{ctx, s}
+
+	// Record call args
+	mmUpdateService.UpdateServiceMock.mutex.Lock()
+	mmUpdateService.UpdateServiceMock.callArgs = append(mmUpdateService.UpdateServiceMock.callArgs, mm_params)
+	mmUpdateService.UpdateServiceMock.mutex.Unlock()
+
+	for _, e := range mmUpdateService.UpdateServiceMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmUpdateService.UpdateServiceMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdateService.UpdateServiceMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpdateService.UpdateServiceMock.defaultExpectation.params
+		mm_got := IRepositoryMockUpdateServiceParams{ctx, s}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmUpdateService.t.Errorf("IRepositoryMock.UpdateService got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmUpdateService.UpdateServiceMock.defaultExpectation.results
+		if mm_results == nil {
+			mmUpdateService.t.Fatal("No results are set for the IRepositoryMock.UpdateService")
+		}
+		return (*mm_results).err
+	}
+	if mmUpdateService.funcUpdateService != nil {
+		return mmUpdateService.funcUpdateService(ctx, s)
+	}
+	mmUpdateService.t.Fatalf("Unexpected call to IRepositoryMock.UpdateService. %v %v", ctx, s)
+	return
+}
+
+// UpdateServiceAfterCounter returns a count of finished IRepositoryMock.UpdateService invocations
+func (mmUpdateService *IRepositoryMock) UpdateServiceAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateService.afterUpdateServiceCounter)
+}
+
+// UpdateServiceBeforeCounter returns a count of IRepositoryMock.UpdateService invocations
+func (mmUpdateService *IRepositoryMock) UpdateServiceBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateService.beforeUpdateServiceCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.UpdateService.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUpdateService *mIRepositoryMockUpdateService) Calls() []*IRepositoryMockUpdateServiceParams {
+	mmUpdateService.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockUpdateServiceParams, len(mmUpdateService.callArgs))
+	copy(argCopy, mmUpdateService.callArgs)
+
+	mmUpdateService.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUpdateServiceDone returns true if the count of the UpdateService invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockUpdateServiceDone() bool {
+	for _, e := range m.UpdateServiceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpdateServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterUpdateServiceCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpdateService != nil && mm_atomic.LoadUint64(&m.afterUpdateServiceCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockUpdateServiceInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockUpdateServiceInspect() {
+	for _, e := range m.UpdateServiceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.UpdateService with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpdateServiceMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterUpdateServiceCounter) < 1 {
+		if m.UpdateServiceMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.UpdateService")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.UpdateService with params: %#v", *m.UpdateServiceMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpdateService != nil && mm_atomic.LoadUint64(&m.afterUpdateServiceCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.UpdateService")
+	}
+}
+
+type mIRepositoryMockWithdraw struct {
+	mock               *IRepositoryMock
+	defaultExpectation *IRepositoryMockWithdrawExpectation
+	expectations       []*IRepositoryMockWithdrawExpectation
+
+	callArgs []*IRepositoryMockWithdrawParams
+	mutex    sync.RWMutex
+}
+
+// IRepositoryMockWithdrawExpectation specifies expectation struct of the IRepository.Withdraw
+type IRepositoryMockWithdrawExpectation struct {
+	mock    *IRepositoryMock
+	params  *IRepositoryMockWithdrawParams
+	results *IRepositoryMockWithdrawResults
+	Counter uint64
+}
+
+// IRepositoryMockWithdrawParams contains parameters of the IRepository.Withdraw
+type IRepositoryMockWithdrawParams struct {
+	ctx        context.Context
+	userID     uuid.UUID
+	funds      model.Money
+	minBalance model.Money
+	date       time.Time
+}
+
+// IRepositoryMockWithdrawResults contains results of the IRepository.Withdraw
+type IRepositoryMockWithdrawResults struct {
+	err error
+}
+
+// Expect sets up expected params for IRepository.Withdraw
+func (mmWithdraw *mIRepositoryMockWithdraw) Expect(ctx context.Context, userID uuid.UUID, funds model.Money, minBalance model.Money, date time.Time) *mIRepositoryMockWithdraw {
+	if mmWithdraw.mock.funcWithdraw != nil {
+		mmWithdraw.mock.t.Fatalf("IRepositoryMock.Withdraw mock is already set by Set")
+	}
+
+	if mmWithdraw.defaultExpectation == nil {
+		mmWithdraw.defaultExpectation = &IRepositoryMockWithdrawExpectation{}
+	}
+
+	mmWithdraw.defaultExpectation.params = &IRepositoryMockWithdrawParams{ctx, userID, funds, minBalance, date}
+	for _, e := range mmWithdraw.expectations {
+		if minimock.Equal(e.params, mmWithdraw.defaultExpectation.params) {
+			mmWithdraw.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmWithdraw.defaultExpectation.params)
+		}
+	}
+
+	return mmWithdraw
+}
+
+// Inspect accepts an inspector function that has same arguments as the IRepository.Withdraw
+func (mmWithdraw *mIRepositoryMockWithdraw) Inspect(f func(ctx context.Context, userID uuid.UUID, funds model.Money, minBalance model.Money, date time.Time)) *mIRepositoryMockWithdraw {
+	if mmWithdraw.mock.inspectFuncWithdraw != nil {
+		mmWithdraw.mock.t.Fatalf("Inspect function is already set for IRepositoryMock.Withdraw")
+	}
+
+	mmWithdraw.mock.inspectFuncWithdraw = f
+
+	return mmWithdraw
+}
+
+// Return sets up results that will be returned by IRepository.Withdraw
+func (mmWithdraw *mIRepositoryMockWithdraw) Return(err error) *IRepositoryMock {
+	if mmWithdraw.mock.funcWithdraw != nil {
+		mmWithdraw.mock.t.Fatalf("IRepositoryMock.Withdraw mock is already set by Set")
+	}
+
+	if mmWithdraw.defaultExpectation == nil {
+		mmWithdraw.defaultExpectation = &IRepositoryMockWithdrawExpectation{mock: mmWithdraw.mock}
+	}
+	mmWithdraw.defaultExpectation.results = &IRepositoryMockWithdrawResults{err}
+	return mmWithdraw.mock
+}
+
+// Set uses given function f to mock the IRepository.Withdraw method
+func (mmWithdraw *mIRepositoryMockWithdraw) Set(f func(ctx context.Context, userID uuid.UUID, funds model.Money, minBalance model.Money, date time.Time) (err error)) *IRepositoryMock {
+	if mmWithdraw.defaultExpectation != nil {
+		mmWithdraw.mock.t.Fatalf("Default expectation is already set for the IRepository.Withdraw method")
+	}
+
+	if len(mmWithdraw.expectations) > 0 {
+		mmWithdraw.mock.t.Fatalf("Some expectations are already set for the IRepository.Withdraw method")
+	}
+
+	mmWithdraw.mock.funcWithdraw = f
+	return mmWithdraw.mock
+}
+
+// When sets expectation for the IRepository.Withdraw which will trigger the result defined by the following
+// Then helper
+func (mmWithdraw *mIRepositoryMockWithdraw) When(ctx context.Context, userID uuid.UUID, funds model.Money, minBalance model.Money, date time.Time) *IRepositoryMockWithdrawExpectation {
+	if mmWithdraw.mock.funcWithdraw != nil {
+		mmWithdraw.mock.t.Fatalf("IRepositoryMock.Withdraw mock is already set by Set")
+	}
+
+	expectation := &IRepositoryMockWithdrawExpectation{
+		mock:   mmWithdraw.mock,
+		params: &IRepositoryMockWithdrawParams{ctx, userID, funds, minBalance, date},
+	}
+	mmWithdraw.expectations = append(mmWithdraw.expectations, expectation)
+	return expectation
+}
+
+// Then sets up IRepository.Withdraw return parameters for the expectation previously defined by the When method
+func (e *IRepositoryMockWithdrawExpectation) Then(err error) *IRepositoryMock {
+	e.results = &IRepositoryMockWithdrawResults{err}
+	return e.mock
+}
+
+// Withdraw implements IRepository
+func (mmWithdraw *IRepositoryMock) Withdraw(ctx context.Context, userID uuid.UUID, funds model.Money, minBalance model.Money, date time.Time) (err error) {
+	mm_atomic.AddUint64(&mmWithdraw.beforeWithdrawCounter, 1)
+	defer mm_atomic.AddUint64(&mmWithdraw.afterWithdrawCounter, 1)
+
+	if mmWithdraw.inspectFuncWithdraw != nil {
+		mmWithdraw.inspectFuncWithdraw(ctx, userID, funds, minBalance, date)
+	}
+
+	mm_params := &IRepositoryMockWithdrawParams{ctx, userID, funds, minBalance, date}
+
+	// Record call args
+	mmWithdraw.WithdrawMock.mutex.Lock()
+	mmWithdraw.WithdrawMock.callArgs = append(mmWithdraw.WithdrawMock.callArgs, mm_params)
+	mmWithdraw.WithdrawMock.mutex.Unlock()
+
+	for _, e := range mmWithdraw.WithdrawMock.expectations {
+		if minimock.Equal(e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmWithdraw.WithdrawMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmWithdraw.WithdrawMock.defaultExpectation.Counter, 1)
+		mm_want := mmWithdraw.WithdrawMock.defaultExpectation.params
+		mm_got := IRepositoryMockWithdrawParams{ctx, userID, funds, minBalance, date}
+		if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmWithdraw.t.Errorf("IRepositoryMock.Withdraw got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmWithdraw.WithdrawMock.defaultExpectation.results
+		if mm_results == nil {
+			mmWithdraw.t.Fatal("No results are set for the IRepositoryMock.Withdraw")
+		}
+		return (*mm_results).err
+	}
+	if mmWithdraw.funcWithdraw != nil {
+		return mmWithdraw.funcWithdraw(ctx, userID, funds, minBalance, date)
+	}
+	mmWithdraw.t.Fatalf("Unexpected call to IRepositoryMock.Withdraw. %v %v %v %v %v", ctx, userID, funds, minBalance, date)
+	return
+}
+
+// WithdrawAfterCounter returns a count of finished IRepositoryMock.Withdraw invocations
+func (mmWithdraw *IRepositoryMock) WithdrawAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithdraw.afterWithdrawCounter)
+}
+
+// WithdrawBeforeCounter returns a count of IRepositoryMock.Withdraw invocations
+func (mmWithdraw *IRepositoryMock) WithdrawBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithdraw.beforeWithdrawCounter)
+}
+
+// Calls returns a list of arguments used in each call to IRepositoryMock.Withdraw.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmWithdraw *mIRepositoryMockWithdraw) Calls() []*IRepositoryMockWithdrawParams {
+	mmWithdraw.mutex.RLock()
+
+	argCopy := make([]*IRepositoryMockWithdrawParams, len(mmWithdraw.callArgs))
+	copy(argCopy, mmWithdraw.callArgs)
+
+	mmWithdraw.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockWithdrawDone returns true if the count of the Withdraw invocations corresponds
+// the number of defined expectations
+func (m *IRepositoryMock) MinimockWithdrawDone() bool {
+	for _, e := range m.WithdrawMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.WithdrawMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterWithdrawCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcWithdraw != nil && mm_atomic.LoadUint64(&m.afterWithdrawCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockWithdrawInspect logs each unmet expectation
+func (m *IRepositoryMock) MinimockWithdrawInspect() {
+	for _, e := range m.WithdrawMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to IRepositoryMock.Withdraw with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.WithdrawMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterWithdrawCounter) < 1 {
+		if m.WithdrawMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to IRepositoryMock.Withdraw")
+		} else {
+			m.t.Errorf("Expected call to IRepositoryMock.Withdraw with params: %#v", *m.WithdrawMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcWithdraw != nil && mm_atomic.LoadUint64(&m.afterWithdrawCounter) < 1 {
+		m.t.Error("Expected call to IRepositoryMock.Withdraw")
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *IRepositoryMock) MinimockFinish() {
 	if !m.minimockDone() {
-		m.MinimockAddUserInspect()
+		m.MinimockAdjustInspect()
 
 		m.MinimockBalanceInspect()
 
+		m.MinimockClaimReportJobInspect()
+
+		m.MinimockCreateIdempotencyKeyInspect()
+
+		m.MinimockCreateReportJobInspect()
+
+		m.MinimockCreateServiceInspect()
+
+		m.MinimockDeleteIdempotencyKeyInspect()
+
+		m.MinimockDeleteIdempotencyKeysInspect()
+
+		m.MinimockDeleteReportJobsInspect()
+
+		m.MinimockDeleteServiceInspect()
+
+		m.MinimockDiscrepanciesInspect()
+
 		m.MinimockEnrollmentInspect()
 
+		m.MinimockExpiredOrdersInspect()
+
+		m.MinimockFinishReportJobInspect()
+
+		m.MinimockGetIdempotencyKeyInspect()
+
 		m.MinimockGetOrderInspect()
 
+		m.MinimockGetReportJobInspect()
+
+		m.MinimockGetServiceInspect()
+
 		m.MinimockHistoryInspect()
 
 		m.MinimockOrderInspect()
 
-		m.MinimockOrderFailedInspect()
-
 		m.MinimockOrderSuccessInspect()
 
+		m.MinimockPingInspect()
+
+		m.MinimockRefundInspect()
+
+		m.MinimockReleaseOrderInspect()
+
 		m.MinimockReportInspect()
 
+		m.MinimockSaveIdempotencyResponseInspect()
+
+		m.MinimockServicesInspect()
+
 		m.MinimockTransferInspect()
+
+		m.MinimockUpdateServiceInspect()
+
+		m.MinimockWithdrawInspect()
 		m.t.FailNow()
 	}
 }
@@ -2326,14 +6901,34 @@ func (m *IRepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *IRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
-		m.MinimockAddUserDone() &&
+		m.MinimockAdjustDone() &&
 		m.MinimockBalanceDone() &&
+		m.MinimockClaimReportJobDone() &&
+		m.MinimockCreateIdempotencyKeyDone() &&
+		m.MinimockCreateReportJobDone() &&
+		m.MinimockCreateServiceDone() &&
+		m.MinimockDeleteIdempotencyKeyDone() &&
+		m.MinimockDeleteIdempotencyKeysDone() &&
+		m.MinimockDeleteReportJobsDone() &&
+		m.MinimockDeleteServiceDone() &&
+		m.MinimockDiscrepanciesDone() &&
 		m.MinimockEnrollmentDone() &&
+		m.MinimockExpiredOrdersDone() &&
+		m.MinimockFinishReportJobDone() &&
+		m.MinimockGetIdempotencyKeyDone() &&
 		m.MinimockGetOrderDone() &&
+		m.MinimockGetReportJobDone() &&
+		m.MinimockGetServiceDone() &&
 		m.MinimockHistoryDone() &&
 		m.MinimockOrderDone() &&
-		m.MinimockOrderFailedDone() &&
 		m.MinimockOrderSuccessDone() &&
+		m.MinimockPingDone() &&
+		m.MinimockRefundDone() &&
+		m.MinimockReleaseOrderDone() &&
 		m.MinimockReportDone() &&
-		m.MinimockTransferDone()
+		m.MinimockSaveIdempotencyResponseDone() &&
+		m.MinimockServicesDone() &&
+		m.MinimockTransferDone() &&
+		m.MinimockUpdateServiceDone() &&
+		m.MinimockWithdrawDone()
 }
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Rates returns the exchange rate table in use.
+func (c *controller) Rates(ctx context.Context) model.Rates {
+	logrus.Infoln("Starting controller.Rates")
+
+	rates := c.currentRates()
+
+	logrus.Infoln("Ending controller.Rates")
+	return rates
+}
+
+func (c *controller) currentRates() model.Rates {
+	c.ratesMu.RLock()
+	defer c.ratesMu.RUnlock()
+	return c.rates
+}
+
+// SetRates replaces the exchange rate table. Wallets in currencies the new table
+// lacks keep their money but can no longer be topped up or converted.
+func (c *controller) SetRates(ctx context.Context, rates model.Rates) error {
+	logrus.Infoln("Starting controller.SetRates")
+
+	if err := rates.Validate(); err != nil {
+		logrus.Errorf("Validate %v: %s\n", rates, err)
+		logrus.Infoln("Ending controller.SetRates")
+		return err
+	}
+
+	c.ratesMu.Lock()
+	c.rates = rates
+	c.ratesMu.Unlock()
+
+	logrus.Infof("Exchange rates set: base %s, %d currencies\n", rates.Base, len(rates.Rates))
+	logrus.Infoln("Ending controller.SetRates")
+	return nil
+}
+
+// checkCurrency rejects money in a currency the rate table does not know.
+func (c *controller) checkCurrency(field string, m model.Money) error {
+	if c.currentRates().Supported(m.CurrencyCode()) {
+		return nil
+	}
+	logrus.Errorf("%s %s: %s\n", Err.ErrUnsupportedCurrency, field, m.CurrencyCode())
+	return Err.ErrUnsupportedCurrency.WithDetails(Err.Field(field, "no exchange rate for "+m.CurrencyCode()))
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Avito/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// Reconcile returns the users whose stored balance or held funds do not match the ledger.
+// With fix, a correcting entry is posted for each of them; users that came right in the
+// meantime are left out of the result.
+func (c *controller) Reconcile(ctx context.Context, fix bool) ([]model.Discrepancy, error) {
+	logrus.Infoln("Starting controller.Reconcile")
+
+	discrepancies, err := c.repository.Discrepancies(ctx)
+	if err != nil {
+		logrus.Infoln("Ending controller.Reconcile")
+		return nil, err
+	}
+	if !fix {
+		logrus.Infoln("Ending controller.Reconcile")
+		return discrepancies, nil
+	}
+
+	date := time.Now()
+	adjusted := make([]model.Discrepancy, 0, len(discrepancies))
+	for i, d := range discrepancies {
+		// Discrepancies come ordered by user, and Adjust corrects all currencies of a user at once.
+		if i > 0 && discrepancies[i-1].UserID == d.UserID {
+			continue
+		}
+		ds, err := c.repository.Adjust(ctx, d.UserID, date)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			logrus.Errorf("Adjust %s: %s\n", d.UserID, err)
+			logrus.Infoln("Ending controller.Reconcile")
+			return nil, err
+		}
+		for _, a := range ds {
+			logrus.Infof("Adjusted %s %s: balance %s, ledger %s, held %s, reserved %s\n", a.UserID, a.Currency, a.Balance, a.LedgerBalance, a.Held, a.Reserved)
+		}
+		adjusted = append(adjusted, ds...)
+	}
+
+	logrus.Infoln("Ending controller.Reconcile")
+	return adjusted, nil
+}
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"time"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// IStorage keeps report files. Put makes a file visible only if write succeeds;
+// Open returns an error matching fs.ErrNotExist for a missing file.
+type IStorage interface {
+	Put(ctx context.Context, name string, write func(w io.Writer) error) error
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	DeleteBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+// Report returns the revenue selected by q.
+func (c *controller) Report(ctx context.Context, q model.ReportQuery) ([]model.Report, error) {
+	logrus.Infoln("Starting controller.Report")
+
+	if err := q.Validate(); err != nil {
+		logrus.Errorf("Validate %+v: %s\n", q, err)
+		logrus.Infoln("Ending controller.Report")
+		return nil, err
+	}
+
+	rep, err := c.repository.Report(ctx, q)
+	if err != nil {
+		logrus.Infoln("Ending controller.Report")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending controller.Report")
+	return rep, nil
+}
+
+// EnqueueReport queues the report selected by q; a worker builds it with RunReportJob.
+func (c *controller) EnqueueReport(ctx context.Context, q model.ReportQuery) (*model.ReportJob, error) {
+	logrus.Infoln("Starting controller.EnqueueReport")
+
+	if err := q.Validate(); err != nil {
+		logrus.Errorf("Validate %+v: %s\n", q, err)
+		logrus.Infoln("Ending controller.EnqueueReport")
+		return nil, err
+	}
+
+	job := model.ReportJob{ID: uuid.New(), Status: model.ReportPending, Query: q, DateCreate: time.Now()}
+	if err := c.repository.CreateReportJob(ctx, job); err != nil {
+		logrus.Infoln("Ending controller.EnqueueReport")
+		return nil, err
+	}
+
+	logrus.Infof("Report %s queued\n", job.ID)
+	logrus.Infoln("Ending controller.EnqueueReport")
+	return &job, nil
+}
+
+// ReportJob returns the state of a queued report.
+func (c *controller) ReportJob(ctx context.Context, id uuid.UUID) (*model.ReportJob, error) {
+	logrus.Infoln("Starting controller.ReportJob")
+
+	job, err := c.repository.GetReportJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = Err.ErrReportNotFound
+		}
+		logrus.Infoln("Ending controller.ReportJob")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending controller.ReportJob")
+	return job, nil
+}
+
+// RunReportJob builds the oldest queued report and reports whether there was one.
+// A report is given timeout to finish; after that it is cancelled, and a job left
+// running that long by a worker that died is taken again. A job interrupted by ctx
+// goes back to the queue.
+func (c *controller) RunReportJob(ctx context.Context, timeout time.Duration) (bool, error) {
+	logrus.Infoln("Starting controller.RunReportJob")
+
+	now := time.Now()
+	job, err := c.repository.ClaimReportJob(ctx, now, now.Add(-timeout))
+	if errors.Is(err, pgx.ErrNoRows) {
+		logrus.Infoln("Ending controller.RunReportJob")
+		return false, nil
+	}
+	if err != nil {
+		logrus.Infoln("Ending controller.RunReportJob")
+		return false, err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	err = c.writeReport(runCtx, job.ID, job.Query)
+	cancel()
+
+	finishCtx := ctx
+	switch {
+	case err == nil:
+		job.Status = model.ReportDone
+	case ctx.Err() != nil:
+		// Shutting down: the job is put back for the next worker, which needs a live context.
+		job.Status = model.ReportPending
+		var finishCancel context.CancelFunc
+		finishCtx, finishCancel = context.WithTimeout(context.Background(), 5*time.Second)
+		defer finishCancel()
+	default:
+		logrus.Errorf("Report %s: %s\n", job.ID, err)
+		job.Status, job.Error = model.ReportFailed, reportError(err)
+	}
+
+	if err := c.repository.FinishReportJob(finishCtx, *job, time.Now()); err != nil {
+		logrus.Infoln("Ending controller.RunReportJob")
+		return true, err
+	}
+
+	logrus.Infof("Report %s %s\n", job.ID, job.Status)
+	logrus.Infoln("Ending controller.RunReportJob")
+	return true, nil
+}
+
+// writeReport writes the revenue selected by q to the CSV file of report id.
+func (c *controller) writeReport(ctx context.Context, id uuid.UUID, q model.ReportQuery) error {
+	rep, err := c.Report(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	err = c.storage.Put(ctx, reportName(id), func(w io.Writer) error {
+		return model.WriteReportCSV(w, q, rep)
+	})
+	if err != nil {
+		logrus.Errorf("Put %s: %s\n", id, err)
+	}
+	return err
+}
+
+// reportError is what a client is told about a failed report.
+func reportError(err error) string {
+	var e *Err.Error
+	switch {
+	case errors.As(err, &e):
+		return e.Message
+	case errors.Is(err, context.DeadlineExceeded):
+		return Err.ErrTimeout.Message
+	default:
+		return Err.ErrInternal.Message
+	}
+}
+
+// OpenReport returns the contents of a report; the caller closes it.
+func (c *controller) OpenReport(ctx context.Context, id uuid.UUID) (io.ReadCloser, error) {
+	logrus.Infoln("Starting controller.OpenReport")
+
+	r, err := c.storage.Open(ctx, reportName(id))
+	if err != nil {
+		logrus.Errorf("Open %s: %s\n", id, err)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = Err.ErrReportNotFound
+		}
+		logrus.Infoln("Ending controller.OpenReport")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending controller.OpenReport")
+	return r, nil
+}
+
+// PurgeReports deletes the reports created before the given time.
+func (c *controller) PurgeReports(ctx context.Context, before time.Time) (int, error) {
+	logrus.Infoln("Starting controller.PurgeReports")
+
+	n, err := c.storage.DeleteBefore(ctx, before)
+	if err != nil {
+		logrus.Errorf("DeleteBefore %s: %s\n", before, err)
+		logrus.Infoln("Ending controller.PurgeReports")
+		return n, err
+	}
+	if n > 0 {
+		logrus.Infof("Deleted %d reports created before %s\n", n, before)
+	}
+
+	// Jobs go with their files, so a done job always has its file.
+	jobs, err := c.repository.DeleteReportJobs(ctx, before)
+	if jobs > 0 {
+		logrus.Infof("Deleted %d report jobs created before %s\n", jobs, before)
+	}
+
+	logrus.Infoln("Ending controller.PurgeReports")
+	return n, err
+}
+
+func reportName(id uuid.UUID) string {
+	return id.String() + ".csv"
+}
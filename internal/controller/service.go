@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// serviceNotFound is the error of a service missing from the catalog.
+func serviceNotFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Err.ErrServiceNotFound.WithDetails(Err.Field("id", "is not in the catalog"))
+	}
+	return err
+}
+
+func (c *controller) Services(ctx context.Context) ([]model.Service, error) {
+	logrus.Infoln("Starting controller.Services")
+
+	services, err := c.repository.Services(ctx)
+
+	logrus.Infoln("Ending controller.Services")
+	return services, err
+}
+
+func (c *controller) Service(ctx context.Context, id uuid.UUID) (*model.Service, error) {
+	logrus.Infoln("Starting controller.Service")
+
+	service, err := c.repository.GetService(ctx, id)
+	if err != nil {
+		logrus.Infoln("Ending controller.Service")
+		return nil, serviceNotFound(err)
+	}
+
+	logrus.Infoln("Ending controller.Service")
+	return service, nil
+}
+
+// CreateService adds s to the catalog, under a new ID unless s has one.
+func (c *controller) CreateService(ctx context.Context, s model.Service) (*model.Service, error) {
+	logrus.Infoln("Starting controller.CreateService")
+
+	if err := s.Validate(); err != nil {
+		logrus.Errorf("Validate %+v: %s\n", s, err)
+		logrus.Infoln("Ending controller.CreateService")
+		return nil, err
+	}
+
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	if s.Price != nil {
+		s.Currency = s.Price.CurrencyCode()
+	}
+	s.DateCreate = time.Now()
+	s.LastUpdate = s.DateCreate
+	if err := c.repository.CreateService(ctx, s); err != nil {
+		logrus.Infoln("Ending controller.CreateService")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending controller.CreateService")
+	return &s, nil
+}
+
+// UpdateService replaces the name, price and active flag of a service. Orders placed
+// before keep their name; reports show the new one.
+func (c *controller) UpdateService(ctx context.Context, s model.Service) (*model.Service, error) {
+	logrus.Infoln("Starting controller.UpdateService")
+
+	if err := s.Validate(); err != nil {
+		logrus.Errorf("Validate %+v: %s\n", s, err)
+		logrus.Infoln("Ending controller.UpdateService")
+		return nil, err
+	}
+
+	s.LastUpdate = time.Now()
+	if err := c.repository.UpdateService(ctx, s); err != nil {
+		logrus.Infoln("Ending controller.UpdateService")
+		return nil, serviceNotFound(err)
+	}
+
+	service, err := c.repository.GetService(ctx, s.ID)
+	if err != nil {
+		logrus.Infoln("Ending controller.UpdateService")
+		return nil, serviceNotFound(err)
+	}
+
+	logrus.Infoln("Ending controller.UpdateService")
+	return service, nil
+}
+
+// DeleteService removes a service that was never ordered; others can only be deactivated.
+func (c *controller) DeleteService(ctx context.Context, id uuid.UUID) error {
+	logrus.Infoln("Starting controller.DeleteService")
+
+	err := c.repository.DeleteService(ctx, id)
+	if errors.Is(err, Err.ErrServiceInUse) {
+		err = Err.ErrServiceInUse.WithDetails(Err.Field("active", "set it to false instead"))
+	}
+
+	logrus.Infoln("Ending controller.DeleteService")
+	return serviceNotFound(err)
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// Withdrawals holds the amount a wallet must keep after a withdrawal, keyed by currency.
+// Currencies without an entry can be withdrawn to zero.
+type Withdrawals struct {
+	MinBalance map[string]model.Money
+}
+
+func (w Withdrawals) For(currency string) model.Money {
+	if m, ok := w.MinBalance[currency]; ok {
+		return m
+	}
+	return model.NewMoney(0, currency)
+}
+
+// Withdraw takes funds out of the user's wallet in their currency.
+func (c *controller) Withdraw(ctx context.Context, userID uuid.UUID, funds model.Money) error {
+	logrus.Infoln("Starting controller.Withdraw")
+
+	if err := c.checkCurrency("currency", funds); err != nil {
+		logrus.Infoln("Ending controller.Withdraw")
+		return err
+	}
+
+	minBalance := c.withdrawals.For(funds.CurrencyCode())
+	err := c.repository.Withdraw(ctx, userID, funds, minBalance, time.Now())
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		err = Err.ErrUserNotFound.WithDetails(Err.Field("user_id", "does not exist"))
+	case errors.Is(err, Err.ErrInsufficientFunds) && minBalance.IsPositive():
+		err = Err.ErrInsufficientFunds.WithDetails(Err.Field("funds", "the balance must stay at least "+minBalance.String()))
+	}
+
+	logrus.Infoln("Ending controller.Withdraw")
+	return err
+}
@@ -0,0 +1,76 @@
+package errors
+
+// Code is a stable machine readable error identifier. Clients should branch on it, not on the message.
+type Code string
+
+const (
+	CodeBadRequest                Code = "bad_request"
+	CodeInvalidAmount             Code = "invalid_amount"
+	CodeAmountOverflow            Code = "amount_overflow"
+	CodeCurrencyMismatch          Code = "currency_mismatch"
+	CodeUnsupportedCurrency       Code = "unsupported_currency"
+	CodeInsufficientFunds         Code = "insufficient_funds"
+	CodeUserNotFound              Code = "user_not_found"
+	CodeOrderNotFound             Code = "order_not_found"
+	CodeOrderMismatch             Code = "order_mismatch"
+	CodeOrderExists               Code = "order_exists"
+	CodeOrderTransition           Code = "invalid_order_transition"
+	CodeCaptureExceedsReservation Code = "capture_exceeds_reservation"
+	CodeRefundExceedsCapture      Code = "refund_exceeds_capture"
+	CodeReportNotFound            Code = "report_not_found"
+	CodeServiceNotFound           Code = "service_not_found"
+	CodeServiceExists             Code = "service_exists"
+	CodeServiceInactive           Code = "service_inactive"
+	CodeServiceInUse              Code = "service_in_use"
+	CodeNotFound                  Code = "not_found"
+	CodeForbidden                 Code = "forbidden"
+	CodeIdempotencyConflict       Code = "idempotency_conflict"
+	CodeIdempotencyInProgress     Code = "idempotency_in_progress"
+	CodeTimeout                   Code = "timeout"
+	CodeCancelled                 Code = "request_cancelled"
+	CodeUnavailable               Code = "service_unavailable"
+	CodeInternal                  Code = "internal_error"
+)
+
+// FieldError points at the request field that caused an error.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error that can be shown to a client as is.
+type Error struct {
+	Code    Code         `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is compares codes, so an error with details still matches its sentinel.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithDetails returns a copy of the error with field details added.
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	err := *e
+	err.Details = append(append([]FieldError{}, e.Details...), details...)
+	return &err
+}
+
+func Field(field, message string) FieldError {
+	return FieldError{Field: field, Message: message}
+}
+
+// Validation is ErrBadRequest with the fields that failed validation.
+func Validation(details ...FieldError) *Error {
+	return ErrBadRequest.WithDetails(details...)
+}
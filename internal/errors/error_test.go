@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	t.Run("details keep the code", func(t *testing.T) {
+		err := ErrOrderMismatch.WithDetails(Field("cost", "does not match"))
+
+		require.ErrorIs(t, err, ErrOrderMismatch)
+		require.NotErrorIs(t, err, ErrBadRequest)
+		require.Empty(t, ErrOrderMismatch.Details)
+		require.Equal(t, []FieldError{{Field: "cost", Message: "does not match"}}, err.Details)
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		err := fmt.Errorf("transfer: %w", Validation(Field("funds", "must be positive")))
+
+		var e *Error
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, CodeBadRequest, e.Code)
+		require.ErrorIs(t, err, ErrBadRequest)
+	})
+}
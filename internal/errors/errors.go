@@ -3,9 +3,45 @@ package errors
 import "errors"
 
 var (
-	ErrNoConnectionToDb  = errors.New("missing connection to DB")
-	ErrNoRepository      = errors.New("missing repository")
-	ErrNoController      = errors.New("missing controller")
-	ErrInsufficientFunds = errors.New("insufficient funds")
-	ErrBadRequest        = errors.New("wrong data")
+	ErrNoConnectionToDb = errors.New("missing connection to DB")
+	ErrNoRepository     = errors.New("missing repository")
+	ErrNoController     = errors.New("missing controller")
+	ErrNoStorage        = errors.New("missing report storage")
+	ErrBadWorkerConfig  = errors.New("wrong worker interval or batch size")
+	ErrExpireFailed     = errors.New("expired orders were not released")
+
+	ErrUnbalancedTransaction = errors.New("ledger postings do not sum to zero")
+)
+
+// Errors returned to clients. Each one has its own code.
+var (
+	ErrBadRequest          = New(CodeBadRequest, "wrong data")
+	ErrInvalidAmount       = New(CodeInvalidAmount, "invalid amount")
+	ErrAmountOverflow      = New(CodeAmountOverflow, "amount overflow")
+	ErrCurrencyMismatch    = New(CodeCurrencyMismatch, "currency mismatch")
+	ErrUnsupportedCurrency = New(CodeUnsupportedCurrency, "no exchange rate for the currency")
+	ErrInsufficientFunds   = New(CodeInsufficientFunds, "insufficient funds")
+	ErrUserNotFound        = New(CodeUserNotFound, "user not found")
+	ErrOrderNotFound       = New(CodeOrderNotFound, "order not found")
+	ErrOrderMismatch       = New(CodeOrderMismatch, "order fields do not match the reservation")
+	ErrOrderExists         = New(CodeOrderExists, "order already exists")
+	ErrOrderTransition     = New(CodeOrderTransition, "order cannot move to this status")
+
+	ErrCaptureExceedsReservation = New(CodeCaptureExceedsReservation, "captured amount exceeds the reservation")
+	ErrRefundExceedsCapture      = New(CodeRefundExceedsCapture, "refund exceeds the captured amount")
+	ErrReportNotFound            = New(CodeReportNotFound, "report not found")
+	ErrServiceNotFound           = New(CodeServiceNotFound, "service not found")
+	ErrServiceExists             = New(CodeServiceExists, "service already exists")
+	ErrServiceInactive           = New(CodeServiceInactive, "service is not active")
+	ErrServiceInUse              = New(CodeServiceInUse, "service has orders or revenue")
+	ErrNotFound                  = New(CodeNotFound, "not found")
+	ErrForbidden                 = New(CodeForbidden, "admin token is missing or wrong")
+
+	ErrIdempotencyConflict   = New(CodeIdempotencyConflict, "idempotency key reused with another request")
+	ErrIdempotencyInProgress = New(CodeIdempotencyInProgress, "request with this idempotency key is in progress")
+
+	ErrTimeout     = New(CodeTimeout, "request timeout")
+	ErrCancelled   = New(CodeCancelled, "request cancelled")
+	ErrUnavailable = New(CodeUnavailable, "database unavailable")
+	ErrInternal    = New(CodeInternal, "internal error")
 )
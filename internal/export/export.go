@@ -0,0 +1,156 @@
+// Package export writes tables in the formats reports are downloaded in.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Format is a file format a table can be written in.
+type Format string
+
+const (
+	CSV   Format = "csv"
+	XLSX  Format = "xlsx"
+	JSONL Format = "jsonl"
+)
+
+var (
+	ErrBadFormat    = errors.New("unknown export format")
+	ErrBadDelimiter = errors.New("wrong CSV delimiter")
+)
+
+// ContentType is the MIME type of files in format f.
+func (f Format) ContentType() string {
+	switch f {
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case JSONL:
+		return "application/jsonl; charset=utf-8"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Options shape the written table. Delimiter separates CSV fields (',' when zero); Header
+// adds a row of column names to CSV and XLSX. Cells of the Numeric columns that hold
+// a number are written as numbers where the format has them.
+type Options struct {
+	Delimiter rune
+	Header    bool
+	Numeric   []string
+}
+
+// Writer writes a table row by row; Close finishes the file but leaves w open.
+type Writer interface {
+	Write(record []string) error
+	Close() error
+}
+
+// New returns a writer of a table with the given columns in format f.
+func New(f Format, w io.Writer, columns []string, options Options) (Writer, error) {
+	numeric := make([]bool, len(columns))
+	for i, column := range columns {
+		for _, n := range options.Numeric {
+			numeric[i] = numeric[i] || column == n
+		}
+	}
+
+	switch f {
+	case CSV:
+		return newCSV(w, columns, options)
+	case XLSX:
+		return newXLSX(w, columns, numeric, options.Header)
+	case JSONL:
+		return &jsonlWriter{w: w, columns: columns, numeric: numeric}, nil
+	default:
+		return nil, ErrBadFormat
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSV(w io.Writer, columns []string, options Options) (*csvWriter, error) {
+	delimiter := options.Delimiter
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	if delimiter == '"' || delimiter == '\r' || delimiter == '\n' || !utf8.ValidRune(delimiter) || delimiter == utf8.RuneError {
+		return nil, ErrBadDelimiter
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+	c := &csvWriter{writer: writer}
+	if options.Header {
+		if err := c.Write(columns); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *csvWriter) Write(record []string) error {
+	return c.writer.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// jsonlWriter writes a JSON object per row, keyed by column name in column order.
+type jsonlWriter struct {
+	w       io.Writer
+	columns []string
+	numeric []bool
+}
+
+func (j *jsonlWriter) Write(record []string) error {
+	line := []byte{'{'}
+	for i, value := range record {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		column := "column" + strconv.Itoa(i+1)
+		if i < len(j.columns) {
+			column = j.columns[i]
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		line = append(append(line, key...), ':')
+
+		if i < len(j.numeric) && j.numeric[i] && isNumber(value) {
+			line = append(line, value...)
+			continue
+		}
+		v, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		line = append(line, v...)
+	}
+	line = append(line, '}', '\n')
+	_, err := j.w.Write(line)
+	return err
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+// isNumber reports whether s is a plain decimal number, valid both in JSON and in a spreadsheet.
+func isNumber(s string) bool {
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return false
+	}
+	return json.Valid([]byte(s))
+}
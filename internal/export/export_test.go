@@ -0,0 +1,81 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	columns = []string{"service_name", "revenue", "currency"}
+	rows    = [][]string{
+		{`Доставка; "экспресс"`, "1500.50", "RUB"},
+		{"Line\nbreak", "-20.00", "USD"},
+	}
+)
+
+func write(t *testing.T, f Format, options Options) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := New(f, &buf, columns, options)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	require.Equal(t, "\"Доставка; \"\"экспресс\"\"\",1500.50,RUB\n\"Line\nbreak\",-20.00,USD\n", string(write(t, CSV, Options{})))
+
+	require.Equal(t, "service_name;revenue;currency\n"+
+		"\"Доставка; \"\"экспресс\"\"\";1500.50;RUB\n\"Line\nbreak\";-20.00;USD\n", string(write(t, CSV, Options{Delimiter: ';', Header: true})))
+
+	_, err := New(CSV, io.Discard, columns, Options{Delimiter: '"'})
+	require.ErrorIs(t, err, ErrBadDelimiter)
+	_, err = New("pdf", io.Discard, columns, Options{})
+	require.ErrorIs(t, err, ErrBadFormat)
+}
+
+func TestJSONL(t *testing.T) {
+	require.Equal(t, `{"service_name":"Доставка; \"экспресс\"","revenue":1500.50,"currency":"RUB"}`+"\n"+
+		`{"service_name":"Line\nbreak","revenue":-20.00,"currency":"USD"}`+"\n", string(write(t, JSONL, Options{Header: true, Numeric: []string{"revenue"}})))
+
+	// Without the numeric hint every value is a string.
+	require.Contains(t, string(write(t, JSONL, Options{})), `"revenue":"1500.50"`)
+}
+
+func TestXLSX(t *testing.T) {
+	data := write(t, XLSX, Options{Header: true, Numeric: []string{"revenue"}})
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = string(content)
+	}
+	require.Contains(t, files, "[Content_Types].xml")
+	require.Contains(t, files, "xl/workbook.xml")
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	require.True(t, strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+	require.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">service_name</t></is></c>`+
+		`<c r="B1" t="inlineStr"><is><t xml:space="preserve">revenue</t></is></c>`)
+	require.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Доставка; &#34;экспресс&#34;</t></is></c><c r="B2"><v>1500.50</v></c>`)
+	require.Contains(t, sheet, `<c r="A3" t="inlineStr"><is><t xml:space="preserve">Line&#xA;break</t></is></c>`)
+}
+
+func TestColumnName(t *testing.T) {
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		require.Equal(t, name, columnName(i))
+	}
+}
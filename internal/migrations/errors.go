@@ -0,0 +1,11 @@
+package migrations
+
+import "errors"
+
+var (
+	ErrBadFileName      = errors.New("wrong migration file name")
+	ErrDuplicateVersion = errors.New("duplicate migration version")
+	ErrMissingDirection = errors.New("migration must have up and down scripts")
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	ErrUnknownVersion   = errors.New("database has a migration unknown to this build")
+)
@@ -0,0 +1,286 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// lockID is the pg_advisory_lock key that keeps concurrent replicas from migrating at once.
+const lockID = 7_318_420_001
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status describes a known migration and whether it is applied to the database.
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type applied struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Load reads the embedded migrations ordered by version.
+func Load() ([]Migration, error) {
+	return load(files)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", ErrBadFileName, entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		data, err := fs.ReadFile(fsys, path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateVersion, version)
+		}
+
+		if match[3] == "up" {
+			m.Up = string(data)
+			sum := sha256.Sum256(data)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("%w: %d", ErrMissingDirection, m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies every pending migration, each in its own transaction.
+func Up(ctx context.Context, pool *pgxpool.Pool) error {
+	logrus.Infoln("Starting migrations.Up")
+
+	err := withLock(ctx, pool, func(conn *pgxpool.Conn, migrations []Migration, done map[int]applied) error {
+		for _, m := range migrations {
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
+
+			logrus.Infof("Applying migration %04d_%s\n", m.Version, m.Name)
+			query := `INSERT INTO public.schema_migrations(version, name, checksum, applied_at)
+					  VALUES
+					  ($1, $2, $3, $4);`
+			if err := inTx(ctx, conn, m.Up, query, m.Version, m.Name, m.Checksum, time.Now()); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+
+	logrus.Infoln("Ending migrations.Up")
+	return err
+}
+
+// Down reverts the last steps applied migrations.
+func Down(ctx context.Context, pool *pgxpool.Pool, steps int) error {
+	logrus.Infoln("Starting migrations.Down")
+
+	err := withLock(ctx, pool, func(conn *pgxpool.Conn, migrations []Migration, done map[int]applied) error {
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			m := migrations[i]
+			if _, ok := done[m.Version]; !ok {
+				continue
+			}
+
+			logrus.Infof("Reverting migration %04d_%s\n", m.Version, m.Name)
+			query := `DELETE FROM public.schema_migrations
+					  WHERE version = $1;`
+			if err := inTx(ctx, conn, m.Down, query, m.Version); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+			}
+			steps--
+		}
+		return nil
+	})
+
+	logrus.Infoln("Ending migrations.Down")
+	return err
+}
+
+// Baseline marks migrations up to version as applied without running them.
+// It is meant for databases created from the old init.sql: 0001 is that script as first
+// released, and the later migrations tolerate the edits made to it afterwards, so baselining
+// version 1 fits every version of it.
+func Baseline(ctx context.Context, pool *pgxpool.Pool, version int) error {
+	logrus.Infoln("Starting migrations.Baseline")
+
+	err := withLock(ctx, pool, func(conn *pgxpool.Conn, migrations []Migration, done map[int]applied) error {
+		for _, m := range migrations {
+			if m.Version > version {
+				break
+			}
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
+
+			query := `INSERT INTO public.schema_migrations(version, name, checksum, applied_at)
+					  VALUES
+					  ($1, $2, $3, $4);`
+			if _, err := conn.Exec(ctx, query, m.Version, m.Name, m.Checksum, time.Now()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	logrus.Infoln("Ending migrations.Baseline")
+	return err
+}
+
+// CurrentStatus lists the embedded migrations together with their state in the database.
+func CurrentStatus(ctx context.Context, pool *pgxpool.Pool) ([]Status, error) {
+	var statuses []Status
+
+	err := withLock(ctx, pool, func(conn *pgxpool.Conn, migrations []Migration, done map[int]applied) error {
+		for _, m := range migrations {
+			a, ok := done[m.Version]
+			statuses = append(statuses, Status{Version: m.Version, Name: m.Name, Applied: ok, AppliedAt: a.appliedAt})
+		}
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock serialises migrators, makes sure the version table exists and
+// verifies that applied migrations were not edited afterwards.
+func withLock(ctx context.Context, pool *pgxpool.Pool, f func(*pgxpool.Conn, []Migration, map[int]applied) error) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1);`, lockID); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1);`, lockID); err != nil {
+			logrus.Errorln("Unlock: ", err)
+		}
+	}()
+
+	query := `CREATE TABLE IF NOT EXISTS public.schema_migrations
+			  (
+			      version integer PRIMARY KEY,
+			      name text NOT NULL,
+			      checksum text NOT NULL,
+			      applied_at timestamp NOT NULL
+			  );`
+	if _, err := conn.Exec(ctx, query); err != nil {
+		return err
+	}
+
+	done, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	known := map[int]bool{}
+	for _, m := range migrations {
+		known[m.Version] = true
+		if a, ok := done[m.Version]; ok && a.checksum != m.Checksum {
+			return fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, m.Version, m.Name)
+		}
+	}
+	for version := range done {
+		if !known[version] {
+			return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+		}
+	}
+
+	return f(conn, migrations, done)
+}
+
+func appliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int]applied, error) {
+	rows, err := conn.Query(ctx, `SELECT version, name, checksum, applied_at FROM public.schema_migrations;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[int]applied{}
+	for rows.Next() {
+		var (
+			version int
+			a       applied
+		)
+		if err := rows.Scan(&version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = a
+	}
+	return done, rows.Err()
+}
+
+// inTx runs a migration script and its bookkeeping statement atomically.
+func inTx(ctx context.Context, conn *pgxpool.Conn, script, query string, args ...any) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, script); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, query, args...)
+		return err
+	})
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Embedded(t *testing.T) {
+	migrations, err := Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		require.Equal(t, i+1, m.Version, "versions must be consecutive")
+		require.NotEmpty(t, m.Checksum)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/0002_second.up.sql":   {Data: []byte("CREATE TABLE b();")},
+			"sql/0002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+			"sql/0001_first.up.sql":    {Data: []byte("CREATE TABLE a();")},
+			"sql/0001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+		}
+
+		migrations, err := load(fsys)
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		require.Equal(t, 1, migrations[0].Version)
+		require.Equal(t, "first", migrations[0].Name)
+		require.Equal(t, "DROP TABLE a;", migrations[0].Down)
+		require.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+	})
+
+	t.Run("failed: bad name", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/first.sql": {Data: []byte("")}}
+
+		_, err := load(fsys)
+		require.ErrorIs(t, err, ErrBadFileName)
+	})
+
+	t.Run("failed: missing down", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/0001_first.up.sql": {Data: []byte("CREATE TABLE a();")}}
+
+		_, err := load(fsys)
+		require.ErrorIs(t, err, ErrMissingDirection)
+	})
+
+	t.Run("failed: duplicate version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/0001_first.up.sql":    {Data: []byte("CREATE TABLE a();")},
+			"sql/0001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+			"sql/0001_second.up.sql":   {Data: []byte("CREATE TABLE b();")},
+			"sql/0001_second.down.sql": {Data: []byte("DROP TABLE b;")},
+		}
+
+		_, err := load(fsys)
+		require.ErrorIs(t, err, ErrDuplicateVersion)
+	})
+}
@@ -0,0 +1,3 @@
+DROP TABLE public.accounting;
+DROP TABLE public.order;
+DROP TABLE public.user;
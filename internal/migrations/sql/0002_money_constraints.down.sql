@@ -0,0 +1,12 @@
+-- Rows without an order cannot be kept under the order_id primary key.
+DELETE FROM public.accounting WHERE order_id IS NULL;
+ALTER TABLE public.accounting DROP COLUMN id;
+ALTER TABLE public.accounting ADD PRIMARY KEY (order_id);
+ALTER TABLE public.accounting ALTER COLUMN funds TYPE decimal;
+
+ALTER TABLE public.order ALTER COLUMN funds TYPE decimal;
+
+ALTER TABLE public.user DROP CONSTRAINT user_balance_check;
+ALTER TABLE public.user ALTER COLUMN balance DROP NOT NULL;
+ALTER TABLE public.user ALTER COLUMN balance DROP DEFAULT;
+ALTER TABLE public.user ALTER COLUMN balance TYPE decimal;
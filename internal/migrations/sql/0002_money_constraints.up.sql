@@ -0,0 +1,22 @@
+-- Amounts are kept in kopecks, wallets cannot go negative and every accounting row has its
+-- own id, so transfers and enrollments, which have no order, can be recorded.
+-- The schema of the later init.sql already had these, so every step tolerates them.
+ALTER TABLE public.user ALTER COLUMN balance TYPE decimal(20, 2);
+UPDATE public.user SET balance = 0 WHERE balance IS NULL;
+ALTER TABLE public.user ALTER COLUMN balance SET DEFAULT 0;
+ALTER TABLE public.user ALTER COLUMN balance SET NOT NULL;
+ALTER TABLE public.user DROP CONSTRAINT IF EXISTS user_balance_check;
+ALTER TABLE public.user ADD CONSTRAINT user_balance_check CHECK (balance >= 0);
+
+ALTER TABLE public.order ALTER COLUMN funds TYPE decimal(20, 2);
+ALTER TABLE public.accounting ALTER COLUMN funds TYPE decimal(20, 2);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_schema = 'public' AND table_name = 'accounting' AND column_name = 'id') THEN
+        ALTER TABLE public.accounting DROP CONSTRAINT accounting_pkey;
+        ALTER TABLE public.accounting ADD COLUMN id bigserial PRIMARY KEY;
+    END IF;
+END;
+$$;
@@ -0,0 +1,9 @@
+-- The later init.sql already created the table, so databases made from it are upgraded as well.
+CREATE TABLE IF NOT EXISTS public.idempotency
+(
+    key text PRIMARY KEY,
+    request_hash text NOT NULL,
+    status integer,
+    body bytea,
+    date_create timestamp NOT NULL
+);
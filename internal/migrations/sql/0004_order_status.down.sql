@@ -0,0 +1,10 @@
+DELETE FROM public.order WHERE status <> 'reserved';
+
+DROP INDEX public.order_status_reserved_at_idx;
+
+ALTER TABLE public.order
+    DROP COLUMN status,
+    DROP COLUMN reserved_at,
+    DROP COLUMN confirmed_at,
+    DROP COLUMN cancelled_at,
+    DROP COLUMN expired_at;
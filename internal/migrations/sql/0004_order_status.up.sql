@@ -0,0 +1,13 @@
+ALTER TABLE public.order
+    ADD COLUMN status text NOT NULL DEFAULT 'reserved'
+        CHECK (status IN ('reserved', 'confirmed', 'cancelled', 'expired')),
+    ADD COLUMN reserved_at timestamp,
+    ADD COLUMN confirmed_at timestamp,
+    ADD COLUMN cancelled_at timestamp,
+    ADD COLUMN expired_at timestamp;
+
+UPDATE public.order SET reserved_at = date_create;
+
+ALTER TABLE public.order ALTER COLUMN reserved_at SET NOT NULL;
+
+CREATE INDEX order_status_reserved_at_idx ON public.order (status, reserved_at);
@@ -0,0 +1,3 @@
+DROP INDEX public.order_reserved_expires_at_idx;
+
+ALTER TABLE public.order DROP COLUMN expires_at;
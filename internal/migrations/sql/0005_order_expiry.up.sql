@@ -0,0 +1,3 @@
+ALTER TABLE public.order ADD COLUMN expires_at timestamp;
+
+CREATE INDEX order_reserved_expires_at_idx ON public.order (expires_at) WHERE status = 'reserved';
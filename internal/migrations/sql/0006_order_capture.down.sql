@@ -0,0 +1,3 @@
+ALTER TABLE public.order
+    DROP COLUMN captured,
+    DROP COLUMN refunded;
@@ -0,0 +1,9 @@
+ALTER TABLE public.order
+    ADD COLUMN captured decimal(20, 2),
+    ADD COLUMN refunded decimal(20, 2) NOT NULL DEFAULT 0;
+
+UPDATE public.order SET captured = funds WHERE status = 'confirmed';
+
+ALTER TABLE public.order
+    ADD CONSTRAINT order_captured_check CHECK (captured >= 0 AND captured <= funds),
+    ADD CONSTRAINT order_refunded_check CHECK (refunded >= 0 AND refunded <= COALESCE(captured, 0));
@@ -0,0 +1,35 @@
+CREATE TABLE public.accounting
+(
+    id bigserial PRIMARY KEY,
+    order_id uuid,
+    user_id uuid REFERENCES public.user(id),
+    service_id uuid,
+    service_name text NOT NULL,
+    date_create date NOT NULL,
+    funds decimal(20, 2)
+);
+
+-- One row per user posting, as the service wrote them before the ledger; reservations had no rows.
+INSERT INTO public.accounting(order_id, user_id, service_id, service_name, date_create, funds)
+SELECT t.order_id, a.user_id, t.service_id,
+       CASE
+           WHEN t.kind IN ('capture', 'refund') THEN t.service_name
+           WHEN t.kind = 'release' THEN 'Released'
+           WHEN t.kind = 'transfer' AND p.amount < 0 THEN 'Transferred'
+           ELSE 'Replenished'
+       END,
+       t.date_create,
+       CASE WHEN t.kind = 'refund' THEN -p.amount ELSE abs(p.amount) END
+FROM public.ledger_transaction t
+JOIN public.ledger_posting p ON p.transaction_id = t.id
+JOIN public.ledger_account a ON a.id = p.account_id
+WHERE t.kind = 'capture' AND a.kind IN ('user', 'hold')
+   OR t.kind IN ('enrollment', 'transfer', 'release', 'refund') AND a.kind = 'user'
+ORDER BY t.id, p.id;
+
+DROP VIEW public.ledger_balance;
+DROP TRIGGER ledger_posting_balanced ON public.ledger_posting;
+DROP FUNCTION public.ledger_check_balanced();
+DROP TABLE public.ledger_posting;
+DROP TABLE public.ledger_transaction;
+DROP TABLE public.ledger_account;
@@ -0,0 +1,129 @@
+-- Double-entry ledger: every money movement is a transaction whose postings sum to zero.
+-- The balance of an account is the sum of its postings; public.user.balance is kept
+-- in the same transactions and can be verified against the 'user' accounts.
+CREATE TABLE public.ledger_account
+(
+    id bigserial PRIMARY KEY,
+    kind text NOT NULL CHECK (kind IN ('user', 'hold', 'revenue', 'external')),
+    user_id uuid REFERENCES public.user(id),
+    service_id uuid,
+    CHECK (
+        kind IN ('user', 'hold') AND user_id IS NOT NULL AND service_id IS NULL
+        OR kind = 'revenue' AND user_id IS NULL AND service_id IS NOT NULL
+        OR kind = 'external' AND user_id IS NULL AND service_id IS NULL
+    )
+);
+
+CREATE UNIQUE INDEX ledger_account_owner_idx ON public.ledger_account
+    (kind, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'), COALESCE(service_id, '00000000-0000-0000-0000-000000000000'));
+
+CREATE TABLE public.ledger_transaction
+(
+    id bigserial PRIMARY KEY,
+    kind text NOT NULL CHECK (kind IN ('enrollment', 'transfer', 'reserve', 'capture', 'release', 'refund')),
+    order_id uuid,
+    service_id uuid,
+    service_name text,
+    date_create timestamp NOT NULL
+);
+
+CREATE INDEX ledger_transaction_date_create_idx ON public.ledger_transaction (date_create);
+
+CREATE TABLE public.ledger_posting
+(
+    id bigserial PRIMARY KEY,
+    transaction_id bigint NOT NULL REFERENCES public.ledger_transaction(id),
+    account_id bigint NOT NULL REFERENCES public.ledger_account(id),
+    amount decimal(20, 2) NOT NULL CHECK (amount <> 0)
+);
+
+CREATE INDEX ledger_posting_transaction_id_idx ON public.ledger_posting (transaction_id);
+CREATE INDEX ledger_posting_account_id_idx ON public.ledger_posting (account_id);
+
+-- Checked at commit, so the postings of a transaction may be inserted one by one.
+CREATE FUNCTION public.ledger_check_balanced() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT SUM(amount) FROM public.ledger_posting WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+        RAISE EXCEPTION 'ledger transaction % is not balanced', NEW.transaction_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_posting_balanced
+    AFTER INSERT OR UPDATE ON public.ledger_posting
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION public.ledger_check_balanced();
+
+CREATE VIEW public.ledger_balance AS
+SELECT a.id AS account_id, a.kind, a.user_id, a.service_id, COALESCE(SUM(p.amount), 0) AS balance
+FROM public.ledger_account a
+LEFT JOIN public.ledger_posting p ON p.account_id = a.id
+GROUP BY a.id;
+
+-- Accounts for the existing data.
+INSERT INTO public.ledger_account(kind) VALUES ('external');
+INSERT INTO public.ledger_account(kind, user_id) SELECT 'user', id FROM public.user;
+INSERT INTO public.ledger_account(kind, user_id)
+SELECT DISTINCT 'hold', user_id FROM public.order WHERE user_id IS NOT NULL;
+INSERT INTO public.ledger_account(kind, service_id)
+SELECT DISTINCT 'revenue', service_id FROM public.accounting WHERE service_id IS NOT NULL;
+
+-- Every order kept in public.order was reserved: the cost moved from the wallet to the hold.
+ALTER TABLE public.ledger_transaction ADD COLUMN accounting_id bigint;
+
+INSERT INTO public.ledger_transaction(kind, order_id, service_id, service_name, date_create)
+SELECT 'reserve', order_id, service_id, service_name, COALESCE(reserved_at, date_create)
+FROM public.order
+WHERE user_id IS NOT NULL AND funds > 0;
+
+INSERT INTO public.ledger_posting(transaction_id, account_id, amount)
+SELECT t.id, a.id, p.amount
+FROM public.ledger_transaction t
+JOIN public.order o ON o.order_id = t.order_id
+CROSS JOIN LATERAL (VALUES ('user', -o.funds), ('hold', o.funds)) AS p(kind, amount)
+JOIN public.ledger_account a ON a.kind = p.kind AND a.user_id = o.user_id
+WHERE t.kind = 'reserve';
+
+-- Accounting rows. Transfers were written as two unrelated rows, so each half is posted
+-- against the external account. Revenue of orders deleted before 0004 is taken from the wallet.
+INSERT INTO public.ledger_transaction(kind, order_id, service_id, service_name, date_create, accounting_id)
+SELECT CASE
+           WHEN service_id IS NOT NULL AND funds < 0 THEN 'refund'
+           WHEN service_id IS NOT NULL THEN 'capture'
+           WHEN service_name = 'Released' THEN 'release'
+           WHEN service_name = 'Transferred' THEN 'transfer'
+           ELSE 'enrollment'
+       END,
+       order_id, service_id, CASE WHEN service_id IS NOT NULL THEN service_name END, date_create, id
+FROM public.accounting
+WHERE user_id IS NOT NULL AND funds <> 0;
+
+INSERT INTO public.ledger_posting(transaction_id, account_id, amount)
+SELECT t.id, a.id, p.amount
+FROM public.ledger_transaction t
+JOIN public.accounting ac ON ac.id = t.accounting_id
+LEFT JOIN public.order o ON o.order_id = ac.order_id
+CROSS JOIN LATERAL (
+    SELECT * FROM (VALUES
+        ('enrollment', 'external', -ac.funds),
+        ('enrollment', 'user', ac.funds),
+        ('transfer', 'user', -ac.funds),
+        ('transfer', 'external', ac.funds),
+        ('release', 'hold', -ac.funds),
+        ('release', 'user', ac.funds),
+        ('capture', CASE WHEN o.order_id IS NULL THEN 'user' ELSE 'hold' END, -ac.funds),
+        ('capture', 'revenue', ac.funds),
+        ('refund', 'revenue', ac.funds),
+        ('refund', 'user', -ac.funds)
+    ) AS v(transaction_kind, kind, amount)
+    WHERE v.transaction_kind = t.kind
+) AS p
+JOIN public.ledger_account a ON a.kind = p.kind
+    AND a.user_id IS NOT DISTINCT FROM CASE WHEN p.kind IN ('user', 'hold') THEN ac.user_id END
+    AND a.service_id IS NOT DISTINCT FROM CASE WHEN p.kind = 'revenue' THEN ac.service_id END;
+
+ALTER TABLE public.ledger_transaction DROP COLUMN accounting_id;
+
+DROP TABLE public.accounting;
@@ -0,0 +1,9 @@
+-- Corrections are dropped with the kind; running the reconciliation again writes them anew.
+DELETE FROM public.ledger_posting
+WHERE transaction_id IN (SELECT id FROM public.ledger_transaction WHERE kind = 'adjustment');
+DELETE FROM public.ledger_transaction WHERE kind = 'adjustment';
+
+ALTER TABLE public.ledger_transaction
+    DROP CONSTRAINT ledger_transaction_kind_check,
+    ADD CONSTRAINT ledger_transaction_kind_check
+        CHECK (kind IN ('enrollment', 'transfer', 'reserve', 'capture', 'release', 'refund'));
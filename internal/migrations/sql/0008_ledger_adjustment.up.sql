@@ -0,0 +1,5 @@
+-- Correcting entries written by reconciliation.
+ALTER TABLE public.ledger_transaction
+    DROP CONSTRAINT ledger_transaction_kind_check,
+    ADD CONSTRAINT ledger_transaction_kind_check
+        CHECK (kind IN ('enrollment', 'transfer', 'reserve', 'capture', 'release', 'refund', 'adjustment'));
@@ -0,0 +1,45 @@
+-- Only RUB survives: other wallets, orders and ledger entries cannot be expressed without a currency.
+DROP VIEW public.ledger_balance;
+
+DELETE FROM public.ledger_posting
+WHERE transaction_id IN (
+    SELECT p.transaction_id
+    FROM public.ledger_posting p
+    JOIN public.ledger_account a ON a.id = p.account_id
+    WHERE a.currency <> 'RUB'
+);
+DELETE FROM public.ledger_transaction t
+WHERE NOT EXISTS (SELECT 1 FROM public.ledger_posting p WHERE p.transaction_id = t.id);
+DELETE FROM public.ledger_account WHERE currency <> 'RUB';
+
+DROP INDEX public.ledger_account_owner_idx;
+ALTER TABLE public.ledger_account DROP COLUMN currency;
+CREATE UNIQUE INDEX ledger_account_owner_idx ON public.ledger_account
+    (kind, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'), COALESCE(service_id, '00000000-0000-0000-0000-000000000000'));
+
+CREATE OR REPLACE FUNCTION public.ledger_check_balanced() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT SUM(amount) FROM public.ledger_posting WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+        RAISE EXCEPTION 'ledger transaction % is not balanced', NEW.transaction_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE VIEW public.ledger_balance AS
+SELECT a.id AS account_id, a.kind, a.user_id, a.service_id, COALESCE(SUM(p.amount), 0) AS balance
+FROM public.ledger_account a
+LEFT JOIN public.ledger_posting p ON p.account_id = a.id
+GROUP BY a.id;
+
+DELETE FROM public.order WHERE currency <> 'RUB';
+ALTER TABLE public.order DROP COLUMN currency;
+
+ALTER TABLE public.user ADD COLUMN balance decimal(20, 2) NOT NULL DEFAULT 0 CHECK (balance >= 0);
+UPDATE public.user u
+SET balance = w.balance
+FROM public.wallet w
+WHERE w.user_id = u.id AND w.currency = 'RUB';
+
+DROP TABLE public.wallet;
@@ -0,0 +1,51 @@
+-- A user has one wallet per currency; the old single balance becomes the RUB wallet.
+CREATE TABLE public.wallet
+(
+    user_id uuid NOT NULL REFERENCES public.user(id),
+    currency text NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    balance decimal(20, 2) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    date_create timestamp NOT NULL,
+    last_update timestamp NOT NULL,
+    PRIMARY KEY (user_id, currency)
+);
+
+INSERT INTO public.wallet(user_id, currency, balance, date_create, last_update)
+SELECT id, 'RUB', balance, date_create, last_update FROM public.user;
+
+ALTER TABLE public.user DROP COLUMN balance;
+
+ALTER TABLE public.order ADD COLUMN currency text NOT NULL DEFAULT 'RUB';
+ALTER TABLE public.order ALTER COLUMN currency DROP DEFAULT;
+
+-- Ledger accounts are kept per currency and a transaction balances in each of them.
+DROP VIEW public.ledger_balance;
+
+ALTER TABLE public.ledger_account ADD COLUMN currency text NOT NULL DEFAULT 'RUB';
+ALTER TABLE public.ledger_account ALTER COLUMN currency DROP DEFAULT;
+
+DROP INDEX public.ledger_account_owner_idx;
+CREATE UNIQUE INDEX ledger_account_owner_idx ON public.ledger_account
+    (kind, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'), COALESCE(service_id, '00000000-0000-0000-0000-000000000000'), currency);
+
+CREATE OR REPLACE FUNCTION public.ledger_check_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM public.ledger_posting p
+        JOIN public.ledger_account a ON a.id = p.account_id
+        WHERE p.transaction_id = NEW.transaction_id
+        GROUP BY a.currency
+        HAVING SUM(p.amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'ledger transaction % is not balanced', NEW.transaction_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE VIEW public.ledger_balance AS
+SELECT a.id AS account_id, a.kind, a.user_id, a.service_id, a.currency, COALESCE(SUM(p.amount), 0) AS balance
+FROM public.ledger_account a
+LEFT JOIN public.ledger_posting p ON p.account_id = a.id
+GROUP BY a.id;
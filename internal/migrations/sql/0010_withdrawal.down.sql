@@ -0,0 +1,7 @@
+-- Withdrawals are kept as transfers to the outside world, so the wallets stay explained by the ledger.
+UPDATE public.ledger_transaction SET kind = 'transfer' WHERE kind = 'withdrawal';
+
+ALTER TABLE public.ledger_transaction
+    DROP CONSTRAINT ledger_transaction_kind_check,
+    ADD CONSTRAINT ledger_transaction_kind_check
+        CHECK (kind IN ('enrollment', 'transfer', 'reserve', 'capture', 'release', 'refund', 'adjustment'));
@@ -0,0 +1,5 @@
+-- Money leaving the service through POST /withdraw.
+ALTER TABLE public.ledger_transaction
+    DROP CONSTRAINT ledger_transaction_kind_check,
+    ADD CONSTRAINT ledger_transaction_kind_check
+        CHECK (kind IN ('enrollment', 'transfer', 'reserve', 'capture', 'release', 'refund', 'adjustment', 'withdrawal'));
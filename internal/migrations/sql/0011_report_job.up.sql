@@ -0,0 +1,18 @@
+-- Reports built in the background: POST /report queues a job and GET /report/{id} polls it.
+-- A job is running from the time a worker takes it; one running since before the job timeout
+-- belongs to a worker that died and is taken again.
+CREATE TABLE public.report_job
+(
+    id uuid PRIMARY KEY,
+    status text NOT NULL CHECK (status IN ('pending', 'running', 'done', 'failed')),
+    date_from timestamp NOT NULL,
+    date_to timestamp NOT NULL,
+    period text NOT NULL,
+    group_by text NOT NULL,
+    error text,
+    date_create timestamp NOT NULL,
+    started_at timestamp,
+    finished_at timestamp
+);
+
+CREATE INDEX report_job_queue_idx ON public.report_job (date_create) WHERE status IN ('pending', 'running');
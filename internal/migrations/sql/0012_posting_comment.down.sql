@@ -0,0 +1,4 @@
+ALTER TABLE public.ledger_posting
+    DROP COLUMN comment,
+    DROP COLUMN counterparty_kind,
+    DROP COLUMN counterparty_id;
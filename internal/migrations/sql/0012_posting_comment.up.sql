@@ -0,0 +1,48 @@
+-- What an entry means to the owner of its account: a comment for people and the other
+-- side of the movement. Entries of orders have the service on the other side.
+ALTER TABLE public.ledger_posting
+    ADD COLUMN comment text,
+    ADD COLUMN counterparty_kind text CHECK (counterparty_kind IN ('user', 'service', 'external')),
+    ADD COLUMN counterparty_id uuid,
+    ADD CHECK ((counterparty_kind = 'external') = (counterparty_id IS NULL));
+
+-- Every transaction has two postings, so the other side of an entry is the other posting.
+WITH entry AS (
+    SELECT p.id, t.kind, t.order_id, t.service_name, a.kind AS account_kind, p.amount,
+           CASE
+               WHEN t.service_id IS NOT NULL AND a.kind IN ('user', 'hold') THEN 'service'
+               WHEN o.kind IN ('user', 'hold') THEN 'user'
+               WHEN o.kind = 'revenue' THEN 'service'
+               ELSE 'external'
+           END AS counterparty_kind,
+           CASE
+               WHEN t.service_id IS NOT NULL AND a.kind IN ('user', 'hold') THEN t.service_id
+               ELSE COALESCE(o.user_id, o.service_id)
+           END AS counterparty_id
+    FROM public.ledger_posting p
+    JOIN public.ledger_transaction t ON t.id = p.transaction_id
+    JOIN public.ledger_account a ON a.id = p.account_id
+    JOIN public.ledger_posting op ON op.transaction_id = p.transaction_id AND op.id <> p.id
+    JOIN public.ledger_account o ON o.id = op.account_id
+)
+UPDATE public.ledger_posting p
+SET counterparty_kind = e.counterparty_kind,
+    counterparty_id = e.counterparty_id,
+    comment = CASE
+        WHEN e.kind = 'enrollment' AND e.account_kind = 'external' THEN 'Top-up of user ' || e.counterparty_id
+        WHEN e.kind = 'enrollment' THEN 'Top-up'
+        WHEN e.kind = 'withdrawal' AND e.account_kind = 'external' THEN 'Withdrawal of user ' || e.counterparty_id
+        WHEN e.kind = 'withdrawal' THEN 'Withdrawal'
+        WHEN e.kind = 'transfer' AND e.counterparty_id IS NULL THEN 'Transfer'
+        WHEN e.kind = 'transfer' AND e.amount > 0 THEN 'Transfer from user ' || e.counterparty_id
+        WHEN e.kind = 'transfer' THEN 'Transfer to user ' || e.counterparty_id
+        WHEN e.kind = 'adjustment' THEN 'Reconciliation adjustment'
+        WHEN e.kind IN ('capture', 'refund') AND e.account_kind = 'revenue' THEN
+            CASE e.kind WHEN 'capture' THEN 'Sale to user ' ELSE 'Refund to user ' END
+                || e.counterparty_id || COALESCE(' for order ' || e.order_id, '')
+        ELSE
+            CASE e.kind WHEN 'reserve' THEN 'Reservation' WHEN 'capture' THEN 'Purchase' WHEN 'release' THEN 'Release' ELSE 'Refund' END
+                || COALESCE(' of service "' || e.service_name || '"', '') || COALESCE(' for order ' || e.order_id, '')
+    END
+FROM entry e
+WHERE e.id = p.id;
@@ -0,0 +1,4 @@
+ALTER TABLE public.ledger_account DROP CONSTRAINT ledger_account_service_id_fkey;
+ALTER TABLE public.order DROP CONSTRAINT order_service_id_fkey;
+
+DROP TABLE public.service;
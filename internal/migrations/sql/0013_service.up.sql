@@ -0,0 +1,41 @@
+-- The service catalog. Orders and revenue accounts refer to it, so a service cannot be
+-- deleted once sold; it is deactivated instead. A service with a price is sold only at it.
+CREATE TABLE public.service
+(
+    id uuid PRIMARY KEY,
+    name text NOT NULL CHECK (name <> ''),
+    price decimal(20, 2) CHECK (price >= 0),
+    currency text CHECK (currency ~ '^[A-Z]{3}$'),
+    active boolean NOT NULL DEFAULT true,
+    date_create timestamp NOT NULL,
+    last_update timestamp NOT NULL,
+    CHECK ((price IS NULL) = (currency IS NULL))
+);
+
+-- The services sold so far, under the name they were last sold with.
+INSERT INTO public.service(id, name, date_create, last_update)
+SELECT DISTINCT ON (service_id) service_id, service_name, now(), now()
+FROM (
+    SELECT service_id, service_name, date_create FROM public.order
+    UNION ALL
+    SELECT service_id, service_name, date_create FROM public.ledger_transaction
+    WHERE service_id IS NOT NULL AND service_name IS NOT NULL
+) sold
+WHERE service_name <> ''
+ORDER BY service_id, date_create DESC;
+
+INSERT INTO public.service(id, name, date_create, last_update)
+SELECT DISTINCT a.service_id, a.service_id::text, now(), now()
+FROM public.ledger_account a
+WHERE a.service_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public.service(id, name, date_create, last_update)
+SELECT DISTINCT o.service_id, o.service_id::text, now(), now()
+FROM public.order o
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE public.order
+    ADD CONSTRAINT order_service_id_fkey FOREIGN KEY (service_id) REFERENCES public.service(id);
+ALTER TABLE public.ledger_account
+    ADD CONSTRAINT ledger_account_service_id_fkey FOREIGN KEY (service_id) REFERENCES public.service(id);
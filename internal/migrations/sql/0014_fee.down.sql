@@ -0,0 +1,31 @@
+-- Fees are kept as adjustments against the outside world, so the wallets stay explained by the ledger.
+INSERT INTO public.ledger_account(kind, currency)
+SELECT DISTINCT 'external', currency FROM public.ledger_account WHERE kind = 'fee'
+ON CONFLICT DO NOTHING;
+
+UPDATE public.ledger_posting p
+SET account_id = e.id
+FROM public.ledger_account f
+JOIN public.ledger_account e ON e.kind = 'external' AND e.currency = f.currency
+WHERE f.kind = 'fee' AND p.account_id = f.id;
+
+UPDATE public.ledger_transaction SET kind = 'adjustment' WHERE kind = 'fee';
+DELETE FROM public.ledger_account WHERE kind = 'fee';
+
+ALTER TABLE public.ledger_transaction
+    DROP CONSTRAINT ledger_transaction_kind_check,
+    ADD CONSTRAINT ledger_transaction_kind_check
+        CHECK (kind IN ('enrollment', 'transfer', 'reserve', 'capture', 'release', 'refund', 'adjustment', 'withdrawal'));
+
+ALTER TABLE public.ledger_account
+    DROP CONSTRAINT ledger_account_kind_check,
+    ADD CONSTRAINT ledger_account_kind_check
+        CHECK (kind IN ('user', 'hold', 'revenue', 'external')),
+    DROP CONSTRAINT ledger_account_check,
+    ADD CONSTRAINT ledger_account_check CHECK (
+        kind IN ('user', 'hold') AND user_id IS NOT NULL AND service_id IS NULL
+        OR kind = 'revenue' AND user_id IS NULL AND service_id IS NOT NULL
+        OR kind = 'external' AND user_id IS NULL AND service_id IS NULL
+    );
+
+ALTER TABLE public.order DROP COLUMN fee;
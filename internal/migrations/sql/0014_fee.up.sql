@@ -0,0 +1,21 @@
+-- Fees are paid to a house account, one per currency, by ledger transactions of their own kind.
+-- An order keeps the commission withheld from its capture.
+ALTER TABLE public.ledger_account
+    DROP CONSTRAINT ledger_account_kind_check,
+    ADD CONSTRAINT ledger_account_kind_check
+        CHECK (kind IN ('user', 'hold', 'revenue', 'external', 'fee')),
+    DROP CONSTRAINT ledger_account_check,
+    ADD CONSTRAINT ledger_account_check CHECK (
+        kind IN ('user', 'hold') AND user_id IS NOT NULL AND service_id IS NULL
+        OR kind = 'revenue' AND user_id IS NULL AND service_id IS NOT NULL
+        OR kind IN ('external', 'fee') AND user_id IS NULL AND service_id IS NULL
+    );
+
+ALTER TABLE public.ledger_transaction
+    DROP CONSTRAINT ledger_transaction_kind_check,
+    ADD CONSTRAINT ledger_transaction_kind_check
+        CHECK (kind IN ('enrollment', 'transfer', 'reserve', 'capture', 'release', 'refund', 'adjustment', 'withdrawal', 'fee'));
+
+ALTER TABLE public.order
+    ADD COLUMN fee decimal(20, 2) NOT NULL DEFAULT 0,
+    ADD CONSTRAINT order_fee_check CHECK (fee >= 0 AND fee <= COALESCE(captured, 0));
@@ -0,0 +1 @@
+DROP INDEX public.idempotency_date_create_idx;
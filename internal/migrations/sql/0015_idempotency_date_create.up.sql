@@ -0,0 +1,2 @@
+-- Keys older than the idempotency TTL are purged by date_create.
+CREATE INDEX idempotency_date_create_idx ON public.idempotency (date_create);
@@ -0,0 +1 @@
+ALTER TABLE public.order ALTER COLUMN date_create TYPE date;
@@ -0,0 +1,3 @@
+-- The reservation time was kept as a date and lost the time of day. reserved_at holds the
+-- same moment, in full for the orders placed since 0004.
+ALTER TABLE public.order ALTER COLUMN date_create TYPE timestamp USING reserved_at;
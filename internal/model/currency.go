@@ -0,0 +1,115 @@
+package model
+
+import (
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"sort"
+
+	Err "Avito/internal/errors"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ValidCurrency reports whether code looks like an ISO 4217 code, e.g. "USD".
+func ValidCurrency(code string) bool {
+	return currencyCode.MatchString(code)
+}
+
+// Rates is an exchange rate table: one unit of Base is worth Rates[c] units of c.
+// Rates are decimal numbers kept as written, so no precision is lost to floats.
+type Rates struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates" swaggertype:"object,number"`
+}
+
+// DefaultRates knows only DefaultCurrency, so every conversion to another currency fails.
+func DefaultRates() Rates {
+	return Rates{Base: DefaultCurrency, Rates: map[string]json.Number{}}
+}
+
+// Validate checks the codes and that every rate is a positive number.
+func (r Rates) Validate() error {
+	var details []Err.FieldError
+	if !ValidCurrency(r.Base) {
+		details = append(details, Err.Field("base", "must be a currency code"))
+	}
+
+	codes := make([]string, 0, len(r.Rates))
+	for code := range r.Rates {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if !ValidCurrency(code) {
+			details = append(details, Err.Field("rates."+code, "must be a currency code"))
+			continue
+		}
+		if rate, ok := new(big.Rat).SetString(r.Rates[code].String()); !ok || rate.Sign() <= 0 {
+			details = append(details, Err.Field("rates."+code, "must be a positive number"))
+		}
+	}
+
+	if len(details) > 0 {
+		return Err.Validation(details...)
+	}
+	return nil
+}
+
+// Supported reports whether the table has a rate for currency.
+func (r Rates) Supported(currency string) bool {
+	_, ok := r.rate(currency)
+	return ok
+}
+
+func (r Rates) rate(currency string) (*big.Rat, bool) {
+	if currency == r.Base {
+		return big.NewRat(1, 1), true
+	}
+	n, ok := r.Rates[currency]
+	if !ok {
+		return nil, false
+	}
+	rate, ok := new(big.Rat).SetString(n.String())
+	if !ok || rate.Sign() <= 0 {
+		return nil, false
+	}
+	return rate, true
+}
+
+// Convert expresses m in currency to, rounding half away from zero to minor units.
+func (r Rates) Convert(m Money, to string) (Money, error) {
+	from := m.CurrencyCode()
+	if from == to {
+		return NewMoney(m.Amount, to), nil
+	}
+
+	fromRate, ok := r.rate(from)
+	if !ok {
+		return Money{}, Err.ErrUnsupportedCurrency
+	}
+	toRate, ok := r.rate(to)
+	if !ok {
+		return Money{}, Err.ErrUnsupportedCurrency
+	}
+
+	x := new(big.Rat).SetInt64(m.Amount)
+	x.Mul(x, toRate)
+	x.Quo(x, fromRate)
+	return round(x, to)
+}
+
+// round turns x minor units into Money, rounding half away from zero.
+func round(x *big.Rat, currency string) (Money, error) {
+	// (2|num| + den) / 2den, then restore the sign.
+	num, den := new(big.Int).Abs(x.Num()), x.Denom()
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	num.Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+	if x.Sign() < 0 {
+		num.Neg(num)
+	}
+	if !num.IsInt64() {
+		return Money{}, Err.ErrAmountOverflow
+	}
+	return NewMoney(num.Int64(), currency), nil
+}
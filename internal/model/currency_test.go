@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	Err "Avito/internal/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRates_Convert(t *testing.T) {
+	rates := Rates{Base: "RUB", Rates: map[string]json.Number{"USD": "0.0108", "EUR": "0.01"}}
+
+	tests := []struct {
+		name string
+		in   Money
+		to   string
+		want int64
+		err  error
+	}{
+		{name: "same currency", in: NewMoney(1234, "USD"), to: "USD", want: 1234},
+		{name: "default currency", in: NewMoney(1000, ""), to: "RUB", want: 1000},
+		{name: "from base", in: NewMoney(10000, "RUB"), to: "USD", want: 108},
+		{name: "to base", in: NewMoney(108, "USD"), to: "RUB", want: 10000},
+		{name: "cross rate", in: NewMoney(100, "EUR"), to: "USD", want: 108},
+		{name: "rounds half away from zero", in: NewMoney(50, "RUB"), to: "EUR", want: 1},
+		{name: "negative", in: NewMoney(-50, "RUB"), to: "EUR", want: -1},
+		{name: "unknown target", in: NewMoney(100, "RUB"), to: "GBP", err: Err.ErrUnsupportedCurrency},
+		{name: "unknown source", in: NewMoney(100, "GBP"), to: "RUB", err: Err.ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.Convert(tt.in, tt.to)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, NewMoney(tt.want, tt.to), got)
+		})
+	}
+}
+
+func TestRates_Validate(t *testing.T) {
+	require.NoError(t, DefaultRates().Validate())
+	require.NoError(t, Rates{Base: "USD", Rates: map[string]json.Number{"RUB": "92.5"}}.Validate())
+
+	err := Rates{Base: "rub", Rates: map[string]json.Number{"USD": "0", "eur": "1", "GBP": "-1"}}.Validate()
+	var e *Err.Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, Err.CodeBadRequest, e.Code)
+	fields := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		fields = append(fields, d.Field)
+	}
+	require.Equal(t, []string{"base", "rates.GBP", "rates.USD", "rates.eur"}, fields)
+}
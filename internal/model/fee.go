@@ -0,0 +1,135 @@
+package model
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	Err "Avito/internal/errors"
+)
+
+// FeeRule prices a payment: Percent of the amount plus Fixed, kept within Min and Max.
+// Percent is a decimal number kept as written, e.g. "1.5", with at most PercentScale digits
+// after the point; Fixed, Min and Max are keyed
+// by currency, and a payment in a currency without an entry has no fixed part or cap.
+// The zero rule charges nothing.
+type FeeRule struct {
+	Percent string
+	Fixed   map[string]Money
+	Min     map[string]Money
+	Max     map[string]Money
+}
+
+// PercentScale is the number of digits allowed after the point of FeeRule.Percent.
+const PercentScale = 4
+
+func (r FeeRule) percent() (*big.Rat, bool) {
+	if r.Percent == "" {
+		return new(big.Rat), true
+	}
+	// Plain decimals only: big.Rat would also take fractions and exponents.
+	whole, frac, hasPoint := strings.Cut(r.Percent, ".")
+	if whole == "" || hasPoint && frac == "" || len(frac) > PercentScale {
+		return nil, false
+	}
+	for _, c := range whole + frac {
+		if c < '0' || c > '9' {
+			return nil, false
+		}
+	}
+	percent, ok := new(big.Rat).SetString(r.Percent)
+	if !ok || percent.Cmp(big.NewRat(100, 1)) > 0 {
+		return nil, false
+	}
+	return percent, true
+}
+
+// Validate checks that Percent is between 0 and 100, the amounts are not negative
+// and Min does not exceed Max.
+func (r FeeRule) Validate() error {
+	var details []Err.FieldError
+	if _, ok := r.percent(); !ok {
+		details = append(details, Err.Field("percent", "must be a number from 0 to 100"))
+	}
+
+	for _, amounts := range []struct {
+		field string
+		m     map[string]Money
+	}{{"fixed", r.Fixed}, {"min", r.Min}, {"max", r.Max}} {
+		codes := make([]string, 0, len(amounts.m))
+		for code := range amounts.m {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			switch {
+			case !ValidCurrency(code):
+				details = append(details, Err.Field(amounts.field+"."+code, "must be a currency code"))
+			case amounts.m[code].IsNegative():
+				details = append(details, Err.Field(amounts.field+"."+code, "must not be negative"))
+			}
+		}
+	}
+
+	codes := make([]string, 0, len(r.Min))
+	for code := range r.Min {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if max, ok := r.Max[code]; ok && max.Amount < r.Min[code].Amount {
+			details = append(details, Err.Field("max."+code, "must not be less than min."+code))
+		}
+	}
+
+	if len(details) > 0 {
+		return Err.Validation(details...)
+	}
+	return nil
+}
+
+// Fee is the fee for a payment of amount, in its currency. The percentage is rounded
+// half away from zero to minor units.
+func (r FeeRule) Fee(amount Money) (Money, error) {
+	currency := amount.CurrencyCode()
+	percent, ok := r.percent()
+	if !ok {
+		return Money{}, Err.ErrInvalidAmount
+	}
+
+	x := new(big.Rat).SetInt64(amount.Amount)
+	x.Mul(x, percent)
+	x.Quo(x, big.NewRat(100, 1))
+	fee, err := round(x, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if fixed, ok := r.Fixed[currency]; ok {
+		if fee, err = fee.Add(NewMoney(fixed.Amount, currency)); err != nil {
+			return Money{}, err
+		}
+	}
+	if min, ok := r.Min[currency]; ok && fee.Amount < min.Amount {
+		fee.Amount = min.Amount
+	}
+	if max, ok := r.Max[currency]; ok && fee.Amount > max.Amount {
+		fee.Amount = max.Amount
+	}
+	return fee, nil
+}
+
+// FeeShare is the part of fee, charged on whole, that falls on part of it, rounded half
+// away from zero. The shares of parts adding up to whole add up to fee when taken as the
+// difference of the shares of running totals.
+func FeeShare(fee, part, whole Money) (Money, error) {
+	if !fee.SameCurrency(part) || !fee.SameCurrency(whole) {
+		return Money{}, Err.ErrCurrencyMismatch
+	}
+	if whole.IsZero() {
+		return NewMoney(0, fee.CurrencyCode()), nil
+	}
+	x := new(big.Rat).SetInt64(fee.Amount)
+	x.Mul(x, new(big.Rat).SetInt64(part.Amount))
+	x.Quo(x, new(big.Rat).SetInt64(whole.Amount))
+	return round(x, fee.CurrencyCode())
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"testing"
+
+	Err "Avito/internal/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeRule_Validate(t *testing.T) {
+	require.NoError(t, FeeRule{}.Validate())
+
+	rule := FeeRule{
+		Percent: "101",
+		Fixed:   map[string]Money{"rub": NewMoney(100, "rub")},
+		Min:     map[string]Money{"RUB": NewMoney(500, "RUB")},
+		Max:     map[string]Money{"RUB": NewMoney(100, "RUB"), "USD": NewMoney(-1, "USD")},
+	}
+	var e *Err.Error
+	require.ErrorAs(t, rule.Validate(), &e)
+	require.ErrorIs(t, e, Err.ErrBadRequest)
+	require.Equal(t, []Err.FieldError{
+		Err.Field("percent", "must be a number from 0 to 100"),
+		Err.Field("fixed.rub", "must be a currency code"),
+		Err.Field("max.USD", "must not be negative"),
+		Err.Field("max.RUB", "must not be less than min.RUB"),
+	}, e.Details)
+
+	for _, percent := range []string{"1/3", "1e1", "1E1", "-1", "+1", ".5", "1.", "0.00001", "100.01"} {
+		require.Error(t, FeeRule{Percent: percent}.Validate(), percent)
+	}
+	for _, percent := range []string{"0", "1.5", "0.0001", "100", "100.0000"} {
+		require.NoError(t, FeeRule{Percent: percent}.Validate(), percent)
+	}
+}
+
+func TestFeeRule_Fee(t *testing.T) {
+	rule := FeeRule{
+		Percent: "1.5",
+		Fixed:   map[string]Money{"RUB": NewMoney(1000, "RUB")},
+		Min:     map[string]Money{"RUB": NewMoney(1500, "RUB")},
+		Max:     map[string]Money{"RUB": NewMoney(50000, "RUB"), "USD": NewMoney(100, "USD")},
+	}
+
+	tests := []struct {
+		name   string
+		amount Money
+		want   Money
+	}{
+		{"min", NewMoney(10000, "RUB"), NewMoney(1500, "RUB")},
+		{"percent and fixed", NewMoney(100000, ""), NewMoney(2500, "RUB")},
+		{"max", NewMoney(10000000, "RUB"), NewMoney(50000, "RUB")},
+		{"rounded half away from zero", NewMoney(3300, "USD"), NewMoney(50, "USD")},
+		{"capped in its currency", NewMoney(100000, "USD"), NewMoney(100, "USD")},
+		{"no rule for the currency", NewMoney(1000, "EUR"), NewMoney(15, "EUR")},
+	}
+	for _, tt := range tests {
+		fee, err := rule.Fee(tt.amount)
+		require.NoError(t, err, tt.name)
+		require.True(t, fee.Equal(tt.want), "%s: %s", tt.name, fee)
+	}
+
+	fee, err := FeeRule{}.Fee(NewMoney(100000, "RUB"))
+	require.NoError(t, err)
+	require.True(t, fee.IsZero())
+}
+
+func TestFeeShare(t *testing.T) {
+	fee, whole := NewMoney(100, "RUB"), NewMoney(300, "RUB")
+
+	// Three refunds of a third each return the whole fee.
+	var returned int64
+	refunded := NewMoney(0, "RUB")
+	for i := 0; i < 3; i++ {
+		before, err := FeeShare(fee, refunded, whole)
+		require.NoError(t, err)
+		refunded.Amount += 100
+		after, err := FeeShare(fee, refunded, whole)
+		require.NoError(t, err)
+		returned += after.Amount - before.Amount
+	}
+	require.Equal(t, fee.Amount, returned)
+
+	_, err := FeeShare(fee, NewMoney(1, "USD"), whole)
+	require.ErrorIs(t, err, Err.ErrCurrencyMismatch)
+}
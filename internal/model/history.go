@@ -0,0 +1,176 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	Err "Avito/internal/errors"
+
+	"github.com/google/uuid"
+)
+
+// HistoryType is the kind of a movement of a user's money.
+type HistoryType string
+
+const (
+	HistoryTopUp       HistoryType = "top_up"
+	HistoryTransferIn  HistoryType = "transfer_in"
+	HistoryTransferOut HistoryType = "transfer_out"
+	HistoryPurchase    HistoryType = "purchase"
+	HistoryRefund      HistoryType = "refund"
+	HistoryWithdrawal  HistoryType = "withdrawal"
+	HistoryAdjustment  HistoryType = "adjustment"
+	HistoryFee         HistoryType = "fee"
+)
+
+var historyTypes = []HistoryType{HistoryTopUp, HistoryTransferIn, HistoryTransferOut, HistoryPurchase, HistoryRefund, HistoryWithdrawal, HistoryAdjustment, HistoryFee}
+
+// ValidHistoryType reports whether t is one of the history types.
+func ValidHistoryType(t HistoryType) bool {
+	for _, known := range historyTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// CounterpartyType is what is on the other side of a history entry.
+type CounterpartyType string
+
+const (
+	CounterpartyUser     CounterpartyType = "user"
+	CounterpartyService  CounterpartyType = "service"
+	CounterpartyExternal CounterpartyType = "external"
+)
+
+// Counterparty is who the money of a history entry came from or went to: the other user of
+// a transfer, the service of an order, or the outside world, which has no ID.
+type Counterparty struct {
+	Type CounterpartyType
+	ID   *uuid.UUID
+}
+
+// History is a movement of a user's money. Amount is signed: positive when money came to the
+// user, negative when it left. Cost is the amount as it was shown before entries had a type:
+// what the user paid, so purchases and transfers are positive and refunds negative.
+// Comment describes the entry for people.
+type History struct {
+	UserID       uuid.UUID
+	Type         HistoryType
+	ServiceName  string
+	Amount       Money `swaggertype:"number"`
+	Cost         Money `swaggertype:"number"`
+	Currency     string
+	OrderDate    time.Time
+	Comment      string
+	Counterparty *Counterparty
+}
+
+// HistorySort is the field history is sorted by; amounts are compared without their sign.
+type HistorySort string
+
+const (
+	SortDate   HistorySort = "date"
+	SortAmount HistorySort = "amount"
+)
+
+// HistoryQuery selects a page of a user's history: Limit entries after Offset entries, or
+// after the cursor After. Zero From and To leave the range open, empty Types allow every type,
+// and nil MinAmount and MaxAmount do not bound the amount, which is compared without its sign.
+// Total asks for the number of entries matching the filters.
+type HistoryQuery struct {
+	UserID    uuid.UUID
+	Limit     int
+	Offset    int
+	After     *HistoryCursor
+	Total     bool
+	Sort      HistorySort
+	Desc      bool
+	From      time.Time
+	To        time.Time
+	Types     []HistoryType
+	MinAmount *Money
+	MaxAmount *Money
+}
+
+// HistoryCursor is the position after an entry in the order it was made for: the sort key
+// of the entry, with Amount without its sign, and the ID of its ledger posting. Clients
+// get it as an opaque token.
+type HistoryCursor struct {
+	Sort   HistorySort `json:"s"`
+	Desc   bool        `json:"d,omitempty"`
+	Date   time.Time   `json:"t"`
+	Amount int64       `json:"a,omitempty"`
+	ID     int64       `json:"i"`
+}
+
+// Encode makes the token of c.
+func (c HistoryCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseHistoryCursor reads a token made by Encode.
+func ParseHistoryCursor(token string) (*HistoryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	c := &HistoryCursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// HistoryPage is a page of history. Next is set when more entries follow; Total when
+// it was asked for.
+type HistoryPage struct {
+	Entries []History
+	Next    *HistoryCursor
+	Total   *int
+}
+
+// Validate checks the page, the sort and the filters.
+func (q HistoryQuery) Validate() error {
+	var details []Err.FieldError
+	if q.Limit <= 0 {
+		details = append(details, Err.Field("limit", "must be positive"))
+	}
+	if q.Offset < 0 {
+		details = append(details, Err.Field("offset", "must not be negative"))
+	}
+	if q.After != nil && q.Offset != 0 {
+		details = append(details, Err.Field("offset", "must not be set with a cursor"))
+	}
+	if q.After != nil && (q.After.Sort != q.Sort || q.After.Desc != q.Desc) {
+		details = append(details, Err.Field("cursor", "was made for another sort or order"))
+	}
+	if q.Sort != SortDate && q.Sort != SortAmount {
+		details = append(details, Err.Field("sort", "must be date or amount"))
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		details = append(details, Err.Field("to", "must be after from"))
+	}
+	for _, t := range q.Types {
+		if !ValidHistoryType(t) {
+			details = append(details, Err.Field("type", "unknown type "+string(t)))
+		}
+	}
+	if q.MinAmount != nil && q.MinAmount.IsNegative() {
+		details = append(details, Err.Field("min_amount", "must not be negative"))
+	}
+	if q.MaxAmount != nil && q.MaxAmount.IsNegative() {
+		details = append(details, Err.Field("max_amount", "must not be negative"))
+	}
+	if q.MinAmount != nil && q.MaxAmount != nil && q.MaxAmount.Amount < q.MinAmount.Amount {
+		details = append(details, Err.Field("max_amount", "must not be less than min_amount"))
+	}
+
+	if len(details) > 0 {
+		return Err.Validation(details...)
+	}
+	return nil
+}
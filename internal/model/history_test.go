@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	Err "Avito/internal/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryQuery_Validate(t *testing.T) {
+	day := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	min, max := NewMoney(500, DefaultCurrency), NewMoney(100, DefaultCurrency)
+	q := HistoryQuery{
+		UserID:    uuid.New(),
+		Offset:    -1,
+		Sort:      "name",
+		From:      day,
+		To:        day,
+		Types:     []HistoryType{HistoryPurchase, "bonus"},
+		MinAmount: &min,
+		MaxAmount: &max,
+	}
+
+	var e *Err.Error
+	require.ErrorAs(t, q.Validate(), &e)
+	require.ErrorIs(t, e, Err.ErrBadRequest)
+	require.Len(t, e.Details, 6)
+
+	q = HistoryQuery{UserID: uuid.New(), Limit: 10, Sort: SortAmount, From: day, Types: []HistoryType{HistoryTopUp, HistoryTransferIn}, MaxAmount: &min}
+	require.NoError(t, q.Validate())
+}
+
+func TestHistoryCursor(t *testing.T) {
+	c := HistoryCursor{Sort: SortAmount, Date: time.Date(2022, 11, 1, 10, 0, 0, 123000, time.UTC), Amount: 25000, ID: 42}
+	parsed, err := ParseHistoryCursor(c.Encode())
+	require.NoError(t, err)
+	require.Equal(t, c, *parsed)
+
+	_, err = ParseHistoryCursor("not-a-cursor")
+	require.Error(t, err)
+
+	q := HistoryQuery{UserID: uuid.New(), Limit: 10, Sort: SortAmount, After: parsed}
+	require.NoError(t, q.Validate())
+	q.Desc, q.Offset = true, 10
+	var e *Err.Error
+	require.ErrorAs(t, q.Validate(), &e)
+	require.Len(t, e.Details, 2)
+}
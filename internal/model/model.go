@@ -6,30 +6,49 @@ import (
 	"github.com/google/uuid"
 )
 
+// User is a user's money: Funds is expressed in Currency, Wallets holds the balance
+// of every currency the user has.
 type User struct {
 	ID         uuid.UUID
-	Funds      float64
+	Funds      Money `swaggertype:"number"`
+	Currency   string
+	Wallets    []Wallet
 	DateCreate time.Time
 	LastUpdate time.Time
 }
 
+type Wallet struct {
+	Currency string
+	Funds    Money `swaggertype:"number"`
+}
+
+// Order is a reservation and what became of it. Fee is the commission withheld from the
+// service revenue when the order was captured.
 type Order struct {
 	ID          uuid.UUID
 	UserID      uuid.UUID
 	ServiceID   uuid.UUID
 	ServiceName string
 	DateCreate  time.Time
-	Funds       float64
-}
-
-type Report struct {
-	ServiceName string
-	Revenue     float64
+	Funds       Money `swaggertype:"number"`
+	Currency    string
+	Status      OrderStatus
+	ReservedAt  time.Time
+	ExpiresAt   *time.Time
+	ConfirmedAt *time.Time
+	CancelledAt *time.Time
+	ExpiredAt   *time.Time
+	Captured    Money `swaggertype:"number"`
+	Refunded    Money `swaggertype:"number"`
+	Fee         Money `swaggertype:"number"`
 }
 
-type History struct {
-	UserID      uuid.UUID
-	ServiceName string
-	Cost        float64
-	OrderDate   time.Time
+// Idempotency is the stored outcome of a request sent with an Idempotency-Key header.
+// Status is zero while the first request is still being processed.
+type Idempotency struct {
+	Key         string
+	RequestHash string
+	Status      int
+	Body        []byte
+	DateCreate  time.Time
 }
@@ -0,0 +1,227 @@
+package model
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	Err "Avito/internal/errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DefaultCurrency is assigned to amounts that arrive without a currency.
+const DefaultCurrency = "RUB"
+
+// MoneyScale is the number of fractional digits stored in minor units.
+const MoneyScale = 2
+
+var minorUnitsPerMajor = int64(math.Pow10(MoneyScale))
+
+// Money is an exact amount kept in minor units (kopecks, cents) of a currency.
+// Arithmetic never goes through floating point.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal string like "10", "-3.5" or "0.01".
+// More than MoneyScale fractional digits is an error rather than a rounding.
+func ParseMoney(s, currency string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{}, Err.ErrInvalidAmount
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if whole == "" && frac == "" || hasPoint && frac == "" || len(frac) > MoneyScale {
+		return Money{}, Err.ErrInvalidAmount
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	frac += strings.Repeat("0", MoneyScale-len(frac))
+
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return Money{}, Err.ErrInvalidAmount
+		}
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/minorUnitsPerMajor {
+		return Money{}, Err.ErrAmountOverflow
+	}
+	minor, _ := strconv.ParseInt(frac, 10, 64)
+
+	amount := units*minorUnitsPerMajor + minor
+	if amount < 0 {
+		return Money{}, Err.ErrAmountOverflow
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// CurrencyCode returns the currency of m, falling back to DefaultCurrency.
+func (m Money) CurrencyCode() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+func (m Money) SameCurrency(o Money) bool {
+	return m.CurrencyCode() == o.CurrencyCode()
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, Err.ErrCurrencyMismatch
+	}
+	sum := m.Amount + o.Amount
+	if (o.Amount > 0 && sum < m.Amount) || (o.Amount < 0 && sum > m.Amount) {
+		return Money{}, Err.ErrAmountOverflow
+	}
+	return Money{Amount: sum, Currency: m.CurrencyCode()}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Amount == math.MinInt64 {
+		return Money{}, Err.ErrAmountOverflow
+	}
+	return m.Add(o.Neg())
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or greater than o.
+func (m Money) Cmp(o Money) (int, error) {
+	if !m.SameCurrency(o) {
+		return 0, Err.ErrCurrencyMismatch
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// LessThan reports whether m < o. Amounts in different currencies are never comparable.
+func (m Money) LessThan(o Money) bool {
+	c, err := m.Cmp(o)
+	return err == nil && c < 0
+}
+
+// Equal reports whether m and o hold the same amount of the same currency.
+func (m Money) Equal(o Money) bool {
+	c, err := m.Cmp(o)
+	return err == nil && c == 0
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsPositive() bool { return m.Amount > 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// String formats the amount as a plain decimal without the currency, e.g. "10.50".
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(big.NewInt(amount)).String()
+	if len(abs) <= MoneyScale {
+		abs = strings.Repeat("0", MoneyScale-len(abs)+1) + abs
+	}
+	return sign + abs[:len(abs)-MoneyScale] + "." + abs[len(abs)-MoneyScale:]
+}
+
+// MarshalJSON writes the amount as a JSON number literal with a fixed scale.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts both a JSON number and a quoted decimal string.
+// The currency is left untouched so callers can preset it.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	if bytes.ContainsAny(data, "eE") {
+		return Err.ErrInvalidAmount
+	}
+
+	parsed, err := ParseMoney(string(data), m.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ScanNumeric lets pgx scan decimal columns straight into Money.
+func (m *Money) ScanNumeric(v pgtype.Numeric) error {
+	currency := m.Currency
+	if !v.Valid {
+		*m = Money{Currency: currency}
+		return nil
+	}
+	if v.NaN || v.InfinityModifier != pgtype.Finite {
+		return Err.ErrInvalidAmount
+	}
+
+	n := new(big.Int)
+	if v.Int != nil {
+		n.Set(v.Int)
+	}
+
+	shift := int64(v.Exp) + MoneyScale
+	switch {
+	case shift > 0:
+		n.Mul(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(shift), nil))
+	case shift < 0:
+		var rem big.Int
+		n.QuoRem(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(-shift), nil), &rem)
+		if rem.Sign() != 0 {
+			return Err.ErrInvalidAmount
+		}
+	}
+	if !n.IsInt64() {
+		return Err.ErrAmountOverflow
+	}
+
+	*m = Money{Amount: n.Int64(), Currency: currency}
+	return nil
+}
+
+// NumericValue lets pgx pass Money as a decimal query argument.
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(m.Amount), Exp: -MoneyScale, Valid: true}, nil
+}
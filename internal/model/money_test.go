@@ -0,0 +1,107 @@
+package model
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	Err "Avito/internal/errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		err  error
+	}{
+		{in: "10", want: 1000},
+		{in: "10.5", want: 1050},
+		{in: "0.01", want: 1},
+		{in: ".25", want: 25},
+		{in: "-3.20", want: -320},
+		{in: "0.1", want: 10},
+		{in: "1.005", err: Err.ErrInvalidAmount},
+		{in: "1.", err: Err.ErrInvalidAmount},
+		{in: "abc", err: Err.ErrInvalidAmount},
+		{in: "", err: Err.ErrInvalidAmount},
+		{in: "99999999999999999999", err: Err.ErrAmountOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := ParseMoney(tt.in, DefaultCurrency)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, m.Amount)
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	require.Equal(t, "0.00", NewMoney(0, "").String())
+	require.Equal(t, "0.05", NewMoney(5, "").String())
+	require.Equal(t, "-1.50", NewMoney(-150, "").String())
+	require.Equal(t, "1234.56", NewMoney(123456, "").String())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var body struct {
+		Funds Money `json:"funds"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"funds": 0.1}`), &body))
+	require.Equal(t, int64(10), body.Funds.Amount)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"funds": "19.99"}`), &body))
+	require.Equal(t, int64(1999), body.Funds.Amount)
+
+	require.Error(t, json.Unmarshal([]byte(`{"funds": 1e3}`), &body))
+	require.Error(t, json.Unmarshal([]byte(`{"funds": 0.001}`), &body))
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"funds": 19.99}`, string(data))
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := NewMoney(10, "")
+	b := NewMoney(20, DefaultCurrency)
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(30, DefaultCurrency), sum)
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	require.True(t, diff.IsNegative())
+	require.True(t, a.LessThan(b))
+
+	_, err = a.Add(NewMoney(1, "USD"))
+	require.ErrorIs(t, err, Err.ErrCurrencyMismatch)
+	require.False(t, a.LessThan(NewMoney(100, "USD")))
+}
+
+func TestMoney_Numeric(t *testing.T) {
+	var m Money
+
+	require.NoError(t, m.ScanNumeric(pgtype.Numeric{Int: big.NewInt(12340), Exp: -3, Valid: true}))
+	require.Equal(t, int64(1234), m.Amount)
+	require.ErrorIs(t, m.ScanNumeric(pgtype.Numeric{Int: big.NewInt(12345), Exp: -3, Valid: true}), Err.ErrInvalidAmount)
+
+	require.NoError(t, m.ScanNumeric(pgtype.Numeric{Int: big.NewInt(15), Exp: 1, Valid: true}))
+	require.Equal(t, int64(15000), m.Amount)
+
+	require.NoError(t, m.ScanNumeric(pgtype.Numeric{}))
+	require.True(t, m.IsZero())
+
+	n, err := NewMoney(-250, "").NumericValue()
+	require.NoError(t, err)
+	require.Equal(t, "-250", n.Int.String())
+	require.Equal(t, int32(-2), n.Exp)
+}
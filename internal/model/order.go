@@ -0,0 +1,26 @@
+package model
+
+// OrderStatus is the state of a reservation. Orders start as reserved and
+// end in exactly one of the final states.
+type OrderStatus string
+
+const (
+	OrderReserved  OrderStatus = "reserved"
+	OrderConfirmed OrderStatus = "confirmed"
+	OrderCancelled OrderStatus = "cancelled"
+	OrderExpired   OrderStatus = "expired"
+)
+
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderReserved: {OrderConfirmed, OrderCancelled, OrderExpired},
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
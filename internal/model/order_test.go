@@ -0,0 +1,20 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStatus_CanTransitionTo(t *testing.T) {
+	for _, next := range []OrderStatus{OrderConfirmed, OrderCancelled, OrderExpired} {
+		require.True(t, OrderReserved.CanTransitionTo(next), next)
+	}
+
+	for _, final := range []OrderStatus{OrderConfirmed, OrderCancelled, OrderExpired} {
+		for _, next := range []OrderStatus{OrderReserved, OrderConfirmed, OrderCancelled, OrderExpired} {
+			require.False(t, final.CanTransitionTo(next), "%s -> %s", final, next)
+		}
+	}
+	require.False(t, OrderReserved.CanTransitionTo(OrderReserved))
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Discrepancy is a user's wallet whose stored balance is not explained by the ledger, or
+// whose held funds differ from the cost of the orders still reserved, in one currency.
+type Discrepancy struct {
+	UserID        uuid.UUID
+	Currency      string
+	Balance       Money `swaggertype:"number"`
+	LedgerBalance Money `swaggertype:"number"`
+	Held          Money `swaggertype:"number"`
+	Reserved      Money `swaggertype:"number"`
+	Adjusted      bool
+}
+
+// WriteDiscrepanciesCSV writes discrepancies with a header row.
+func WriteDiscrepanciesCSV(w io.Writer, discrepancies []Discrepancy) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"user_id", "currency", "balance", "ledger_balance", "held", "reserved", "adjusted"}); err != nil {
+		return err
+	}
+	for _, d := range discrepancies {
+		record := []string{d.UserID.String(), d.Currency, d.Balance.String(), d.LedgerBalance.String(), d.Held.String(), d.Reserved.String(), strconv.FormatBool(d.Adjusted)}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDiscrepanciesCSV(t *testing.T) {
+	userID := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	discrepancies := []Discrepancy{{
+		UserID:        userID,
+		Currency:      DefaultCurrency,
+		Balance:       NewMoney(70500, DefaultCurrency),
+		LedgerBalance: NewMoney(70000, DefaultCurrency),
+		Held:          NewMoney(30000, DefaultCurrency),
+		Adjusted:      true,
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDiscrepanciesCSV(&buf, discrepancies))
+	require.Equal(t, "user_id,currency,balance,ledger_balance,held,reserved,adjusted\n"+
+		"7c9e6679-7425-40de-944b-e07fc1f90ae7,RUB,705.00,700.00,300.00,0.00,true\n", buf.String())
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	Err "Avito/internal/errors"
+
+	"github.com/google/uuid"
+)
+
+// ReportPeriod splits a report into calendar periods; PeriodNone reports the range as a whole.
+type ReportPeriod string
+
+const (
+	PeriodNone  ReportPeriod = ""
+	PeriodDay   ReportPeriod = "day"
+	PeriodWeek  ReportPeriod = "week"
+	PeriodMonth ReportPeriod = "month"
+)
+
+// ReportGroup is what the revenue of a report is grouped by, besides period and currency.
+type ReportGroup string
+
+const (
+	GroupServiceName ReportGroup = "service_name"
+	GroupServiceID   ReportGroup = "service_id"
+	GroupUser        ReportGroup = "user"
+)
+
+// ReportQuery selects the revenue of [From, To), split by Period and grouped by GroupBy.
+type ReportQuery struct {
+	From    time.Time
+	To      time.Time
+	Period  ReportPeriod
+	GroupBy ReportGroup
+}
+
+// MonthQuery is the report of a calendar month grouped by service name.
+func MonthQuery(month time.Time) ReportQuery {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return ReportQuery{From: from, To: from.AddDate(0, 1, 0), GroupBy: GroupServiceName}
+}
+
+// Validate checks the range and that Period and GroupBy are known.
+func (q ReportQuery) Validate() error {
+	var details []Err.FieldError
+	if !q.From.Before(q.To) {
+		details = append(details, Err.Field("to", "must be after from"))
+	}
+	switch q.Period {
+	case PeriodNone, PeriodDay, PeriodWeek, PeriodMonth:
+	default:
+		details = append(details, Err.Field("period", "must be day, week or month"))
+	}
+	switch q.GroupBy {
+	case GroupServiceName, GroupServiceID, GroupUser:
+	default:
+		details = append(details, Err.Field("group_by", "must be service_name, service_id or user"))
+	}
+
+	if len(details) > 0 {
+		return Err.Validation(details...)
+	}
+	return nil
+}
+
+// FeeRevenue is the service name the fees paid to the house account are reported under.
+const FeeRevenue = "Fees"
+
+// Report is the revenue of one group in one period: captures less refunds and the commission
+// withheld from them, by the time they happened, or the fees under FeeRevenue. Period is the
+// start of the period, or of the range when the report is not split. ServiceName is set for
+// both service groupings, ServiceID and UserID only when grouping by them; UserID is nil for
+// revenue without an order.
+type Report struct {
+	Period      time.Time
+	ServiceName string
+	ServiceID   *uuid.UUID
+	UserID      *uuid.UUID
+	Revenue     Money `swaggertype:"number"`
+	Currency    string
+}
+
+// ReportColumns names the columns of a report file: the period start if q splits the range,
+// the group columns of q, the revenue and the currency.
+func ReportColumns(q ReportQuery) []string {
+	var columns []string
+	if q.Period != PeriodNone {
+		columns = append(columns, "period")
+	}
+	switch q.GroupBy {
+	case GroupServiceID:
+		columns = append(columns, "service_id", "service_name")
+	case GroupUser:
+		columns = append(columns, "user_id")
+	default:
+		columns = append(columns, "service_name")
+	}
+	return append(columns, "revenue", "currency")
+}
+
+// WriteReportCSV writes rows with a header row of ReportColumns.
+func WriteReportCSV(w io.Writer, q ReportQuery, rows []Report) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ReportColumns(q)); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		var record []string
+		if q.Period != PeriodNone {
+			record = append(record, r.Period.Format("2006-01-02"))
+		}
+		switch q.GroupBy {
+		case GroupServiceID:
+			record = append(record, uuidString(r.ServiceID), r.ServiceName)
+		case GroupUser:
+			record = append(record, uuidString(r.UserID))
+		default:
+			record = append(record, r.ServiceName)
+		}
+		record = append(record, r.Revenue.String(), r.Currency)
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// ReportJobStatus is the state of a report built in the background. Jobs start as
+// pending and end as done or failed.
+type ReportJobStatus string
+
+const (
+	ReportPending ReportJobStatus = "pending"
+	ReportRunning ReportJobStatus = "running"
+	ReportDone    ReportJobStatus = "done"
+	ReportFailed  ReportJobStatus = "failed"
+)
+
+// ReportJob is a queued report. A done job's file is named after its ID;
+// Error says why a failed job failed.
+type ReportJob struct {
+	ID         uuid.UUID
+	Status     ReportJobStatus
+	Query      ReportQuery
+	Error      string
+	DateCreate time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	Err "Avito/internal/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthQuery(t *testing.T) {
+	q := MonthQuery(time.Date(2022, 12, 15, 10, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), q.From)
+	require.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), q.To)
+	require.NoError(t, q.Validate())
+}
+
+func TestReportQuery_Validate(t *testing.T) {
+	from := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	q := ReportQuery{From: from, To: from, Period: "year", GroupBy: "order"}
+
+	var e *Err.Error
+	require.ErrorAs(t, q.Validate(), &e)
+	require.ErrorIs(t, e, Err.ErrBadRequest)
+	require.Len(t, e.Details, 3)
+
+	q = ReportQuery{From: from, To: from.AddDate(0, 0, 7), Period: PeriodWeek, GroupBy: GroupUser}
+	require.NoError(t, q.Validate())
+}
+
+func TestWriteReportCSV(t *testing.T) {
+	serviceID := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	day := time.Date(2022, 5, 2, 0, 0, 0, 0, time.UTC)
+	rows := []Report{{
+		Period:      day,
+		ServiceName: "Доставка, курьер",
+		ServiceID:   &serviceID,
+		Revenue:     NewMoney(150050, DefaultCurrency),
+		Currency:    DefaultCurrency,
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteReportCSV(&buf, ReportQuery{GroupBy: GroupServiceName}, rows))
+	require.Equal(t, "service_name,revenue,currency\n\"Доставка, курьер\",1500.50,RUB\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteReportCSV(&buf, ReportQuery{Period: PeriodDay, GroupBy: GroupServiceID}, rows))
+	require.Equal(t, "period,service_id,service_name,revenue,currency\n2022-05-02,7c9e6679-7425-40de-944b-e07fc1f90ae7,\"Доставка, курьер\",1500.50,RUB\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteReportCSV(&buf, ReportQuery{GroupBy: GroupUser}, rows))
+	require.Equal(t, "user_id,revenue,currency\n,1500.50,RUB\n", buf.String())
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	Err "Avito/internal/errors"
+
+	"github.com/google/uuid"
+)
+
+// maxServiceName is the longest service name, in characters.
+const maxServiceName = 255
+
+// Service is an entry of the service catalog. Orders are placed only for active services,
+// and only at Price when it is set; services priced per order have no Price. Currency is
+// the currency of Price.
+type Service struct {
+	ID         uuid.UUID
+	Name       string
+	Price      *Money `swaggertype:"number"`
+	Currency   string
+	Active     bool
+	DateCreate time.Time
+	LastUpdate time.Time
+}
+
+// Validate checks the name and the price.
+func (s Service) Validate() error {
+	var details []Err.FieldError
+	if strings.TrimSpace(s.Name) == "" {
+		details = append(details, Err.Field("name", "must not be empty"))
+	} else if utf8.RuneCountInString(s.Name) > maxServiceName {
+		details = append(details, Err.Field("name", "must be at most 255 characters"))
+	}
+	if s.Price != nil && s.Price.IsNegative() {
+		details = append(details, Err.Field("price", "must not be negative"))
+	}
+	if s.Price != nil && !ValidCurrency(s.Price.CurrencyCode()) {
+		details = append(details, Err.Field("currency", "must be a currency code"))
+	}
+
+	if len(details) > 0 {
+		return Err.Validation(details...)
+	}
+	return nil
+}
+
+// CheckOrder tells why s cannot be ordered under name for cost. An empty name stands for
+// the catalog name.
+func (s Service) CheckOrder(name string, cost Money) error {
+	if !s.Active {
+		return Err.ErrServiceInactive.WithDetails(Err.Field("service_id", "is not active"))
+	}
+
+	var details []Err.FieldError
+	if name != "" && name != s.Name {
+		details = append(details, Err.Field("service_name", "must be the catalog name "+s.Name))
+	}
+	if s.Price != nil && !cost.Equal(*s.Price) {
+		details = append(details, Err.Field("cost", "must be the service price "+s.Price.String()+" "+s.Price.CurrencyCode()))
+	}
+	if len(details) > 0 {
+		return Err.Validation(details...)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	Err "Avito/internal/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Validate(t *testing.T) {
+	price := NewMoney(-100, "XX")
+	s := Service{Name: strings.Repeat("я", 256), Price: &price}
+
+	var e *Err.Error
+	require.ErrorAs(t, s.Validate(), &e)
+	require.ErrorIs(t, e, Err.ErrBadRequest)
+	require.Len(t, e.Details, 3)
+
+	price = NewMoney(100, DefaultCurrency)
+	require.NoError(t, Service{Name: strings.Repeat("я", 255), Price: &price}.Validate())
+	require.NoError(t, Service{Name: "service"}.Validate())
+}
+
+func TestService_CheckOrder(t *testing.T) {
+	price := NewMoney(500, DefaultCurrency)
+	s := Service{Name: "service", Price: &price}
+	require.ErrorIs(t, s.CheckOrder("", price), Err.ErrServiceInactive)
+
+	s.Active = true
+	require.NoError(t, s.CheckOrder("", price))
+	require.NoError(t, s.CheckOrder("service", NewMoney(500, "")))
+
+	var e *Err.Error
+	require.ErrorAs(t, s.CheckOrder("other", NewMoney(500, "USD")), &e)
+	require.ErrorIs(t, e, Err.ErrBadRequest)
+	require.Len(t, e.Details, 2)
+
+	s.Price = nil
+	require.NoError(t, s.CheckOrder("", NewMoney(1, "USD")))
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Avito/internal/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CreateIdempotencyKey claims a key for the current request. It also takes over a key
+// whose first request never finished (e.g. the process died) before staleBefore.
+func (r *repository) CreateIdempotencyKey(ctx context.Context, key model.Idempotency, staleBefore time.Time) (bool, error) {
+	logrus.Infoln("Starting repository.CreateIdempotencyKey")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.CreateIdempotencyKey")
+		return false, err
+	}
+	defer conn.Release()
+
+	query := `INSERT INTO public.idempotency(key, request_hash, date_create)
+			  VALUES
+			  ($1, $2, $3)
+			  ON CONFLICT (key) DO UPDATE
+			  SET date_create = EXCLUDED.date_create
+			  WHERE public.idempotency.status IS NULL
+			  AND public.idempotency.request_hash = EXCLUDED.request_hash
+			  AND public.idempotency.date_create < $4;`
+	tag, err := conn.Exec(ctx, query, key.Key, key.RequestHash, key.DateCreate, staleBefore)
+	if err != nil {
+		logrus.Errorf("Exec %s: %s\n", key.Key, err)
+		logrus.Infoln("Ending repository.CreateIdempotencyKey")
+		return false, err
+	}
+
+	logrus.Infoln("Ending repository.CreateIdempotencyKey")
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *repository) GetIdempotencyKey(ctx context.Context, key string) (*model.Idempotency, error) {
+	logrus.Infoln("Starting repository.GetIdempotencyKey")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.GetIdempotencyKey")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `SELECT key, request_hash, COALESCE(status, 0), body, date_create
+			  FROM public.idempotency
+			  WHERE key = $1;`
+	i := idempotency{}
+	if err := conn.QueryRow(ctx, query, key).Scan(&i.key, &i.requestHash, &i.status, &i.body, &i.dateCreate); err != nil {
+		logrus.Errorf("Scan %s: %s\n", key, err)
+		logrus.Infoln("Ending repository.GetIdempotencyKey")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.GetIdempotencyKey")
+	return &model.Idempotency{Key: i.key, RequestHash: i.requestHash, Status: i.status, Body: i.body, DateCreate: i.dateCreate}, nil
+}
+
+func (r *repository) SaveIdempotencyResponse(ctx context.Context, key string, status int, body []byte) error {
+	logrus.Infoln("Starting repository.SaveIdempotencyResponse")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.SaveIdempotencyResponse")
+		return err
+	}
+	defer conn.Release()
+
+	query := `UPDATE public.idempotency
+			  SET status = $1, body = $2
+			  WHERE key = $3;`
+	if _, err := conn.Exec(ctx, query, status, body, key); err != nil {
+		logrus.Errorf("Exec %s: %s\n", key, err)
+		logrus.Infoln("Ending repository.SaveIdempotencyResponse")
+		return err
+	}
+
+	logrus.Infoln("Ending repository.SaveIdempotencyResponse")
+	return nil
+}
+
+func (r *repository) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	logrus.Infoln("Starting repository.DeleteIdempotencyKey")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.DeleteIdempotencyKey")
+		return err
+	}
+	defer conn.Release()
+
+	query := `DELETE FROM public.idempotency
+			  WHERE key = $1 AND status IS NULL;`
+	if _, err := conn.Exec(ctx, query, key); err != nil {
+		logrus.Errorf("Exec %s: %s\n", key, err)
+		logrus.Infoln("Ending repository.DeleteIdempotencyKey")
+		return err
+	}
+
+	logrus.Infoln("Ending repository.DeleteIdempotencyKey")
+	return nil
+}
+
+// DeleteIdempotencyKeys deletes the keys created before the given time, finished or not.
+func (r *repository) DeleteIdempotencyKeys(ctx context.Context, before time.Time) (int, error) {
+	logrus.Infoln("Starting repository.DeleteIdempotencyKeys")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.DeleteIdempotencyKeys")
+		return 0, err
+	}
+	defer conn.Release()
+
+	query := `DELETE FROM public.idempotency
+			  WHERE date_create < $1;`
+	tag, err := conn.Exec(ctx, query, before)
+	if err != nil {
+		logrus.Errorf("Exec %s: %s\n", before, err)
+		logrus.Infoln("Ending repository.DeleteIdempotencyKeys")
+		return 0, err
+	}
+
+	logrus.Infoln("Ending repository.DeleteIdempotencyKeys")
+	return int(tag.RowsAffected()), nil
+}
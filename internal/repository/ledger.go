@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Ledger account kinds: a user's wallet, the funds a user has reserved for orders,
+// the revenue of a service, the outside world money enters from and the house account
+// fees are paid to.
+const (
+	accountUser     = "user"
+	accountHold     = "hold"
+	accountRevenue  = "revenue"
+	accountExternal = "external"
+	accountFee      = "fee"
+)
+
+// Ledger transaction kinds, one per money movement the service performs.
+const (
+	transactionEnrollment = "enrollment"
+	transactionTransfer   = "transfer"
+	transactionReserve    = "reserve"
+	transactionCapture    = "capture"
+	transactionRelease    = "release"
+	transactionRefund     = "refund"
+	transactionWithdrawal = "withdrawal"
+	// A correcting entry written by reconciliation.
+	transactionAdjustment = "adjustment"
+	// A transfer fee, or the commission on an order and its return on refunds.
+	transactionFee = "fee"
+)
+
+// orderSteps names the steps of an order in the comments of its postings.
+var orderSteps = map[string]string{
+	transactionReserve: "Reservation",
+	transactionCapture: "Purchase",
+	transactionRelease: "Release",
+	transactionRefund:  "Refund",
+}
+
+// post records a ledger transaction. The postings must sum to zero; the database
+// checks it again at commit.
+func post(ctx context.Context, tx pgx.Tx, t ledgerTransaction, postings ...posting) error {
+	if len(postings) < 2 {
+		return Err.ErrUnbalancedTransaction
+	}
+	sum := model.NewMoney(0, postings[0].amount.Currency)
+	for _, p := range postings {
+		var err error
+		if sum, err = sum.Add(p.amount); err != nil {
+			return err
+		}
+	}
+	if !sum.IsZero() {
+		return Err.ErrUnbalancedTransaction
+	}
+
+	var id int64
+	query := `INSERT INTO public.ledger_transaction(kind, order_id, service_id, service_name, date_create)
+			  VALUES
+			  ($1, $2, $3, $4, $5)
+			  RETURNING id;`
+	if err := tx.QueryRow(ctx, query, t.kind, t.orderID, t.serviceID, t.serviceName, t.date).Scan(&id); err != nil {
+		return err
+	}
+
+	query = `INSERT INTO public.ledger_posting(transaction_id, account_id, amount, comment, counterparty_kind, counterparty_id)
+			 VALUES
+			 ($1, $2, $3, $4, $5, $6);`
+	for _, p := range postings {
+		if _, err := tx.Exec(ctx, query, id, p.account, p.amount, p.comment, p.counterpartyKind, p.counterpartyID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func userAccount(ctx context.Context, tx pgx.Tx, userID uuid.UUID, currency string) (ledgerAccount, error) {
+	return account(ctx, tx, accountUser, &userID, nil, currency)
+}
+
+func holdAccount(ctx context.Context, tx pgx.Tx, userID uuid.UUID, currency string) (ledgerAccount, error) {
+	return account(ctx, tx, accountHold, &userID, nil, currency)
+}
+
+func revenueAccount(ctx context.Context, tx pgx.Tx, serviceID uuid.UUID, currency string) (ledgerAccount, error) {
+	return account(ctx, tx, accountRevenue, nil, &serviceID, currency)
+}
+
+func externalAccount(ctx context.Context, tx pgx.Tx, currency string) (ledgerAccount, error) {
+	return account(ctx, tx, accountExternal, nil, nil, currency)
+}
+
+func feeAccount(ctx context.Context, tx pgx.Tx, currency string) (ledgerAccount, error) {
+	return account(ctx, tx, accountFee, nil, nil, currency)
+}
+
+// account returns the id of a ledger account in currency, opening it on first use. Accounts
+// are looked up before inserting, so busy accounts are not locked by every transaction.
+func account(ctx context.Context, tx pgx.Tx, kind string, userID, serviceID *uuid.UUID, currency string) (ledgerAccount, error) {
+	a := ledgerAccount{kind: kind, userID: userID, serviceID: serviceID}
+	query := `SELECT id
+			  FROM public.ledger_account
+			  WHERE kind = $1 AND user_id IS NOT DISTINCT FROM $2 AND service_id IS NOT DISTINCT FROM $3 AND currency = $4;`
+	err := tx.QueryRow(ctx, query, kind, userID, serviceID, currency).Scan(&a.id)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return a, err
+	}
+
+	insert := `INSERT INTO public.ledger_account(kind, user_id, service_id, currency)
+			   VALUES
+			   ($1, $2, $3, $4)
+			   ON CONFLICT DO NOTHING;`
+	if _, err := tx.Exec(ctx, insert, kind, userID, serviceID, currency); err != nil {
+		return a, err
+	}
+	err = tx.QueryRow(ctx, query, kind, userID, serviceID, currency).Scan(&a.id)
+	return a, err
+}
+
+// transferFunds posts amount from one account to another, each posting described
+// for the holder of its account.
+func transferFunds(ctx context.Context, tx pgx.Tx, t ledgerTransaction, from, to ledgerAccount, amount model.Money) error {
+	debit := describe(t, from, to, false)
+	debit.amount = amount.Neg()
+	credit := describe(t, to, from, true)
+	credit.amount = amount
+	return post(ctx, tx, t, debit, credit)
+}
+
+// describe makes the posting of t to own, other being the account on the other side:
+// its counterparty, and a comment saying what happened. The counterparty of the
+// user's side of an order is the service.
+func describe(t ledgerTransaction, own, other ledgerAccount, incoming bool) posting {
+	p := posting{account: own.id}
+	switch {
+	case t.serviceID != nil && (own.kind == accountUser || own.kind == accountHold):
+		p.counterpartyKind, p.counterpartyID = string(model.CounterpartyService), t.serviceID
+	case other.kind == accountUser || other.kind == accountHold:
+		p.counterpartyKind, p.counterpartyID = string(model.CounterpartyUser), other.userID
+	case other.kind == accountRevenue:
+		p.counterpartyKind, p.counterpartyID = string(model.CounterpartyService), other.serviceID
+	default:
+		p.counterpartyKind = string(model.CounterpartyExternal)
+	}
+
+	switch {
+	case t.kind == transactionEnrollment && own.kind == accountExternal:
+		p.comment = fmt.Sprintf("Top-up of user %s", p.counterpartyID)
+	case t.kind == transactionEnrollment:
+		p.comment = "Top-up"
+	case t.kind == transactionWithdrawal && own.kind == accountExternal:
+		p.comment = fmt.Sprintf("Withdrawal of user %s", p.counterpartyID)
+	case t.kind == transactionWithdrawal:
+		p.comment = "Withdrawal"
+	case t.kind == transactionTransfer && incoming:
+		p.comment = fmt.Sprintf("Transfer from user %s", p.counterpartyID)
+	case t.kind == transactionTransfer:
+		p.comment = fmt.Sprintf("Transfer to user %s", p.counterpartyID)
+	case t.kind == transactionAdjustment:
+		p.comment = "Reconciliation adjustment"
+	case t.kind == transactionFee && t.orderID == nil && own.kind == accountFee:
+		p.comment = fmt.Sprintf("Transfer fee of user %s", p.counterpartyID)
+	case t.kind == transactionFee && t.orderID == nil:
+		p.comment = "Transfer fee"
+	case t.kind == transactionFee && incoming == (own.kind == accountFee):
+		p.comment = fmt.Sprintf(`Commission on order %s of service "%s"`, t.orderID, *t.serviceName)
+	case t.kind == transactionFee:
+		p.comment = fmt.Sprintf(`Commission returned for order %s of service "%s"`, t.orderID, *t.serviceName)
+	case t.kind == transactionCapture && own.kind == accountRevenue:
+		p.comment = fmt.Sprintf("Sale to user %s for order %s", p.counterpartyID, t.orderID)
+	case t.kind == transactionRefund && own.kind == accountRevenue:
+		p.comment = fmt.Sprintf("Refund to user %s for order %s", p.counterpartyID, t.orderID)
+	default:
+		p.comment = fmt.Sprintf(`%s of service "%s" for order %s`, orderSteps[t.kind], *t.serviceName, t.orderID)
+	}
+	return p
+}
+
+// postEnrollment records money entering a wallet from outside.
+func postEnrollment(ctx context.Context, tx pgx.Tx, userID uuid.UUID, funds model.Money, date time.Time) error {
+	external, err := externalAccount(ctx, tx, funds.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	wallet, err := userAccount(ctx, tx, userID, funds.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	return transferFunds(ctx, tx, ledgerTransaction{kind: transactionEnrollment, date: date}, external, wallet, funds)
+}
+
+// postWithdrawal records money leaving a wallet for the outside world.
+func postWithdrawal(ctx context.Context, tx pgx.Tx, userID uuid.UUID, funds model.Money, date time.Time) error {
+	wallet, err := userAccount(ctx, tx, userID, funds.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	external, err := externalAccount(ctx, tx, funds.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	return transferFunds(ctx, tx, ledgerTransaction{kind: transactionWithdrawal, date: date}, wallet, external, funds)
+}
+
+// postTransfer records a transfer between two wallets as a single transaction, and the
+// fee the sender pays for it, if any, as a second one.
+func postTransfer(ctx context.Context, tx pgx.Tx, senderID, recipientID uuid.UUID, funds, fee model.Money, date time.Time) error {
+	sender, err := userAccount(ctx, tx, senderID, funds.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	recipient, err := userAccount(ctx, tx, recipientID, funds.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	if err := transferFunds(ctx, tx, ledgerTransaction{kind: transactionTransfer, date: date}, sender, recipient, funds); err != nil {
+		return err
+	}
+	if !fee.IsPositive() {
+		return nil
+	}
+	house, err := feeAccount(ctx, tx, fee.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	return transferFunds(ctx, tx, ledgerTransaction{kind: transactionFee, date: date}, sender, house, fee)
+}
+
+// orderRow keeps the fields of a new order the ledger needs.
+func orderRow(o model.Order) *order {
+	return &order{id: o.ID, userID: o.UserID, serviceID: o.ServiceID, serviceName: o.ServiceName, currency: o.Funds.CurrencyCode()}
+}
+
+// postOrder records a step of an order's life: the reservation moves the cost from the
+// wallet to the hold, the capture from the hold to the service revenue, the release back
+// to the wallet, and a refund from the revenue to the wallet.
+func postOrder(ctx context.Context, tx pgx.Tx, kind string, o *order, amount model.Money, date time.Time) error {
+	if !amount.SameCurrency(model.NewMoney(0, o.currency)) {
+		return Err.ErrCurrencyMismatch
+	}
+	currency := amount.CurrencyCode()
+	wallet, err := userAccount(ctx, tx, o.userID, currency)
+	if err != nil {
+		return err
+	}
+	hold, err := holdAccount(ctx, tx, o.userID, currency)
+	if err != nil {
+		return err
+	}
+	revenue, err := revenueAccount(ctx, tx, o.serviceID, currency)
+	if err != nil {
+		return err
+	}
+
+	t := ledgerTransaction{kind: kind, orderID: &o.id, serviceID: &o.serviceID, serviceName: &o.serviceName, date: date}
+	switch kind {
+	case transactionReserve:
+		return transferFunds(ctx, tx, t, wallet, hold, amount)
+	case transactionCapture:
+		return transferFunds(ctx, tx, t, hold, revenue, amount)
+	case transactionRelease:
+		return transferFunds(ctx, tx, t, hold, wallet, amount)
+	case transactionRefund:
+		return transferFunds(ctx, tx, t, revenue, wallet, amount)
+	default:
+		return Err.ErrUnbalancedTransaction
+	}
+}
+
+// postCommission records the commission of an order: withheld from the service revenue
+// into the house account when the order is captured, and handed back when returned is
+// set, for the refunded part of the order.
+func postCommission(ctx context.Context, tx pgx.Tx, o *order, fee model.Money, returned bool, date time.Time) error {
+	if !fee.SameCurrency(model.NewMoney(0, o.currency)) {
+		return Err.ErrCurrencyMismatch
+	}
+	revenue, err := revenueAccount(ctx, tx, o.serviceID, fee.CurrencyCode())
+	if err != nil {
+		return err
+	}
+	house, err := feeAccount(ctx, tx, fee.CurrencyCode())
+	if err != nil {
+		return err
+	}
+
+	t := ledgerTransaction{kind: transactionFee, orderID: &o.id, serviceID: &o.serviceID, serviceName: &o.serviceName, date: date}
+	if returned {
+		return transferFunds(ctx, tx, t, house, revenue, fee)
+	}
+	return transferFunds(ctx, tx, t, revenue, house, fee)
+}
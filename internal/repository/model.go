@@ -3,28 +3,143 @@ package repository
 import (
 	"time"
 
+	"Avito/internal/model"
+
 	"github.com/google/uuid"
 )
 
 type user struct {
 	id         uuid.UUID
-	balance    float64
 	dateCreate time.Time
 	lastUpdate time.Time
 }
 
+type wallet struct {
+	currency string
+	balance  model.Money
+}
+
 type order struct {
 	id          uuid.UUID
 	userID      uuid.UUID
 	serviceID   uuid.UUID
 	serviceName string
 	dateCreate  time.Time
-	funds       float64
+	funds       model.Money
+	currency    string
+	status      string
+	reservedAt  time.Time
+	expiresAt   *time.Time
+	confirmedAt *time.Time
+	cancelledAt *time.Time
+	expiredAt   *time.Time
+	captured    model.Money
+	refunded    model.Money
+	fee         model.Money
+}
+
+type ledgerTransaction struct {
+	kind        string
+	orderID     *uuid.UUID
+	serviceID   *uuid.UUID
+	serviceName *string
+	date        time.Time
+}
+
+type ledgerAccount struct {
+	id        int64
+	kind      string
+	userID    *uuid.UUID
+	serviceID *uuid.UUID
+}
+
+type posting struct {
+	account          int64
+	amount           model.Money
+	comment          string
+	counterpartyKind string
+	counterpartyID   *uuid.UUID
 }
 
 type history struct {
+	postingID   int64
 	id          uuid.UUID
+	kind        string
 	serviceName string
-	cost        float64
+	amount      model.Money
+	cost        model.Money
+	currency    string
 	date        time.Time
+	comment     *string
+	partyKind   *string
+	partyID     *uuid.UUID
+}
+
+func (h *history) model() model.History {
+	entry := model.History{
+		UserID:      h.id,
+		Type:        model.HistoryType(h.kind),
+		ServiceName: h.serviceName,
+		Amount:      h.amount,
+		Cost:        h.cost,
+		Currency:    h.currency,
+		OrderDate:   h.date,
+	}
+	if h.comment != nil {
+		entry.Comment = *h.comment
+	}
+	if h.partyKind != nil {
+		entry.Counterparty = &model.Counterparty{Type: model.CounterpartyType(*h.partyKind), ID: h.partyID}
+	}
+	return entry
+}
+
+// cursor is the position after h in the order of q.
+func (h *history) cursor(q model.HistoryQuery) *model.HistoryCursor {
+	amount := h.amount.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+	return &model.HistoryCursor{Sort: q.Sort, Desc: q.Desc, Date: h.date, Amount: amount, ID: h.postingID}
+}
+
+type service struct {
+	id         uuid.UUID
+	name       string
+	price      *model.Money
+	currency   *string
+	active     bool
+	dateCreate time.Time
+	lastUpdate time.Time
+}
+
+func (s *service) model() *model.Service {
+	m := &model.Service{ID: s.id, Name: s.name, Active: s.active, DateCreate: s.dateCreate, LastUpdate: s.lastUpdate}
+	if s.price != nil && s.currency != nil {
+		price := *s.price
+		price.Currency = *s.currency
+		m.Price, m.Currency = &price, *s.currency
+	}
+	return m
+}
+
+type idempotency struct {
+	key         string
+	requestHash string
+	status      int
+	body        []byte
+	dateCreate  time.Time
+}
+
+type reportJob struct {
+	id         uuid.UUID
+	status     string
+	dateFrom   time.Time
+	dateTo     time.Time
+	period     string
+	groupBy    string
+	err        *string
+	dateCreate time.Time
+	startedAt  *time.Time
+	finishedAt *time.Time
 }
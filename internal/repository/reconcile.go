@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// discrepancyQuery compares, in one snapshot and per currency, the stored wallet balance with
+// the user's wallet in the ledger and the user's hold with the cost of the orders still reserved.
+// $1 limits it to one user; NULL checks everybody.
+const discrepancyQuery = `WITH ledger AS (
+							  SELECT user_id, currency,
+							         COALESCE(SUM(balance) FILTER (WHERE kind = 'user'), 0) AS wallet,
+							         COALESCE(SUM(balance) FILTER (WHERE kind = 'hold'), 0) AS held
+							  FROM public.ledger_balance
+							  WHERE user_id IS NOT NULL
+							  GROUP BY user_id, currency
+						  ), reserved AS (
+							  SELECT user_id, currency, SUM(funds) AS funds
+							  FROM public.order
+							  WHERE status = 'reserved'
+							  GROUP BY user_id, currency
+						  ), wallets AS (
+							  SELECT user_id, currency FROM public.wallet
+							  UNION SELECT user_id, currency FROM ledger
+							  UNION SELECT user_id, currency FROM reserved
+						  )
+						  SELECT k.user_id, k.currency, COALESCE(w.balance, 0), COALESCE(l.wallet, 0), COALESCE(l.held, 0), COALESCE(r.funds, 0)
+						  FROM wallets k
+						  LEFT JOIN public.wallet w ON w.user_id = k.user_id AND w.currency = k.currency
+						  LEFT JOIN ledger l ON l.user_id = k.user_id AND l.currency = k.currency
+						  LEFT JOIN reserved r ON r.user_id = k.user_id AND r.currency = k.currency
+						  WHERE ($1::uuid IS NULL OR k.user_id = $1)
+						    AND (COALESCE(w.balance, 0) <> COALESCE(l.wallet, 0) OR COALESCE(l.held, 0) <> COALESCE(r.funds, 0))
+						  ORDER BY k.user_id, k.currency;`
+
+func scanDiscrepancies(rows pgx.Rows) ([]model.Discrepancy, error) {
+	defer rows.Close()
+
+	discrepancies := []model.Discrepancy{}
+	for rows.Next() {
+		d := model.Discrepancy{}
+		if err := rows.Scan(&d.UserID, &d.Currency, &d.Balance, &d.LedgerBalance, &d.Held, &d.Reserved); err != nil {
+			return nil, err
+		}
+		d.Balance.Currency, d.LedgerBalance.Currency, d.Held.Currency, d.Reserved.Currency = d.Currency, d.Currency, d.Currency, d.Currency
+		discrepancies = append(discrepancies, d)
+	}
+	return discrepancies, rows.Err()
+}
+
+// Discrepancies returns the wallets whose balances do not match the ledger.
+func (r *repository) Discrepancies(ctx context.Context) ([]model.Discrepancy, error) {
+	logrus.Infoln("Starting repository.Discrepancies")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Discrepancies")
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, discrepancyQuery, nil)
+	if err != nil {
+		logrus.Errorln("Query: ", err)
+		logrus.Infoln("Ending repository.Discrepancies")
+		return nil, err
+	}
+	discrepancies, err := scanDiscrepancies(rows)
+	if err != nil {
+		logrus.Errorln("Scan: ", err)
+		logrus.Infoln("Ending repository.Discrepancies")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.Discrepancies")
+	return discrepancies, nil
+}
+
+// Adjust posts correcting entries for one user so the ledger explains the stored balances
+// and the held funds match the reserved orders; the stored balances themselves never change.
+// The discrepancies are computed again under a lock on the user, so neither a movement
+// committed since they were reported nor a concurrent reconciliation is corrected twice.
+// pgx.ErrNoRows means there is nothing to correct.
+func (r *repository) Adjust(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.Discrepancy, error) {
+	logrus.Infoln("Starting repository.Adjust")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Adjust")
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logrus.Errorln("Begin: ", err)
+		logrus.Infoln("Ending repository.Adjust")
+		return nil, err
+	}
+
+	query := `SELECT id FROM public.user WHERE id = $1 FOR UPDATE;`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		logrus.Errorf("Lock %s: %s\n", userID, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Adjust")
+		return nil, err
+	}
+
+	var discrepancies []model.Discrepancy
+	rows, err := tx.Query(ctx, discrepancyQuery, userID)
+	if err == nil {
+		discrepancies, err = scanDiscrepancies(rows)
+	}
+	if err == nil && len(discrepancies) == 0 {
+		err = pgx.ErrNoRows
+	}
+	if err != nil {
+		logrus.Errorf("Scan %s: %s\n", userID, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Adjust")
+		return nil, err
+	}
+
+	for i := range discrepancies {
+		if err := postAdjustment(ctx, tx, &discrepancies[i], date); err != nil {
+			logrus.Errorf("Post %v: %s\n", discrepancies[i], err)
+			if err := tx.Rollback(context.Background()); err != nil {
+				logrus.Errorln("Rollback: ", err)
+			}
+			logrus.Infoln("Ending repository.Adjust")
+			return nil, err
+		}
+		discrepancies[i].Adjusted = true
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logrus.Errorln("Commit: ", err)
+		logrus.Infoln("Ending repository.Adjust")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.Adjust")
+	return discrepancies, nil
+}
+
+// postAdjustment brings the wallet to the stored balance and the hold to the reserved
+// cost, taking the differences from the external account.
+func postAdjustment(ctx context.Context, tx pgx.Tx, d *model.Discrepancy, date time.Time) error {
+	external, err := externalAccount(ctx, tx, d.Currency)
+	if err != nil {
+		return err
+	}
+	t := ledgerTransaction{kind: transactionAdjustment, date: date}
+
+	if diff, err := d.Balance.Sub(d.LedgerBalance); err != nil {
+		return err
+	} else if !diff.IsZero() {
+		wallet, err := userAccount(ctx, tx, d.UserID, d.Currency)
+		if err != nil {
+			return err
+		}
+		if err := transferFunds(ctx, tx, t, external, wallet, diff); err != nil {
+			return err
+		}
+	}
+
+	if diff, err := d.Reserved.Sub(d.Held); err != nil {
+		return err
+	} else if !diff.IsZero() {
+		hold, err := holdAccount(ctx, tx, d.UserID, d.Currency)
+		if err != nil {
+			return err
+		}
+		if err := transferFunds(ctx, tx, t, external, hold, diff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
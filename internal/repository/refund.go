@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Refund returns part of a confirmed order to the user. The guarded update keeps the
+// total refunded within the captured amount under concurrent refunds; if it would not,
+// or the order is not confirmed, pgx.ErrNoRows is returned. The commission on the
+// refunded part goes back from the house account to the service revenue.
+func (r *repository) Refund(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) error {
+	logrus.Infoln("Starting repository.Refund")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Refund")
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logrus.Errorln("Begin: ", err)
+		logrus.Infoln("Ending repository.Refund")
+		return err
+	}
+
+	query := `UPDATE public.order
+			  SET refunded = refunded + $2
+			  WHERE order_id = $1 AND status = 'confirmed' AND refunded + $2 <= captured
+			  RETURNING ` + orderColumns + `;`
+	o, err := scanOrder(tx.QueryRow(ctx, query, orderID, amount))
+	if err != nil {
+		logrus.Errorf("Refund %s %s: %s\n", orderID, amount, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Refund")
+		return err
+	}
+
+	if err := credit(ctx, tx, o.userID, amount, date); err != nil {
+		logrus.Errorf("Credit %s %s: %s\n", o.userID, amount, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Refund")
+		return err
+	}
+
+	// Taken from the service revenue, so reports show the revenue net of refunds.
+	if err := postOrder(ctx, tx, transactionRefund, o, amount, date); err != nil {
+		logrus.Errorf("Post %s %s: %s\n", o.id, amount, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Refund")
+		return err
+	}
+
+	returned, err := commissionReturned(o, amount)
+	if err != nil {
+		logrus.Errorf("Commission %s %s: %s\n", o.id, amount, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Refund")
+		return err
+	}
+	if returned.IsPositive() {
+		if err := postCommission(ctx, tx, o, returned, true, date); err != nil {
+			logrus.Errorf("Post fee %s %s: %s\n", o.id, returned, err)
+			if err := tx.Rollback(context.Background()); err != nil {
+				logrus.Errorln("Rollback: ", err)
+			}
+			logrus.Infoln("Ending repository.Refund")
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logrus.Errorln("Commit: ", err)
+	}
+
+	logrus.Infoln("Ending repository.Refund")
+	return err
+}
+
+// commissionReturned is the part of the commission of o that falls on a refund of amount,
+// o.refunded including it. The refunds of a whole order return the whole commission.
+func commissionReturned(o *order, amount model.Money) (model.Money, error) {
+	before, err := o.refunded.Sub(amount)
+	if err != nil {
+		return model.Money{}, err
+	}
+	after, err := model.FeeShare(o.fee, o.refunded, o.captured)
+	if err != nil {
+		return model.Money{}, err
+	}
+	earlier, err := model.FeeShare(o.fee, before, o.captured)
+	if err != nil {
+		return model.Money{}, err
+	}
+	return after.Sub(earlier)
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const reportJobColumns = `id, status, date_from, date_to, period, group_by, error, date_create, started_at, finished_at`
+
+func scanReportJob(row pgx.Row) (*reportJob, error) {
+	j := reportJob{}
+	if err := row.Scan(&j.id, &j.status, &j.dateFrom, &j.dateTo, &j.period, &j.groupBy, &j.err, &j.dateCreate, &j.startedAt, &j.finishedAt); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func (j *reportJob) model() *model.ReportJob {
+	job := &model.ReportJob{
+		ID:     j.id,
+		Status: model.ReportJobStatus(j.status),
+		Query: model.ReportQuery{
+			From:    j.dateFrom,
+			To:      j.dateTo,
+			Period:  model.ReportPeriod(j.period),
+			GroupBy: model.ReportGroup(j.groupBy),
+		},
+		DateCreate: j.dateCreate,
+		StartedAt:  j.startedAt,
+		FinishedAt: j.finishedAt,
+	}
+	if j.err != nil {
+		job.Error = *j.err
+	}
+	return job
+}
+
+func (r *repository) CreateReportJob(ctx context.Context, job model.ReportJob) error {
+	logrus.Infoln("Starting repository.CreateReportJob")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.CreateReportJob")
+		return err
+	}
+	defer conn.Release()
+
+	query := `INSERT INTO public.report_job(id, status, date_from, date_to, period, group_by, date_create)
+			  VALUES
+			  ($1, $2, $3, $4, $5, $6, $7);`
+	q := job.Query
+	if _, err := conn.Exec(ctx, query, job.ID, string(job.Status), q.From, q.To, string(q.Period), string(q.GroupBy), job.DateCreate); err != nil {
+		logrus.Errorf("Exec %s: %s\n", job.ID, err)
+		logrus.Infoln("Ending repository.CreateReportJob")
+		return err
+	}
+
+	logrus.Infoln("Ending repository.CreateReportJob")
+	return nil
+}
+
+func (r *repository) GetReportJob(ctx context.Context, id uuid.UUID) (*model.ReportJob, error) {
+	logrus.Infoln("Starting repository.GetReportJob")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.GetReportJob")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `SELECT ` + reportJobColumns + `
+			  FROM public.report_job
+			  WHERE id = $1;`
+	j, err := scanReportJob(conn.QueryRow(ctx, query, id))
+	if err != nil {
+		logrus.Errorf("Scan %s: %s\n", id, err)
+		logrus.Infoln("Ending repository.GetReportJob")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.GetReportJob")
+	return j.model(), nil
+}
+
+// ClaimReportJob marks the oldest pending job as running and returns it. A job still running
+// since before staleBefore is taken too: its worker died. Several workers, also of other
+// replicas, may claim at once; each job goes to one of them. pgx.ErrNoRows means no work.
+func (r *repository) ClaimReportJob(ctx context.Context, date, staleBefore time.Time) (*model.ReportJob, error) {
+	logrus.Infoln("Starting repository.ClaimReportJob")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.ClaimReportJob")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `UPDATE public.report_job
+			  SET status = 'running', started_at = $1
+			  WHERE id = (
+				  SELECT id FROM public.report_job
+				  WHERE status = 'pending' OR status = 'running' AND started_at < $2
+				  ORDER BY date_create
+				  LIMIT 1
+				  FOR UPDATE SKIP LOCKED
+			  )
+			  RETURNING ` + reportJobColumns + `;`
+	j, err := scanReportJob(conn.QueryRow(ctx, query, date, staleBefore))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			logrus.Errorln("Scan: ", err)
+		}
+		logrus.Infoln("Ending repository.ClaimReportJob")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.ClaimReportJob")
+	return j.model(), nil
+}
+
+// FinishReportJob records the outcome of a job returned by ClaimReportJob, or puts it back
+// in the queue with status pending. It fails with pgx.ErrNoRows if the job has been claimed
+// again since, and the outcome is dropped.
+func (r *repository) FinishReportJob(ctx context.Context, job model.ReportJob, date time.Time) error {
+	logrus.Infoln("Starting repository.FinishReportJob")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.FinishReportJob")
+		return err
+	}
+	defer conn.Release()
+
+	var errText *string
+	if job.Error != "" {
+		errText = &job.Error
+	}
+	query := `UPDATE public.report_job
+			  SET status = $1::text, error = $2,
+			      started_at = CASE WHEN $1::text = 'pending' THEN NULL ELSE started_at END,
+			      finished_at = CASE WHEN $1::text = 'pending' THEN NULL ELSE $3::timestamp END
+			  WHERE id = $4 AND status = 'running' AND started_at = $5;`
+	tag, err := conn.Exec(ctx, query, string(job.Status), errText, date, job.ID, job.StartedAt)
+	if err != nil {
+		logrus.Errorf("Exec %s: %s\n", job.ID, err)
+		logrus.Infoln("Ending repository.FinishReportJob")
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		logrus.Errorf("Report job %s is no longer ours\n", job.ID)
+		logrus.Infoln("Ending repository.FinishReportJob")
+		return pgx.ErrNoRows
+	}
+
+	logrus.Infoln("Ending repository.FinishReportJob")
+	return nil
+}
+
+// DeleteReportJobs deletes the finished jobs created before the given time.
+func (r *repository) DeleteReportJobs(ctx context.Context, before time.Time) (int, error) {
+	logrus.Infoln("Starting repository.DeleteReportJobs")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.DeleteReportJobs")
+		return 0, err
+	}
+	defer conn.Release()
+
+	query := `DELETE FROM public.report_job
+			  WHERE status IN ('done', 'failed') AND date_create < $1;`
+	tag, err := conn.Exec(ctx, query, before)
+	if err != nil {
+		logrus.Errorf("Exec %s: %s\n", before, err)
+		logrus.Infoln("Ending repository.DeleteReportJobs")
+		return 0, err
+	}
+
+	logrus.Infoln("Ending repository.DeleteReportJobs")
+	return int(tag.RowsAffected()), nil
+}
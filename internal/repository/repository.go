@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	Err "Avito/internal/errors"
@@ -9,151 +12,281 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
 type IRepository interface {
-	Balance(userID uuid.UUID) (*model.User, error)
-	Enrollment(user model.User, funds float64) error
-	AddUser(user model.User) error
-	Transfer(sender, recipient model.User, funds float64) error
-	Order(user model.User, order model.Order) error
-	GetOrder(orderID uuid.UUID) (*model.Order, error)
-	OrderSuccess(order model.Order) error
-	OrderFailed(user model.User, order model.Order) error
-	Report(time.Time) ([]model.Report, error)
-	History(userID uuid.UUID, limit, offset int) ([]model.History, error)
+	Ping(ctx context.Context) error
+	Balance(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	Enrollment(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) error
+	Transfer(ctx context.Context, senderID, recipientID uuid.UUID, funds, fee model.Money, date time.Time) error
+	Withdraw(ctx context.Context, userID uuid.UUID, funds, minBalance model.Money, date time.Time) error
+	Order(ctx context.Context, order model.Order) error
+	GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error)
+	OrderSuccess(ctx context.Context, order model.Order, captured, fee model.Money, date time.Time) error
+	Refund(ctx context.Context, orderID uuid.UUID, amount model.Money, date time.Time) error
+	ReleaseOrder(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) error
+	ExpiredOrders(ctx context.Context, date time.Time, limit int) ([]model.Order, error)
+	Report(ctx context.Context, q model.ReportQuery) ([]model.Report, error)
+	CreateReportJob(ctx context.Context, job model.ReportJob) error
+	GetReportJob(ctx context.Context, id uuid.UUID) (*model.ReportJob, error)
+	ClaimReportJob(ctx context.Context, date, staleBefore time.Time) (*model.ReportJob, error)
+	FinishReportJob(ctx context.Context, job model.ReportJob, date time.Time) error
+	DeleteReportJobs(ctx context.Context, before time.Time) (int, error)
+	History(ctx context.Context, q model.HistoryQuery) (*model.HistoryPage, error)
+	Discrepancies(ctx context.Context) ([]model.Discrepancy, error)
+	Adjust(ctx context.Context, userID uuid.UUID, date time.Time) ([]model.Discrepancy, error)
+	CreateIdempotencyKey(ctx context.Context, key model.Idempotency, staleBefore time.Time) (bool, error)
+	GetIdempotencyKey(ctx context.Context, key string) (*model.Idempotency, error)
+	SaveIdempotencyResponse(ctx context.Context, key string, status int, body []byte) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	DeleteIdempotencyKeys(ctx context.Context, before time.Time) (int, error)
+	CreateService(ctx context.Context, s model.Service) error
+	GetService(ctx context.Context, id uuid.UUID) (*model.Service, error)
+	Services(ctx context.Context) ([]model.Service, error)
+	UpdateService(ctx context.Context, s model.Service) error
+	DeleteService(ctx context.Context, id uuid.UUID) error
 }
 
+// uniqueViolation is the SQLSTATE of a duplicate key.
+const uniqueViolation = "23505"
+
 type repository struct {
-	dbConnection *pgx.Conn
+	pool           *pgxpool.Pool
+	acquireTimeout time.Duration
 }
 
-func NewRepository(dbConnection *pgx.Conn) (IRepository, error) {
-	if dbConnection == nil {
+// NewRepository builds a repository on top of a connection pool.
+// Every method acquires its own connection, waiting at most acquireTimeout (0 means no limit).
+func NewRepository(pool *pgxpool.Pool, acquireTimeout time.Duration) (IRepository, error) {
+	if pool == nil {
 		return nil, Err.ErrNoConnectionToDb
 	}
-	return &repository{dbConnection: dbConnection}, nil
+	return &repository{pool: pool, acquireTimeout: acquireTimeout}, nil
 }
 
-func (r *repository) Balance(userID uuid.UUID) (*model.User, error) {
+func (r *repository) acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	if r.acquireTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.acquireTimeout)
+		defer cancel()
+	}
+
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		logrus.Errorln("Acquire: ", err)
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (r *repository) Ping(ctx context.Context) error {
+	logrus.Infoln("Starting repository.Ping")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Ping")
+		return err
+	}
+	defer conn.Release()
+
+	if err := conn.Ping(ctx); err != nil {
+		logrus.Errorln("Ping: ", err)
+		logrus.Infoln("Ending repository.Ping")
+		return err
+	}
+
+	logrus.Infoln("Ending repository.Ping")
+	return nil
+}
+
+func (r *repository) Balance(ctx context.Context, userID uuid.UUID) (*model.User, error) {
 	logrus.Infoln("Starting repository.Balance")
 
-	query := `SELECT id, balance, date_create, last_update
-			  FROM public.user
-			  WHERE id = $1;`
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Balance")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `SELECT u.id, u.date_create, GREATEST(u.last_update, MAX(w.last_update))
+			  FROM public.user u
+			  LEFT JOIN public.wallet w ON w.user_id = u.id
+			  WHERE u.id = $1
+			  GROUP BY u.id;`
 	u := user{}
-	if err := r.dbConnection.QueryRow(context.Background(), query, userID).Scan(&u.id, &u.balance, &u.dateCreate, &u.lastUpdate); err != nil {
+	if err := conn.QueryRow(ctx, query, userID).Scan(&u.id, &u.dateCreate, &u.lastUpdate); err != nil {
 		logrus.Errorln("Scan: ", err)
 		logrus.Infoln("Ending repository.Balance")
 		return nil, err
 	}
 
+	query = `SELECT currency, balance
+			 FROM public.wallet
+			 WHERE user_id = $1
+			 ORDER BY currency;`
+	rows, err := conn.Query(ctx, query, userID)
+	if err != nil {
+		logrus.Errorf("Query %s: %s\n", userID, err)
+		logrus.Infoln("Ending repository.Balance")
+		return nil, err
+	}
+	defer rows.Close()
+
+	wallets := []model.Wallet{}
+	for rows.Next() {
+		w := wallet{}
+		if err := rows.Scan(&w.currency, &w.balance); err != nil {
+			logrus.Errorln("Scan: ", err)
+			logrus.Infoln("Ending repository.Balance")
+			return nil, err
+		}
+		w.balance.Currency = w.currency
+		wallets = append(wallets, model.Wallet{Currency: w.currency, Funds: w.balance})
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorln("Rows: ", err)
+		logrus.Infoln("Ending repository.Balance")
+		return nil, err
+	}
+
 	logrus.Infoln("Ending repository.Balance")
-	return &model.User{ID: u.id, Funds: u.balance, DateCreate: u.dateCreate, LastUpdate: u.lastUpdate}, nil
+	return &model.User{ID: u.id, Wallets: wallets, DateCreate: u.dateCreate, LastUpdate: u.lastUpdate}, nil
 }
 
-func (r *repository) AddUser(user model.User) error {
-	logrus.Infoln("Starting repository.AddUser")
+// Enrollment credits the wallet in the currency of funds, creating the user and the wallet on the first top-up.
+func (r *repository) Enrollment(ctx context.Context, userID uuid.UUID, funds model.Money, date time.Time) error {
+	logrus.Infoln("Starting repository.Enrollment")
 
-	tx, err := r.dbConnection.Begin(context.Background())
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Enrollment")
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		logrus.Errorln("Begin: ", err)
-		logrus.Infoln("Ending repository.AddUser")
+		logrus.Infoln("Ending repository.Enrollment")
 		return err
 	}
 
-	query := `INSERT INTO public.user(id, balance, date_create, last_update)
+	query := `INSERT INTO public.user(id, date_create, last_update)
 			  VALUES
-			  ($1, $2, $3, $4);`
-	if _, err = tx.Exec(context.Background(), query, user.ID, user.Funds, user.DateCreate, user.LastUpdate); err != nil {
-		logrus.Errorf("Exec %s: %s\n", user, err)
+			  ($1, $2, $2)
+			  ON CONFLICT (id) DO UPDATE
+			  SET last_update = EXCLUDED.last_update;`
+	if _, err := tx.Exec(ctx, query, userID, date); err != nil {
+		logrus.Errorf("Exec %s: %s\n", userID, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.AddUser")
+		logrus.Infoln("Ending repository.Enrollment")
 		return err
 	}
 
-	query = `INSERT INTO public.accounting(user_id,service_name,date_create,funds)
-		     VALUES
-		     ($1, 'Replenished', $2, $3);`
-	if _, err = tx.Exec(context.Background(), query, user.ID, user.LastUpdate, user.Funds); err != nil {
-		logrus.Errorln("Exec %s: %s\n", user, err)
+	if err := credit(ctx, tx, userID, funds, date); err != nil {
+		logrus.Errorf("Credit %s %s: %s\n", userID, funds, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.AddUser")
+		logrus.Infoln("Ending repository.Enrollment")
 		return err
 	}
 
-	err = tx.Commit(context.Background())
+	if err := postEnrollment(ctx, tx, userID, funds, date); err != nil {
+		logrus.Errorf("Post %s %s: %s\n", userID, funds, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Enrollment")
+		return err
+	}
+
+	err = tx.Commit(ctx)
 	if err != nil {
 		logrus.Errorln("Commit: ", err)
 	}
 
-	logrus.Infoln("Ending repository.AddUser")
+	logrus.Infoln("Ending repository.Enrollment")
 	return err
 }
 
-func (r *repository) Enrollment(user model.User, funds float64) error {
-	logrus.Infoln("Starting repository.Enrollment")
+// Transfer locks both users in id order, so opposite transfers cannot deadlock,
+// and debits the sender only if the balance covers the amount and the fee.
+func (r *repository) Transfer(ctx context.Context, senderID, recipientID uuid.UUID, funds, fee model.Money, date time.Time) error {
+	logrus.Infoln("Starting repository.Transfer")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Transfer")
+		return err
+	}
+	defer conn.Release()
 
-	tx, err := r.dbConnection.Begin(context.Background())
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		logrus.Errorln("Begin: ", err)
-		logrus.Infoln("Ending repository.Enrollment")
+		logrus.Infoln("Ending repository.Transfer")
 		return err
 	}
 
-	query := `UPDATE public.user
-			  SET balance = $1, last_update = $2
-			  WHERE id = $3;`
-	if _, err := tx.Exec(context.Background(), query, user.Funds, user.LastUpdate, user.ID); err != nil {
-		logrus.Errorf("Exec %s: %s", user, err)
+	query := `SELECT id
+			  FROM public.user
+			  WHERE id = ANY($1)
+			  ORDER BY id
+			  FOR UPDATE;`
+	rows, err := tx.Query(ctx, query, []uuid.UUID{senderID, recipientID})
+	if err != nil {
+		logrus.Errorf("Query %s %s: %s\n", senderID, recipientID, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.Enrollment")
+		logrus.Infoln("Ending repository.Transfer")
 		return err
 	}
-
-	query = `INSERT INTO public.accounting(user_id,service_name,date_create,funds)
-		     VALUES
-		     ($1, 'Replenished', $2, $3);`
-	if _, err = tx.Exec(context.Background(), query, user.ID, user.LastUpdate, funds); err != nil {
-		logrus.Errorf("Exec %s: %s\n", user, err)
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || locked != 2 {
+		if err == nil {
+			err = pgx.ErrNoRows
+		}
+		logrus.Errorf("Lock %s %s: %s\n", senderID, recipientID, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.Enrollment")
+		logrus.Infoln("Ending repository.Transfer")
 		return err
 	}
 
-	err = tx.Commit(context.Background())
+	total, err := funds.Add(fee)
 	if err != nil {
-		logrus.Errorln("Commit: ", err)
+		logrus.Errorf("Add %s %s: %s\n", funds, fee, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Transfer")
+		return err
 	}
 
-	logrus.Infoln("Ending repository.Transfer")
-	return err
-}
-
-func (r *repository) Transfer(sender, recipient model.User, funds float64) error {
-	logrus.Infoln("Starting repository.Transfer")
-
-	tx, err := r.dbConnection.Begin(context.Background())
-	if err != nil {
-		logrus.Errorln("Begin: ", err)
+	if err := debit(ctx, tx, senderID, total, date); err != nil {
+		logrus.Errorf("Debit %s %s: %s\n", senderID, total, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
 		logrus.Infoln("Ending repository.Transfer")
 		return err
 	}
 
-	query := `UPDATE public.user
-			  SET balance = $1, last_update = $2
-			  WHERE id = $3;`
-	if _, err := tx.Exec(context.Background(), query, sender.Funds, sender.LastUpdate, sender.ID); err != nil {
-		logrus.Errorf("Exec %s: %s", sender, err)
+	if err := credit(ctx, tx, recipientID, funds, date); err != nil {
+		logrus.Errorf("Credit %s %s: %s\n", recipientID, funds, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
@@ -161,11 +294,8 @@ func (r *repository) Transfer(sender, recipient model.User, funds float64) error
 		return err
 	}
 
-	query = `INSERT INTO public.accounting(user_id, service_name, date_create, funds)
-		     VALUES
-		     ($1, 'Transferred', $2, $3);`
-	if _, err = tx.Exec(context.Background(), query, sender.ID, sender.LastUpdate, funds); err != nil {
-		logrus.Errorf("Exec %s %s: %s\n", sender, funds, err)
+	if err := postTransfer(ctx, tx, senderID, recipientID, funds, fee, date); err != nil {
+		logrus.Errorf("Post %s %s %s %s: %s\n", senderID, recipientID, funds, fee, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
@@ -173,54 +303,101 @@ func (r *repository) Transfer(sender, recipient model.User, funds float64) error
 		return err
 	}
 
-	query = `UPDATE public.user
-			 SET balance = $1, last_update = $2
-			 WHERE id = $3;`
-	if _, err := tx.Exec(context.Background(), query, recipient.Funds, recipient.LastUpdate, recipient.ID); err != nil {
-		logrus.Errorf("Exec %s: %s", sender, err)
+	err = tx.Commit(ctx)
+	if err != nil {
+		logrus.Errorln("Commit: ", err)
+	}
+
+	logrus.Infoln("Ending repository.Transfer")
+	return err
+}
+
+// Withdraw takes funds out of the service. The wallet must keep at least minBalance
+// afterwards; the money leaves the ledger to the external account.
+func (r *repository) Withdraw(ctx context.Context, userID uuid.UUID, funds, minBalance model.Money, date time.Time) error {
+	logrus.Infoln("Starting repository.Withdraw")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Withdraw")
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		logrus.Errorln("Begin: ", err)
+		logrus.Infoln("Ending repository.Withdraw")
+		return err
+	}
+
+	if err := debitAbove(ctx, tx, userID, funds, minBalance, date); err != nil {
+		logrus.Errorf("Debit %s %s: %s\n", userID, funds, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.Transfer")
+		logrus.Infoln("Ending repository.Withdraw")
 		return err
 	}
 
-	query = `INSERT INTO public.accounting(user_id, service_name, date_create, funds)
-		     VALUES
-		     ($1, 'Replenished', $2, $3);`
-	if _, err = tx.Exec(context.Background(), query, recipient.ID, recipient.LastUpdate, funds); err != nil {
-		logrus.Errorf("Exec %s %s: %s\n", sender, funds, err)
+	if err := postWithdrawal(ctx, tx, userID, funds, date); err != nil {
+		logrus.Errorf("Post %s %s: %s\n", userID, funds, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.Transfer")
+		logrus.Infoln("Ending repository.Withdraw")
 		return err
 	}
 
-	err = tx.Commit(context.Background())
+	err = tx.Commit(ctx)
 	if err != nil {
 		logrus.Errorln("Commit: ", err)
 	}
 
-	logrus.Infoln("Ending repository.Transfer")
+	logrus.Infoln("Ending repository.Withdraw")
 	return err
 }
 
-func (r *repository) Order(user model.User, order model.Order) error {
+// Order reserves the cost: the debit, the reservation row and the move of the cost
+// to the user's hold account are written in one transaction.
+func (r *repository) Order(ctx context.Context, order model.Order) error {
 	logrus.Infoln("Starting repository.Order")
 
-	tx, err := r.dbConnection.Begin(context.Background())
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Order")
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		logrus.Errorln("Begin: ", err)
 		logrus.Infoln("Ending repository.Order")
 		return err
 	}
 
-	query := `UPDATE public.user
-			  SET balance = $1, last_update = $2
-			  WHERE id = $3`
-	if _, err := tx.Exec(context.Background(), query, user.Funds, user.LastUpdate, user.ID); err != nil {
-		logrus.Errorf("Exec %s: %s\n", user, err)
+	if err := debit(ctx, tx, order.UserID, order.Funds, order.DateCreate); err != nil {
+		logrus.Errorf("Debit %s %s: %s\n", order.UserID, order.Funds, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.Order")
+		return err
+	}
+
+	query := `INSERT INTO public.order(order_id, user_id, service_id, service_name, date_create, funds, currency, status, reserved_at, expires_at)
+			  VALUES
+			  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`
+	if _, err := tx.Exec(ctx, query, order.ID, order.UserID, order.ServiceID, order.ServiceName, order.DateCreate, order.Funds, order.Funds.CurrencyCode(), string(model.OrderReserved), order.DateCreate, order.ExpiresAt); err != nil {
+		logrus.Errorf("Exec %v: %s\n", order, err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			err = Err.ErrOrderExists
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			err = Err.ErrServiceNotFound
+		}
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
@@ -228,11 +405,8 @@ func (r *repository) Order(user model.User, order model.Order) error {
 		return err
 	}
 
-	query = `INSERT INTO public.order
-		     VALUES
-		     ($1, $2, $3, $4, $5, $6);`
-	if _, err := tx.Exec(context.Background(), query, order.ID, order.UserID, order.ServiceID, order.ServiceName, order.DateCreate, order.Funds); err != nil {
-		logrus.Errorf("Exec %s: %s\n", order, err)
+	if err := postOrder(ctx, tx, transactionReserve, orderRow(order), order.Funds, order.DateCreate); err != nil {
+		logrus.Errorf("Post %s %s: %s\n", order.ID, order.Funds, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
@@ -240,7 +414,7 @@ func (r *repository) Order(user model.User, order model.Order) error {
 		return err
 	}
 
-	err = tx.Commit(context.Background())
+	err = tx.Commit(ctx)
 	if err != nil {
 		logrus.Errorln("Commit: ", err)
 	}
@@ -249,37 +423,54 @@ func (r *repository) Order(user model.User, order model.Order) error {
 	return err
 }
 
-func (r *repository) GetOrder(orderID uuid.UUID) (*model.Order, error) {
+func (r *repository) GetOrder(ctx context.Context, orderID uuid.UUID) (*model.Order, error) {
 	logrus.Infoln("Starting repository.GetOrder")
 
-	query := `SELECT order_id, user_id, service_id, service_name, date_create, funds
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.GetOrder")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `SELECT ` + orderColumns + `
 			  FROM public.order
-			  WHERE id = $1;`
-	o := order{}
-	if err := r.dbConnection.QueryRow(context.Background(), query, orderID).Scan(&o.id, &o.userID, &o.serviceID, &o.serviceName, &o.dateCreate, &o.funds); err != nil {
+			  WHERE order_id = $1;`
+	o, err := scanOrder(conn.QueryRow(ctx, query, orderID))
+	if err != nil {
 		logrus.Errorf("Scan %s, %s\n", orderID, err)
 		logrus.Infoln("Ending repository.GetOrder")
 		return nil, err
 	}
 
-	return &model.Order{ID: o.id, UserID: o.userID, ServiceID: o.serviceID, ServiceName: o.serviceName, DateCreate: o.dateCreate, Funds: o.funds}, nil
+	logrus.Infoln("Ending repository.GetOrder")
+	return o.model(), nil
 }
 
-func (r *repository) OrderSuccess(order model.Order) error {
+// OrderSuccess confirms the reservation, moves the captured amount to the service revenue,
+// less the fee which goes to the house account, and returns the rest of the reservation
+// to the user. The order must still be reserved, so a second resolution of the same order
+// fails with pgx.ErrNoRows.
+func (r *repository) OrderSuccess(ctx context.Context, order model.Order, captured, fee model.Money, date time.Time) error {
 	logrus.Infoln("Starting repository.OrderSuccess")
 
-	tx, err := r.dbConnection.Begin(context.Background())
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.OrderSuccess")
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		logrus.Errorln("Begin: ", err)
 		logrus.Infoln("Ending repository.OrderSuccess")
 		return err
 	}
 
-	query := `INSERT INTO public.accounting
-			  VALUES
-			  ($1 ,$2, $3, $4, $5, $6);`
-	if _, err := tx.Exec(context.Background(), query, order.ID, order.UserID, order.ServiceID, order.ServiceName, order.DateCreate, order.Funds); err != nil {
-		logrus.Errorf("Exec %s: %s\n", order, err)
+	reserved, err := resolveOrder(ctx, tx, order.ID, model.OrderConfirmed, date)
+	if err != nil {
+		logrus.Errorf("Resolve %s: %s\n", order.ID, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
@@ -287,10 +478,12 @@ func (r *repository) OrderSuccess(order model.Order) error {
 		return err
 	}
 
-	query = `DELETE FROM public.order
-			 WHERE id = $1;`
-	if _, err := tx.Exec(context.Background(), query, order.ID); err != nil {
-		logrus.Errorf("Exec %s: %s\n", order, err)
+	remainder, err := reserved.funds.Sub(captured)
+	if err != nil || remainder.IsNegative() {
+		if err == nil {
+			err = Err.ErrCaptureExceedsReservation
+		}
+		logrus.Errorf("Capture %s of %s: %s\n", captured, reserved.funds, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
@@ -298,7 +491,50 @@ func (r *repository) OrderSuccess(order model.Order) error {
 		return err
 	}
 
-	err = tx.Commit(context.Background())
+	query := `UPDATE public.order
+			  SET captured = $2, fee = $3
+			  WHERE order_id = $1;`
+	if _, err := tx.Exec(ctx, query, reserved.id, captured, fee); err != nil {
+		logrus.Errorf("Exec %s: %s\n", reserved.id, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.OrderSuccess")
+		return err
+	}
+
+	if err := postOrder(ctx, tx, transactionCapture, reserved, captured, date); err != nil {
+		logrus.Errorf("Post %s %s: %s\n", reserved.id, captured, err)
+		if err := tx.Rollback(context.Background()); err != nil {
+			logrus.Errorln("Rollback: ", err)
+		}
+		logrus.Infoln("Ending repository.OrderSuccess")
+		return err
+	}
+
+	if fee.IsPositive() {
+		if err := postCommission(ctx, tx, reserved, fee, false, date); err != nil {
+			logrus.Errorf("Post fee %s %s: %s\n", reserved.id, fee, err)
+			if err := tx.Rollback(context.Background()); err != nil {
+				logrus.Errorln("Rollback: ", err)
+			}
+			logrus.Infoln("Ending repository.OrderSuccess")
+			return err
+		}
+	}
+
+	if remainder.IsPositive() {
+		if err := releaseFunds(ctx, tx, reserved, remainder, date); err != nil {
+			logrus.Errorf("Release %s %s: %s\n", reserved.id, remainder, err)
+			if err := tx.Rollback(context.Background()); err != nil {
+				logrus.Errorln("Rollback: ", err)
+			}
+			logrus.Infoln("Ending repository.OrderSuccess")
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
 	if err != nil {
 		logrus.Errorln("Commit: ", err)
 	}
@@ -307,105 +543,436 @@ func (r *repository) OrderSuccess(order model.Order) error {
 	return err
 }
 
-func (r *repository) OrderFailed(user model.User, order model.Order) error {
-	logrus.Infoln("Starting repository.OrderFailed")
+// ReleaseOrder moves the reservation to status (cancelled or expired) and returns the
+// reserved funds to the user exactly once, recording the return in the ledger.
+func (r *repository) ReleaseOrder(ctx context.Context, order model.Order, status model.OrderStatus, date time.Time) error {
+	logrus.Infoln("Starting repository.ReleaseOrder")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.ReleaseOrder")
+		return err
+	}
+	defer conn.Release()
 
-	tx, err := r.dbConnection.Begin(context.Background())
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		logrus.Errorln("Begin: ", err)
-		logrus.Infoln("Ending repository.OrderFailed")
+		logrus.Infoln("Ending repository.ReleaseOrder")
 		return err
 	}
 
-	query := `UPDATE puiblic.user
-			  SET balance = $1, last_update = $2
-			  WHERE id = $3;`
-	if _, err := tx.Exec(context.Background(), query, user.Funds, user.LastUpdate, user.ID); err != nil {
-		logrus.Errorf("Exec %s: %s\n", user, err)
+	reserved, err := resolveOrder(ctx, tx, order.ID, status, date)
+	if err != nil {
+		logrus.Errorf("Resolve %s: %s\n", order.ID, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.OrderFailed")
+		logrus.Infoln("Ending repository.ReleaseOrder")
 		return err
 	}
 
-	query = `DELETE FROM public.order
-			 WHERE id = $1;`
-	if _, err := tx.Exec(context.Background(), query, order.ID); err != nil {
-		logrus.Errorf("Exec %s: %s\n", order, err)
+	if err := releaseFunds(ctx, tx, reserved, reserved.funds, date); err != nil {
+		logrus.Errorf("Release %s %s: %s\n", reserved.id, reserved.funds, err)
 		if err := tx.Rollback(context.Background()); err != nil {
 			logrus.Errorln("Rollback: ", err)
 		}
-		logrus.Infoln("Ending repository.OrderFailed")
+		logrus.Infoln("Ending repository.ReleaseOrder")
 		return err
 	}
 
-	err = tx.Commit(context.Background())
+	err = tx.Commit(ctx)
 	if err != nil {
 		logrus.Errorln("Commit: ", err)
 	}
 
-	logrus.Infoln("Ending repository.OrderFailed")
+	logrus.Infoln("Ending repository.ReleaseOrder")
 	return err
 }
 
-func (r *repository) Report(t time.Time) (report []model.Report, err error) {
+// ExpiredOrders returns up to limit reservations whose expiry time is not after date.
+func (r *repository) ExpiredOrders(ctx context.Context, date time.Time, limit int) ([]model.Order, error) {
+	logrus.Infoln("Starting repository.ExpiredOrders")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.ExpiredOrders")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `SELECT ` + orderColumns + `
+			  FROM public.order
+			  WHERE status = 'reserved' AND expires_at <= $1
+			  ORDER BY expires_at
+			  LIMIT $2;`
+	rows, err := conn.Query(ctx, query, date, limit)
+	if err != nil {
+		logrus.Errorf("Query %s: %s\n", date, err)
+		logrus.Infoln("Ending repository.ExpiredOrders")
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []model.Order{}
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			logrus.Errorln("Scan: ", err)
+			logrus.Infoln("Ending repository.ExpiredOrders")
+			return nil, err
+		}
+		orders = append(orders, *o.model())
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorln("Rows: ", err)
+		logrus.Infoln("Ending repository.ExpiredOrders")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.ExpiredOrders")
+	return orders, nil
+}
+
+// debit subtracts funds from the wallet in their currency with a relative update guarded
+// by the balance, so concurrent debits can never drive it below zero. A user without a
+// wallet in the currency has nothing to spend.
+func debit(ctx context.Context, tx pgx.Tx, userID uuid.UUID, funds model.Money, date time.Time) error {
+	return debitAbove(ctx, tx, userID, funds, model.NewMoney(0, funds.CurrencyCode()), date)
+}
+
+// debitAbove is debit that also leaves at least minBalance in the wallet.
+func debitAbove(ctx context.Context, tx pgx.Tx, userID uuid.UUID, funds, minBalance model.Money, date time.Time) error {
+	query := `UPDATE public.wallet
+			  SET balance = balance - $1, last_update = $2
+			  WHERE user_id = $3 AND currency = $4 AND balance - $1 >= $5;`
+	tag, err := tx.Exec(ctx, query, funds, date, userID, funds.CurrencyCode(), minBalance)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 1 {
+		return nil
+	}
+
+	var exists bool
+	query = `SELECT EXISTS(SELECT 1 FROM public.user WHERE id = $1);`
+	if err := tx.QueryRow(ctx, query, userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return pgx.ErrNoRows
+	}
+	return Err.ErrInsufficientFunds
+}
+
+// credit adds funds to the wallet in their currency, opening it if needed.
+// The user must exist, otherwise pgx.ErrNoRows is returned.
+func credit(ctx context.Context, tx pgx.Tx, userID uuid.UUID, funds model.Money, date time.Time) error {
+	query := `INSERT INTO public.wallet(user_id, currency, balance, date_create, last_update)
+			  SELECT id, $2, $3, $4, $4
+			  FROM public.user
+			  WHERE id = $1
+			  ON CONFLICT (user_id, currency) DO UPDATE
+			  SET balance = public.wallet.balance + EXCLUDED.balance, last_update = EXCLUDED.last_update;`
+	tag, err := tx.Exec(ctx, query, userID, funds.CurrencyCode(), funds, date)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() != 1 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// releaseFunds returns amount of a reservation to its user and records the return in the ledger.
+func releaseFunds(ctx context.Context, tx pgx.Tx, o *order, amount model.Money, date time.Time) error {
+	if err := credit(ctx, tx, o.userID, amount, date); err != nil {
+		return err
+	}
+	return postOrder(ctx, tx, transactionRelease, o, amount, date)
+}
+
+const orderColumns = `order_id, user_id, service_id, service_name, date_create, funds, currency, status, reserved_at, expires_at, confirmed_at, cancelled_at, expired_at, captured, refunded, fee`
+
+func scanOrder(row pgx.Row) (*order, error) {
+	o := order{}
+	if err := row.Scan(&o.id, &o.userID, &o.serviceID, &o.serviceName, &o.dateCreate, &o.funds, &o.currency, &o.status, &o.reservedAt, &o.expiresAt, &o.confirmedAt, &o.cancelledAt, &o.expiredAt, &o.captured, &o.refunded, &o.fee); err != nil {
+		return nil, err
+	}
+	o.funds.Currency, o.captured.Currency, o.refunded.Currency, o.fee.Currency = o.currency, o.currency, o.currency, o.currency
+	return &o, nil
+}
+
+func (o *order) model() *model.Order {
+	return &model.Order{
+		ID:          o.id,
+		UserID:      o.userID,
+		ServiceID:   o.serviceID,
+		ServiceName: o.serviceName,
+		DateCreate:  o.dateCreate,
+		Funds:       o.funds,
+		Currency:    o.currency,
+		Status:      model.OrderStatus(o.status),
+		ReservedAt:  o.reservedAt,
+		ExpiresAt:   o.expiresAt,
+		ConfirmedAt: o.confirmedAt,
+		CancelledAt: o.cancelledAt,
+		ExpiredAt:   o.expiredAt,
+		Captured:    o.captured,
+		Refunded:    o.refunded,
+		Fee:         o.fee,
+	}
+}
+
+// resolveOrder moves a reserved order to a final status, stamping the time of the
+// transition, and returns it; a missing row means the order was never placed or
+// is no longer reserved.
+func resolveOrder(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, status model.OrderStatus, date time.Time) (*order, error) {
+	query := `UPDATE public.order
+			  SET status = $2::text,
+			      confirmed_at = CASE WHEN $2::text = 'confirmed' THEN $3::timestamp ELSE confirmed_at END,
+			      cancelled_at = CASE WHEN $2::text = 'cancelled' THEN $3::timestamp ELSE cancelled_at END,
+			      expired_at = CASE WHEN $2::text = 'expired' THEN $3::timestamp ELSE expired_at END
+			  WHERE order_id = $1 AND status = 'reserved'
+			  RETURNING ` + orderColumns + `;`
+	return scanOrder(tx.QueryRow(ctx, query, orderID, string(status), date))
+}
+
+// reportGroups are the columns a report is grouped by: the service name, service id and
+// user columns as selected, what they are grouped by, and the join they need. Services
+// are named as in the catalog, so revenue stays together when a service is renamed.
+// The house account, which has no service, is reported as model.FeeRevenue.
+var reportGroups = map[model.ReportGroup]struct{ columns, groupBy, join string }{
+	model.GroupServiceName: {feeName + ", NULL::uuid, NULL::uuid", feeName, ""},
+	model.GroupServiceID: {"CASE WHEN a.service_id IS NULL THEN '" + model.FeeRevenue + "' ELSE COALESCE(MAX(s.name), MAX(t.service_name)) END, a.service_id, NULL::uuid",
+		"a.service_id", ""},
+	// The user is the owner of the other side of the capture or refund: the hold, or the
+	// wallet for revenue carried over from before holds. The commission of an order is
+	// moved without the user and goes to the user of the order, so it nets out.
+	model.GroupUser: {"'', NULL::uuid, u.user_id", "u.user_id", `LEFT JOIN LATERAL (
+				SELECT ua.user_id FROM public.ledger_posting up
+				JOIN public.ledger_account ua ON ua.id = up.account_id
+				WHERE up.transaction_id = t.id AND ua.user_id IS NOT NULL
+				UNION ALL
+				SELECT o.user_id FROM public.order o
+				WHERE o.order_id = t.order_id
+				LIMIT 1) u ON true`},
+}
+
+// feeName names the group of a posting by service name.
+const feeName = "CASE WHEN a.kind = 'fee' THEN '" + model.FeeRevenue + "' ELSE COALESCE(s.name, t.service_name) END"
+
+func (r *repository) Report(ctx context.Context, q model.ReportQuery) (report []model.Report, err error) {
 	logrus.Infoln("Starting repository.Report")
 
-	query := `SELECT public.accounting.service_name, SUM(public.accounting.funds)
-			  FROM public.accounting
-			  WHERE date_part('year', public.accounting.date_create) = date_part('year', date($1)) AND date_part('month', public.accounting.date_create) = date_part('month', date($1)) AND public.accounting.service_id IS NOT NULL
-			  GROUP BY public.accounting.service_name
-			  ORDER BY SUM(public.accounting.funds) DESC;`
+	group, ok := reportGroups[q.GroupBy]
+	if !ok {
+		logrus.Errorf("Unknown report group %q\n", q.GroupBy)
+		logrus.Infoln("Ending repository.Report")
+		return nil, Err.ErrBadRequest
+	}
+	period := "$1::timestamp"
+	switch q.Period {
+	case model.PeriodDay, model.PeriodWeek, model.PeriodMonth:
+		period = fmt.Sprintf("date_trunc('%s', t.date_create)", q.Period)
+	case model.PeriodNone:
+	default:
+		logrus.Errorf("Unknown report period %q\n", q.Period)
+		logrus.Infoln("Ending repository.Report")
+		return nil, Err.ErrBadRequest
+	}
 
-	rows, err := r.dbConnection.Query(context.Background(), query, t)
+	conn, err := r.acquire(ctx)
 	if err != nil {
-		logrus.Errorf("Query %s: %s\n", t, err)
 		logrus.Infoln("Ending repository.Report")
 		return nil, err
 	}
+	defer conn.Release()
+
+	// Revenue of [$1, $2): captures less refunds, and fees, by the time they happened. Weeks start on Monday.
+	query := fmt.Sprintf(`SELECT %s, %s, a.currency, SUM(p.amount)
+			  FROM public.ledger_posting p
+			  JOIN public.ledger_account a ON a.id = p.account_id
+			  JOIN public.ledger_transaction t ON t.id = p.transaction_id
+			  LEFT JOIN public.service s ON s.id = a.service_id
+			  %s
+			  WHERE a.kind IN ('revenue', 'fee') AND t.date_create >= $1 AND t.date_create < $2
+			  GROUP BY 1, %s, a.currency
+			  ORDER BY 1, SUM(p.amount) DESC;`, period, group.columns, group.join, group.groupBy)
+
+	rows, err := conn.Query(ctx, query, q.From, q.To)
+	if err != nil {
+		logrus.Errorf("Query %+v: %s\n", q, err)
+		logrus.Infoln("Ending repository.Report")
+		return nil, err
+	}
+	defer rows.Close()
 
 	for rows.Next() {
 		r := model.Report{}
-		if err := rows.Scan(&r.ServiceName, &r.Revenue); err != nil {
+		if err := rows.Scan(&r.Period, &r.ServiceName, &r.ServiceID, &r.UserID, &r.Currency, &r.Revenue); err != nil {
 			logrus.Errorln("Scan: ", err)
 			logrus.Infoln("Ending repository.Report")
 			return nil, err
 		}
+		r.Revenue.Currency = r.Currency
 		report = append(report, r)
 	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorln("Rows: ", err)
+		logrus.Infoln("Ending repository.Report")
+		return nil, err
+	}
 
 	logrus.Infoln("Ending repository.Report")
 	return report, nil
 }
 
-func (r *repository) History(userID uuid.UUID, limit, offset int) ([]model.History, error) {
+// historyEntries selects the history of user $1 filtered by $2-$6 of History. One entry per
+// movement of the user's money, counting the held money as the user's: a purchase is shown
+// when it is captured from the hold, reservations and their releases are not shown, so once
+// no order is reserved the amounts add up to the balance. The amount is signed from the
+// user's side; the cost and the service name are kept as they were before entries had a type.
+const historyEntries = `WITH entries AS (
+				  SELECT p.id,
+				         a.user_id,
+				         CASE
+				             WHEN t.kind = 'enrollment' THEN 'top_up'
+				             WHEN t.kind = 'transfer' AND p.amount < 0 THEN 'transfer_out'
+				             WHEN t.kind = 'transfer' THEN 'transfer_in'
+				             WHEN t.kind = 'capture' THEN 'purchase'
+				             ELSE t.kind
+				         END AS entry_type,
+				         CASE
+				             WHEN t.kind IN ('capture', 'refund') THEN t.service_name
+				             WHEN t.kind = 'adjustment' THEN 'Adjusted'
+				             WHEN t.kind = 'withdrawal' THEN 'Withdrawn'
+				             WHEN t.kind = 'fee' THEN 'Fee'
+				             WHEN t.kind = 'transfer' AND p.amount < 0 THEN 'Transferred'
+				             ELSE 'Replenished'
+				         END AS service_name,
+				         p.amount,
+				         CASE t.kind WHEN 'refund' THEN -p.amount WHEN 'adjustment' THEN p.amount ELSE abs(p.amount) END AS cost,
+				         a.currency,
+				         t.date_create,
+				         p.comment,
+				         p.counterparty_kind,
+				         p.counterparty_id
+				  FROM public.ledger_posting p
+				  JOIN public.ledger_account a ON a.id = p.account_id
+				  JOIN public.ledger_transaction t ON t.id = p.transaction_id
+				  WHERE a.user_id = $1 AND (a.kind = 'user' AND t.kind NOT IN ('reserve', 'release') OR a.kind = 'hold' AND t.kind = 'capture')
+			  )`
+
+const historyFilters = `($2::timestamp IS NULL OR date_create >= $2)
+			  AND ($3::timestamp IS NULL OR date_create < $3)
+			  AND ($4::text[] IS NULL OR entry_type = ANY($4))
+			  AND ($5::decimal IS NULL OR abs(amount) >= $5)
+			  AND ($6::decimal IS NULL OR abs(amount) <= $6)`
+
+// History returns a page of q.Limit entries after q.Offset entries or after the cursor q.After.
+// The page is read one entry longer to learn whether another one follows.
+func (r *repository) History(ctx context.Context, q model.HistoryQuery) (*model.HistoryPage, error) {
 	logrus.Infoln("Starting repository.History")
 
-	query := `SELECT public.accounting.user_id,public.accounting.service_name, public.accounting.funds, public.accounting.date_create
-			  FROM public.accounting			 
-			  WHERE public.accounting.user_id = $1
-			  ORDER BY public.accounting.funds DESC, public.accounting.date_create 
-			  LIMIT $2 OFFSET $3;`
-	rows, err := r.dbConnection.Query(context.Background(), query, userID, limit, offset)
+	var from, to *time.Time
+	if !q.From.IsZero() {
+		from = &q.From
+	}
+	if !q.To.IsZero() {
+		to = &q.To
+	}
+	var types []string
+	for _, t := range q.Types {
+		types = append(types, string(t))
+	}
+	args := []any{q.UserID, from, to, types, q.MinAmount, q.MaxAmount, q.Limit + 1, q.Offset}
+
+	// Entries are ordered by a unique key, so a cursor is the key of the last entry of a page.
+	keys := []string{"date_create", "id"}
+	if q.Sort == model.SortAmount {
+		keys = []string{"abs(amount)", "date_create", "id"}
+	}
+	direction, compare := "ASC", ">"
+	if q.Desc {
+		direction, compare = "DESC", "<"
+	}
+	order := strings.Join(keys, " "+direction+", ") + " " + direction
+	after := "TRUE"
+	if c := q.After; c != nil {
+		values, casts := []any{c.Date, c.ID}, []string{"timestamp", "bigint"}
+		if q.Sort == model.SortAmount {
+			values, casts = append([]any{model.NewMoney(c.Amount, "")}, values...), append([]string{"decimal"}, casts...)
+		}
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			args = append(args, v)
+			placeholders[i] = fmt.Sprintf("$%d::%s", len(args), casts[i])
+		}
+		after = fmt.Sprintf("(%s) %s (%s)", strings.Join(keys, ", "), compare, strings.Join(placeholders, ", "))
+	}
+
+	conn, err := r.acquire(ctx)
 	if err != nil {
-		logrus.Errorf("Query %s: %s\n", userID, err)
 		logrus.Infoln("Ending repository.History")
 		return nil, err
 	}
+	defer conn.Release()
+
+	query := historyEntries + `
+			  SELECT id, user_id, entry_type, service_name, amount, cost, currency, date_create, comment, counterparty_kind, counterparty_id
+			  FROM entries
+			  WHERE ` + historyFilters + `
+			  AND ` + after + `
+			  ORDER BY ` + order + `
+			  LIMIT $7 OFFSET $8;`
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Query %s: %s\n", q.UserID, err)
+		logrus.Infoln("Ending repository.History")
+		return nil, err
+	}
+	defer rows.Close()
 
-	report := []model.History{}
+	page := &model.HistoryPage{Entries: []model.History{}}
+	var last history
 
 	for rows.Next() {
 		h := history{}
-		if err := rows.Scan(&h.id, &h.serviceName, &h.cost, &h.date); err != nil {
+		if err := rows.Scan(&h.postingID, &h.id, &h.kind, &h.serviceName, &h.amount, &h.cost, &h.currency, &h.date, &h.comment, &h.partyKind, &h.partyID); err != nil {
 			logrus.Errorln("Scan: ", err)
 			logrus.Infoln("Ending repository.History")
 			return nil, err
 		}
-		report = append(report, model.History{UserID: h.id, ServiceName: h.serviceName, Cost: h.cost, OrderDate: h.date})
+		if len(page.Entries) == q.Limit {
+			page.Next = last.cursor(q)
+			break
+		}
+		h.amount.Currency, h.cost.Currency = h.currency, h.currency
+		page.Entries = append(page.Entries, h.model())
+		last = h
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		logrus.Errorln("Rows: ", err)
+		logrus.Infoln("Ending repository.History")
+		return nil, err
+	}
+
+	if q.Total {
+		var total int
+		query := historyEntries + `
+				  SELECT count(*)
+				  FROM entries
+				  WHERE ` + historyFilters + `;`
+		if err := conn.QueryRow(ctx, query, args[:6]...).Scan(&total); err != nil {
+			logrus.Errorf("Count %s: %s\n", q.UserID, err)
+			logrus.Infoln("Ending repository.History")
+			return nil, err
+		}
+		page.Total = &total
 	}
 
 	logrus.Infoln("Ending repository.History")
-	return report, nil
+	return page, nil
 }
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	Err "Avito/internal/errors"
+	"Avito/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+// foreignKeyViolation is the SQLSTATE of a reference to a missing row, or of deleting a referenced one.
+const foreignKeyViolation = "23503"
+
+const serviceColumns = `id, name, price, currency, active, date_create, last_update`
+
+func scanService(row pgx.Row) (*model.Service, error) {
+	s := service{}
+	if err := row.Scan(&s.id, &s.name, &s.price, &s.currency, &s.active, &s.dateCreate, &s.lastUpdate); err != nil {
+		return nil, err
+	}
+	return s.model(), nil
+}
+
+// servicePrice splits the price of s into the columns it is stored in.
+func servicePrice(s model.Service) (*model.Money, *string) {
+	if s.Price == nil {
+		return nil, nil
+	}
+	currency := s.Price.CurrencyCode()
+	return s.Price, &currency
+}
+
+func (r *repository) CreateService(ctx context.Context, s model.Service) error {
+	logrus.Infoln("Starting repository.CreateService")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.CreateService")
+		return err
+	}
+	defer conn.Release()
+
+	price, currency := servicePrice(s)
+	query := `INSERT INTO public.service(id, name, price, currency, active, date_create, last_update)
+			  VALUES
+			  ($1, $2, $3, $4, $5, $6, $7);`
+	if _, err := conn.Exec(ctx, query, s.ID, s.Name, price, currency, s.Active, s.DateCreate, s.LastUpdate); err != nil {
+		logrus.Errorf("Exec %s: %s\n", s.ID, err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			err = Err.ErrServiceExists
+		}
+		logrus.Infoln("Ending repository.CreateService")
+		return err
+	}
+
+	logrus.Infoln("Ending repository.CreateService")
+	return nil
+}
+
+func (r *repository) GetService(ctx context.Context, id uuid.UUID) (*model.Service, error) {
+	logrus.Infoln("Starting repository.GetService")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.GetService")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `SELECT ` + serviceColumns + `
+			  FROM public.service
+			  WHERE id = $1;`
+	s, err := scanService(conn.QueryRow(ctx, query, id))
+	if err != nil {
+		logrus.Errorf("Scan %s: %s\n", id, err)
+		logrus.Infoln("Ending repository.GetService")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.GetService")
+	return s, nil
+}
+
+// Services returns the catalog ordered by name.
+func (r *repository) Services(ctx context.Context) ([]model.Service, error) {
+	logrus.Infoln("Starting repository.Services")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.Services")
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := `SELECT ` + serviceColumns + `
+			  FROM public.service
+			  ORDER BY name, id;`
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		logrus.Errorln("Query: ", err)
+		logrus.Infoln("Ending repository.Services")
+		return nil, err
+	}
+	defer rows.Close()
+
+	services := []model.Service{}
+	for rows.Next() {
+		s, err := scanService(rows)
+		if err != nil {
+			logrus.Errorln("Scan: ", err)
+			logrus.Infoln("Ending repository.Services")
+			return nil, err
+		}
+		services = append(services, *s)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorln("Rows: ", err)
+		logrus.Infoln("Ending repository.Services")
+		return nil, err
+	}
+
+	logrus.Infoln("Ending repository.Services")
+	return services, nil
+}
+
+// UpdateService replaces the name, price and active flag of a service. It fails with
+// pgx.ErrNoRows for a service not in the catalog.
+func (r *repository) UpdateService(ctx context.Context, s model.Service) error {
+	logrus.Infoln("Starting repository.UpdateService")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.UpdateService")
+		return err
+	}
+	defer conn.Release()
+
+	price, currency := servicePrice(s)
+	query := `UPDATE public.service
+			  SET name = $2, price = $3, currency = $4, active = $5, last_update = $6
+			  WHERE id = $1;`
+	tag, err := conn.Exec(ctx, query, s.ID, s.Name, price, currency, s.Active, s.LastUpdate)
+	if err != nil {
+		logrus.Errorf("Exec %s: %s\n", s.ID, err)
+		logrus.Infoln("Ending repository.UpdateService")
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		logrus.Infoln("Ending repository.UpdateService")
+		return pgx.ErrNoRows
+	}
+
+	logrus.Infoln("Ending repository.UpdateService")
+	return nil
+}
+
+// DeleteService removes a service that was never ordered. It fails with pgx.ErrNoRows for
+// a service not in the catalog and with Err.ErrServiceInUse for one with orders or revenue.
+func (r *repository) DeleteService(ctx context.Context, id uuid.UUID) error {
+	logrus.Infoln("Starting repository.DeleteService")
+
+	conn, err := r.acquire(ctx)
+	if err != nil {
+		logrus.Infoln("Ending repository.DeleteService")
+		return err
+	}
+	defer conn.Release()
+
+	query := `DELETE FROM public.service
+			  WHERE id = $1;`
+	tag, err := conn.Exec(ctx, query, id)
+	if err != nil {
+		logrus.Errorf("Exec %s: %s\n", id, err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			err = Err.ErrServiceInUse
+		}
+		logrus.Infoln("Ending repository.DeleteService")
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		logrus.Infoln("Ending repository.DeleteService")
+		return pgx.ErrNoRows
+	}
+
+	logrus.Infoln("Ending repository.DeleteService")
+	return nil
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Local keeps files in a directory, created on the first write.
+type Local struct {
+	dir string
+}
+
+func NewLocal(dir string) *Local {
+	return &Local{dir: dir}
+}
+
+// Put writes into a temporary file and renames it into place, so a failed or
+// half-written file is never visible under name.
+func (l *Local) Put(ctx context.Context, name string, write func(w io.Writer) error) error {
+	if err := os.MkdirAll(l.dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(l.dir, filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), l.path(name))
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
+
+// Open returns an error matching fs.ErrNotExist for a missing file.
+func (l *Local) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(l.path(name))
+}
+
+// DeleteBefore removes the files last modified before t, including temporary files
+// left behind by a crash.
+func (l *Local) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	entries, err := os.ReadDir(l.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return deleted, ctx.Err()
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || !info.ModTime().Before(t) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(l.dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (l *Local) path(name string) string {
+	return filepath.Join(l.dir, filepath.Base(name))
+}
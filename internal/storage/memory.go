@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+)
+
+// Memory keeps files in memory. It is meant for tests and single-replica setups
+// where losing reports on restart is acceptable.
+type Memory struct {
+	mu    sync.Mutex
+	files map[string]memoryFile
+	now   func() time.Time
+}
+
+type memoryFile struct {
+	data    []byte
+	created time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{files: map[string]memoryFile{}, now: time.Now}
+}
+
+func (m *Memory) Put(ctx context.Context, name string, write func(w io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[name] = memoryFile{data: buf.Bytes(), created: m.now()}
+	return nil
+}
+
+// Open returns an error matching fs.ErrNotExist for a missing file.
+func (m *Memory) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+	return io.NopCloser(bytes.NewReader(file.data)), nil
+}
+
+func (m *Memory) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for name, file := range m.files {
+		if file.created.Before(t) {
+			delete(m.files, name)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
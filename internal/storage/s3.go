@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config addresses a bucket of an S3-compatible service such as MinIO. Files are kept
+// under Prefix, e.g. "reports/", and only objects under it are ever deleted, so the bucket
+// may be shared with other data.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3 keeps files as objects of a bucket, addressed path-style
+// (endpoint/bucket/key) so that it works with MinIO without DNS setup.
+// Requests are signed with AWS Signature Version 4.
+type S3 struct {
+	endpoint *url.URL
+	config   S3Config
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3(config S3Config) (*S3, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3 endpoint must be an http(s) URL: %s", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("missing s3 bucket")
+	}
+	if config.Prefix == "" {
+		return nil, fmt.Errorf("missing s3 key prefix")
+	}
+	if !strings.HasSuffix(config.Prefix, "/") {
+		config.Prefix += "/"
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	return &S3{endpoint: endpoint, config: config, client: http.DefaultClient, now: time.Now}, nil
+}
+
+// Put spools the file to a temporary file first: S3 needs the length and the
+// checksum of the body before the upload starts.
+func (s *S3) Put(ctx context.Context, name string, write func(w io.Writer) error) error {
+	file, err := os.CreateTemp("", "avito-report-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+	if err := write(io.MultiWriter(file, hash)); err != nil {
+		return err
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	req, err := s.request(ctx, http.MethodPut, s.config.Prefix+name, nil, io.NopCloser(file), hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Open returns an error matching fs.ErrNotExist for a missing object.
+func (s *S3) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, s.config.Prefix+name, nil, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// DeleteBefore removes the objects under the prefix last modified before t.
+func (s *S3) DeleteBefore(ctx context.Context, t time.Time) (int, error) {
+	deleted := 0
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.config.Prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := s.request(ctx, http.MethodGet, "", query, nil, emptyPayloadHash)
+		if err != nil {
+			return deleted, err
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return deleted, err
+		}
+		list := listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return deleted, err
+		}
+
+		for _, object := range list.Contents {
+			if !strings.HasPrefix(object.Key, s.config.Prefix) || !object.LastModified.Before(t) {
+				continue
+			}
+			req, err := s.request(ctx, http.MethodDelete, object.Key, nil, nil, emptyPayloadHash)
+			if err != nil {
+				return deleted, err
+			}
+			resp, err := s.do(req)
+			if err != nil {
+				return deleted, err
+			}
+			resp.Body.Close()
+			deleted++
+		}
+
+		if !list.IsTruncated || list.NextContinuationToken == "" {
+			return deleted, nil
+		}
+		token = list.NextContinuationToken
+	}
+}
+
+// do sends a signed request and turns an error status into an error.
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	s.sign(req)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	resp.Body.Close()
+	err = fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	if resp.StatusCode == http.StatusNotFound {
+		err = fmt.Errorf("%w: %s", fs.ErrNotExist, err)
+	}
+	return nil, err
+}
+
+func (s *S3) request(ctx context.Context, method, key string, query url.Values, body io.ReadCloser, payloadHash string) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.config.Bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawPath = encodePath(u.Path)
+	u.RawQuery = encodeQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	return req, nil
+}
+
+// emptyPayloadHash is the SHA-256 of an empty body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// sign adds the AWS Signature Version 4 of req. Every header already set on req is signed,
+// together with Host and X-Amz-Date.
+func (s *S3) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		encodePath(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("x-amz-content-sha256"),
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	digest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(digest[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// encodeQuery sorts and encodes the query the way the signature expects.
+func encodeQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, encode(key, true)+"="+encode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func encodePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return encode(path, false)
+}
+
+// encode percent-encodes everything but the unreserved characters, and '/' unless slash is set.
+func encode(s string, slash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !slash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
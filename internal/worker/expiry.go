@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	Err "Avito/internal/errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type IController interface {
+	ExpireOrders(ctx context.Context, limit int) (int, error)
+}
+
+// Expiry periodically returns the funds of reservations whose TTL has passed.
+// Several replicas may run it at once: an order is released only by the first one.
+type Expiry struct {
+	controller IController
+	interval   time.Duration
+	batchSize  int
+}
+
+func NewExpiry(controller IController, interval time.Duration, batchSize int) (*Expiry, error) {
+	if controller == nil {
+		return nil, Err.ErrNoController
+	}
+	if interval <= 0 || batchSize <= 0 {
+		return nil, Err.ErrBadWorkerConfig
+	}
+	return &Expiry{controller: controller, interval: interval, batchSize: batchSize}, nil
+}
+
+// Run checks for expired reservations every interval until ctx is done.
+func (e *Expiry) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.expire(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expire drains expired reservations batch by batch. An error, including orders that could
+// not be released, ends the drain until the next tick, so that a batch of failing orders
+// is not fetched over and over.
+func (e *Expiry) expire(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := e.controller.ExpireOrders(ctx, e.batchSize)
+		if err != nil {
+			logrus.Errorln("ExpireOrders: ", err)
+			return
+		}
+		if n < e.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type controllerFunc func(ctx context.Context, limit int) (int, error)
+
+func (f controllerFunc) ExpireOrders(ctx context.Context, limit int) (int, error) {
+	return f(ctx, limit)
+}
+
+func TestExpiry(t *testing.T) {
+	t.Run("drains full batches", func(t *testing.T) {
+		var calls []int
+		found := []int{10, 10, 3}
+		e, err := NewExpiry(controllerFunc(func(ctx context.Context, limit int) (int, error) {
+			calls = append(calls, limit)
+			return found[len(calls)-1], nil
+		}), time.Minute, 10)
+		require.NoError(t, err)
+
+		e.expire(context.Background())
+		require.Equal(t, []int{10, 10, 10}, calls)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		calls := 0
+		e, err := NewExpiry(controllerFunc(func(ctx context.Context, limit int) (int, error) {
+			calls++
+			return 0, errors.New("connection refused")
+		}), time.Minute, 10)
+		require.NoError(t, err)
+
+		e.expire(context.Background())
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("run stops with context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		e, err := NewExpiry(controllerFunc(func(ctx context.Context, limit int) (int, error) {
+			cancel()
+			return 0, nil
+		}), time.Hour, 10)
+		require.NoError(t, err)
+
+		done := make(chan struct{})
+		go func() {
+			e.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run did not stop")
+		}
+	})
+
+	t.Run("bad config", func(t *testing.T) {
+		_, err := NewExpiry(controllerFunc(nil), 0, 10)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	Err "Avito/internal/errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type IReportJobController interface {
+	RunReportJob(ctx context.Context, timeout time.Duration) (bool, error)
+}
+
+// Reports builds queued reports in a fixed number of goroutines. Each one looks for a job
+// every interval, and right after finishing one. Several replicas may run it at once:
+// a job is built by one of them.
+type Reports struct {
+	controller IReportJobController
+	workers    int
+	interval   time.Duration
+	timeout    time.Duration
+}
+
+func NewReports(controller IReportJobController, workers int, interval, timeout time.Duration) (*Reports, error) {
+	if controller == nil {
+		return nil, Err.ErrNoController
+	}
+	if workers <= 0 || interval <= 0 || timeout <= 0 {
+		return nil, Err.ErrBadWorkerConfig
+	}
+	return &Reports{controller: controller, workers: workers, interval: interval, timeout: timeout}, nil
+}
+
+// Run builds reports until ctx is done; the reports in progress are then put back in the queue.
+func (r *Reports) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *Reports) work(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain builds reports one after another until the queue is empty.
+func (r *Reports) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		ran, err := r.controller.RunReportJob(ctx, r.timeout)
+		if err != nil {
+			logrus.Errorln("RunReportJob: ", err)
+			return
+		}
+		if !ran {
+			return
+		}
+	}
+}
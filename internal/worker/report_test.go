@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type runFunc func(ctx context.Context, timeout time.Duration) (bool, error)
+
+func (f runFunc) RunReportJob(ctx context.Context, timeout time.Duration) (bool, error) {
+	return f(ctx, timeout)
+}
+
+func TestReports(t *testing.T) {
+	t.Run("drains the queue", func(t *testing.T) {
+		queued := 3
+		calls := 0
+		r, err := NewReports(runFunc(func(ctx context.Context, timeout time.Duration) (bool, error) {
+			calls++
+			require.Equal(t, time.Minute, timeout)
+			if queued == 0 {
+				return false, nil
+			}
+			queued--
+			return true, nil
+		}), 1, time.Hour, time.Minute)
+		require.NoError(t, err)
+
+		r.drain(context.Background())
+		require.Zero(t, queued)
+		require.Equal(t, 4, calls)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		calls := 0
+		r, err := NewReports(runFunc(func(ctx context.Context, timeout time.Duration) (bool, error) {
+			calls++
+			return true, errors.New("connection refused")
+		}), 1, time.Hour, time.Minute)
+		require.NoError(t, err)
+
+		r.drain(context.Background())
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("runs every worker until the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var started int32
+		r, err := NewReports(runFunc(func(ctx context.Context, timeout time.Duration) (bool, error) {
+			if atomic.AddInt32(&started, 1) == 3 {
+				cancel()
+			}
+			<-ctx.Done()
+			return true, nil
+		}), 3, time.Hour, time.Minute)
+		require.NoError(t, err)
+
+		done := make(chan struct{})
+		go func() {
+			r.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run did not stop")
+		}
+		require.Equal(t, int32(3), atomic.LoadInt32(&started))
+	})
+
+	t.Run("bad config", func(t *testing.T) {
+		_, err := NewReports(runFunc(nil), 0, time.Second, time.Minute)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	Err "Avito/internal/errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+type IRetentionController interface {
+	PurgeReports(ctx context.Context, before time.Time) (int, error)
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error)
+}
+
+// Retention periodically deletes the reports older than maxAge and the idempotency keys
+// older than keyTTL. A zero maxAge keeps the reports.
+type Retention struct {
+	controller IRetentionController
+	interval   time.Duration
+	maxAge     time.Duration
+	keyTTL     time.Duration
+}
+
+func NewRetention(controller IRetentionController, interval, maxAge, keyTTL time.Duration) (*Retention, error) {
+	if controller == nil {
+		return nil, Err.ErrNoController
+	}
+	if interval <= 0 || maxAge < 0 || keyTTL <= 0 {
+		return nil, Err.ErrBadWorkerConfig
+	}
+	return &Retention{controller: controller, interval: interval, maxAge: maxAge, keyTTL: keyTTL}, nil
+}
+
+// Run deletes old reports and idempotency keys every interval until ctx is done.
+func (r *Retention) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if r.maxAge > 0 {
+			if _, err := r.controller.PurgeReports(ctx, time.Now().Add(-r.maxAge)); err != nil {
+				logrus.Errorln("PurgeReports: ", err)
+			}
+		}
+		if _, err := r.controller.PurgeIdempotencyKeys(ctx, time.Now().Add(-r.keyTTL)); err != nil {
+			logrus.Errorln("PurgeIdempotencyKeys: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
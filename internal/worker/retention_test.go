@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type purgeFunc func(ctx context.Context, before time.Time) (int, error)
+
+// retentionController purges reports and idempotency keys with separate functions.
+type retentionController struct {
+	reports purgeFunc
+	keys    purgeFunc
+}
+
+func (c retentionController) PurgeReports(ctx context.Context, before time.Time) (int, error) {
+	return c.reports(ctx, before)
+}
+
+func (c retentionController) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int, error) {
+	return c.keys(ctx, before)
+}
+
+func runOnce(ctx context.Context, t *testing.T, r *Retention) {
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop")
+	}
+}
+
+func TestRetention(t *testing.T) {
+	t.Run("purges reports and keys older than their age", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var reports, keys time.Time
+		r, err := NewRetention(retentionController{
+			reports: func(ctx context.Context, before time.Time) (int, error) {
+				reports = before
+				return 0, nil
+			},
+			keys: func(ctx context.Context, before time.Time) (int, error) {
+				keys = before
+				cancel()
+				return 0, nil
+			},
+		}, time.Hour, 24*time.Hour, time.Hour)
+		require.NoError(t, err)
+
+		runOnce(ctx, t, r)
+		require.WithinDuration(t, time.Now().Add(-24*time.Hour), reports, time.Minute)
+		require.WithinDuration(t, time.Now().Add(-time.Hour), keys, time.Minute)
+	})
+
+	t.Run("keeps reports without max age", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		purged := false
+		r, err := NewRetention(retentionController{
+			reports: func(ctx context.Context, before time.Time) (int, error) {
+				purged = true
+				return 0, nil
+			},
+			keys: func(ctx context.Context, before time.Time) (int, error) {
+				cancel()
+				return 0, nil
+			},
+		}, time.Hour, 0, time.Hour)
+		require.NoError(t, err)
+
+		runOnce(ctx, t, r)
+		require.False(t, purged)
+	})
+
+	t.Run("bad config", func(t *testing.T) {
+		_, err := NewRetention(retentionController{}, time.Hour, 24*time.Hour, 0)
+		require.Error(t, err)
+	})
+}